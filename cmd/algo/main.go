@@ -0,0 +1,284 @@
+// algo 不启动 HTTP 服务和数据库，在本地列出、执行、压测和验证算法库中的算法
+// 输入与 POST /api/algorithm/{name} 的请求体相同，可以直接用运行历史中保存的 input 复现线上调用
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"mango/internal/algorithm"
+)
+
+const usage = `usage: algo <command> [flags] [name]
+
+commands:
+  list                  列出所有算法（-category 按分类过滤）
+  run <name>            执行算法，输入来自 -input 指定的文件（默认标准输入）或 -example
+  bench [name]          压测并估计复杂度，省略 name 时列出所有 bench
+  verify [pair]         随机比较实现对，省略 pair 时验证全部
+
+每个命令的参数见 algo <command> -h，输出格式用 -format table|json 选择`
+
+// errUsage 参数错误，退出码为 2
+var errUsage = errors.New("usage error")
+
+func main() {
+	os.Exit(algo(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// algo 执行一条命令并返回退出码：成功或 -h 为 0，执行失败为 1，参数错误为 2
+func algo(args []string, stdout, stderr io.Writer) int {
+	if len(args) < 1 {
+		fmt.Fprintln(stderr, usage)
+		return 2
+	}
+	commands := map[string]func(args []string, out io.Writer) error{
+		"list":   list,
+		"run":    run,
+		"bench":  bench,
+		"verify": verify,
+	}
+	command, exist := commands[args[0]]
+	if !exist {
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s\n", args[0], usage)
+		return 2
+	}
+	if err := command(args[1:], stdout); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		fmt.Fprintln(stderr, "algo:", err)
+		if errors.Is(err, errUsage) {
+			return 2
+		}
+		return 1
+	}
+	return 0
+}
+
+// parse 解析参数，允许 flag 出现在位置参数之后，例如 algo run nQueens -trace
+// 未知或不合法的 flag 返回的错误包装了 errUsage，-h 返回的错误包装了 flag.ErrHelp
+func parse(fs *flag.FlagSet, args []string) (string, error) {
+	if err := fs.Parse(args); err != nil {
+		return "", fmt.Errorf("%w: %w", errUsage, err)
+	}
+	if fs.NArg() == 0 {
+		return "", nil
+	}
+	name := fs.Arg(0)
+	if err := fs.Parse(fs.Args()[1:]); err != nil {
+		return "", fmt.Errorf("%w: %w", errUsage, err)
+	}
+	if fs.NArg() > 0 {
+		return "", fmt.Errorf("%w: unexpected arguments %v", errUsage, fs.Args())
+	}
+	return name, nil
+}
+
+// formatFlag 添加 -format 参数
+func formatFlag(fs *flag.FlagSet) *string {
+	return fs.String("format", formatTable, "输出格式：table 或 json")
+}
+
+func checkFormat(format string) error {
+	if format != formatTable && format != formatJSON {
+		return fmt.Errorf("%w: format must be table or json, got %q", errUsage, format)
+	}
+	return nil
+}
+
+// list 列出算法
+func list(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	format := formatFlag(fs)
+	category := fs.String("category", "", "只列出该分类的算法")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	var specs []*algorithm.Spec
+	for _, spec := range algorithm.Default.List() {
+		if *category == "" || string(spec.Category) == *category {
+			specs = append(specs, spec)
+		}
+	}
+	if *format == formatJSON {
+		return writeJSON(out, specs)
+	}
+	return specTable(out, specs)
+}
+
+// readInput 读取 path 指定的文件，"-" 表示标准输入
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// run 执行一个算法，预算上限与异步任务相同（MaxAsyncBudget）
+func run(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	format := formatFlag(fs)
+	input := fs.String("input", "-", "输入 JSON 文件，- 表示标准输入")
+	example := fs.Bool("example", false, "使用算法自带的示例输入")
+	trace := fs.Bool("trace", false, "记录执行过程（算法需要支持 trace）")
+	traceLimit := fs.Int("trace-limit", 0, "最多记录的 trace 事件数，0 表示默认")
+	timeout := fs.Duration("timeout", 0, "时间预算，0 表示默认")
+	maxSteps := fs.Int64("max-steps", 0, "步数预算，0 表示默认")
+	maxMemory := fs.Int64("max-memory", 0, "内存预算（字节），0 表示默认")
+	name, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	if name == "" {
+		return fmt.Errorf("%w: algo run [flags] <name>", errUsage)
+	}
+	spec, err := algorithm.Default.Get(name)
+	if err != nil {
+		return err
+	}
+	var data []byte
+	if *example {
+		data = spec.Example
+	} else if data, err = readInput(*input); err != nil {
+		return err
+	}
+	budget := algorithm.Budget{Timeout: *timeout, MaxSteps: *maxSteps, MaxMemory: *maxMemory}.ClampTo(algorithm.MaxAsyncBudget)
+	ctx, meter, cancel := algorithm.WithBudget(context.Background(), budget)
+	defer cancel()
+	result := runResult{Algorithm: spec.Name}
+	if *trace {
+		if !spec.Traceable {
+			return fmt.Errorf("%w: %s does not support trace", errUsage, spec.Name)
+		}
+		result.Trace = algorithm.NewTrace(*traceLimit)
+		ctx = algorithm.WithTrace(ctx, result.Trace)
+	}
+	start := time.Now()
+	result.Result, err = spec.Execute(ctx, data)
+	result.Elapsed = time.Since(start).String()
+	result.Budget = meter.Usage()
+	if err != nil {
+		result.Error = err.Error()
+	}
+	if *format == formatJSON {
+		if werr := writeJSON(out, result); werr != nil {
+			return werr
+		}
+	} else if werr := result.table(out); werr != nil {
+		return werr
+	}
+	return err
+}
+
+// parseSizes 解析逗号分隔的规模列表
+func parseSizes(raw string) ([]int, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var sizes []int
+	for _, field := range strings.Split(raw, ",") {
+		size, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || size < 1 {
+			return nil, fmt.Errorf("%w: sizes must be positive integers, got %q", errUsage, field)
+		}
+		sizes = append(sizes, size)
+	}
+	return sizes, nil
+}
+
+// bench 压测一个 bench，省略名称时列出所有 bench
+func bench(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	format := formatFlag(fs)
+	sizesFlag := fs.String("sizes", "", "逗号分隔的输入规模，省略时使用 bench 的默认规模")
+	seed := fs.Int64("seed", 0, "随机种子，0 表示随机选择")
+	release := fs.String("release", "", "版本号，只写入报告")
+	timeout := fs.Duration("timeout", time.Minute, "整个压测的最长时间，超时返回已完成的规模")
+	name, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	if name == "" {
+		if *format == formatJSON {
+			return writeJSON(out, algorithm.Default.Benches())
+		}
+		return benchTable(out, algorithm.Default.Benches())
+	}
+	sizes, err := parseSizes(*sizesFlag)
+	if err != nil {
+		return err
+	}
+	b, err := algorithm.Default.GetBench(name)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	report, err := algorithm.RunBench(ctx, b, algorithm.BenchInput{Name: name, Sizes: sizes, Seed: *seed, Release: *release})
+	if err != nil {
+		return err
+	}
+	if *format == formatJSON {
+		return writeJSON(out, report)
+	}
+	_, err = io.WriteString(out, report.Table())
+	return err
+}
+
+// verify 随机比较实现对，有不一致或超时的实现对时退出码为 1
+func verify(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	format := formatFlag(fs)
+	seed := fs.Int64("seed", 0, "随机种子，0 表示随机选择")
+	cases := fs.Int("cases", 0, "每对实现的随机用例数，0 表示默认")
+	maxSize := fs.Int("max-size", 0, "随机输入的最大规模，0 表示默认")
+	timeout := fs.Duration("timeout", 0, "所有实现对共用的时间预算，0 表示默认")
+	pair, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	ctx, _, cancel := algorithm.WithBudget(context.Background(), algorithm.Budget{Timeout: *timeout}.ClampTo(algorithm.MaxAsyncBudget))
+	defer cancel()
+	reports, err := algorithm.Default.VerifyAll(ctx, algorithm.VerifyInput{Pair: pair, Seed: *seed, Cases: *cases, MaxSize: *maxSize})
+	if err != nil {
+		return err
+	}
+	if *format == formatJSON {
+		err = writeJSON(out, reports)
+	} else {
+		err = verifyTable(out, reports)
+	}
+	if err != nil {
+		return err
+	}
+	var failed int
+	for _, report := range reports {
+		if !report.Passed || report.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d pairs disagree or did not finish", failed, len(reports))
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestParse flag 可以出现在名称前后，多余的位置参数和未知 flag 都是参数错误
+func TestParse(t *testing.T) {
+	tests := []struct {
+		args  []string
+		name  string
+		trace bool
+		err   error
+	}{
+		{nil, "", false, nil},
+		{[]string{"nQueens"}, "nQueens", false, nil},
+		{[]string{"nQueens", "-trace"}, "nQueens", true, nil},
+		{[]string{"-trace", "nQueens"}, "nQueens", true, nil},
+		{[]string{"nQueens", "sudoku"}, "", false, errUsage},
+		{[]string{"-bogus"}, "", false, errUsage},
+		{[]string{"nQueens", "-trace=maybe"}, "", false, errUsage},
+		{[]string{"-h"}, "", false, flag.ErrHelp},
+	}
+	for _, test := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(&bytes.Buffer{})
+		trace := fs.Bool("trace", false, "")
+		name, err := parse(fs, test.args)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("parse(%q): err = %v, want %v", test.args, err, test.err)
+			}
+			continue
+		}
+		if err != nil || name != test.name || *trace != test.trace {
+			t.Errorf("parse(%q) = %q trace %v, %v, want %q trace %v", test.args, name, *trace, err, test.name, test.trace)
+		}
+	}
+}
+
+// TestAlgoExitCodes 成功为 0，算法失败或验证不通过为 1，参数错误为 2
+func TestAlgoExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"k":0,"n":9}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	valid := filepath.Join(dir, "valid.json")
+	if err := os.WriteFile(valid, []byte(`{"k":3,"n":9}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		args   []string
+		code   int
+		output string
+	}{
+		{nil, 2, ""},
+		{[]string{"nope"}, 2, ""},
+		{[]string{"list", "-category", "backtracking"}, 0, "combinationSum3"},
+		{[]string{"list", "-format", "xml"}, 2, ""},
+		{[]string{"list", "-bogus"}, 2, ""},
+		{[]string{"run"}, 2, ""},
+		{[]string{"run", "combinationSum3", "-example", "extra"}, 2, ""},
+		{[]string{"run", "-h"}, 0, ""},
+		{[]string{"run", "noSuchAlgorithm", "-example"}, 1, ""},
+		{[]string{"run", "combinationSum3", "-input", valid, "-format", "json"}, 0, `"count": 3`},
+		{[]string{"run", "combinationSum3", "-input", invalid}, 1, ""},
+		{[]string{"run", "combinationSum3", "-input", filepath.Join(dir, "missing.json")}, 1, ""},
+		{[]string{"bench", "-sizes", "0,x", "twoSum"}, 2, ""},
+		{[]string{"verify", "noSuchPair"}, 1, ""},
+		{[]string{"verify", "zSearch", "-seed", "1", "-cases", "20"}, 0, "zSearch"},
+		{[]string{"verify", "zSearch", "-timeout", "1ns"}, 1, "deadline"},
+	}
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		code := algo(test.args, &stdout, &stderr)
+		if code != test.code || !strings.Contains(stdout.String(), test.output) {
+			t.Errorf("algo %q: exit %d, want %d\nstdout: %s\nstderr: %s", test.args, code, test.code, stdout.String(), stderr.String())
+		}
+	}
+}
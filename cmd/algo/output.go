@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"mango/internal/algorithm"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+// runResult run 命令的输出，字段与 HTTP 接口的返回一致，另外带上实际耗时
+type runResult struct {
+	Algorithm string                 `json:"algorithm"`
+	Result    interface{}            `json:"result,omitempty"`
+	Trace     *algorithm.Trace       `json:"trace,omitempty"`
+	Budget    *algorithm.BudgetUsage `json:"budget,omitempty"`
+	Elapsed   string                 `json:"elapsed"`
+	Error     string                 `json:"error,omitempty"`
+}
+
+func writeJSON(out io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// compact 单行 JSON，用于表格的单元格
+func compact(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// fields 把结果的顶层字段按名称排序展开，结果不是对象时只有一行 result
+func fields(result interface{}) ([]string, map[string]json.RawMessage) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(compact(result)), &object); err != nil || object == nil {
+		return []string{"result"}, map[string]json.RawMessage{"result": json.RawMessage(compact(result))}
+	}
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, object
+}
+
+// table 每个结果字段一行，然后是预算消耗和 trace 事件
+func (r runResult) table(out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "algorithm\t%s\n", r.Algorithm)
+	if r.Error != "" {
+		fmt.Fprintf(w, "error\t%s\n", r.Error)
+	} else {
+		keys, object := fields(r.Result)
+		for _, key := range keys {
+			fmt.Fprintf(w, "%s\t%s\n", key, object[key])
+		}
+	}
+	fmt.Fprintf(w, "elapsed\t%s\n", r.Elapsed)
+	if b := r.Budget; b != nil {
+		fmt.Fprintf(w, "steps\t%d / %d\n", b.Steps, b.MaxSteps)
+		fmt.Fprintf(w, "memory\t%d / %d\n", b.Memory, b.MaxMemory)
+		if b.Exceeded != "" {
+			fmt.Fprintf(w, "exceeded\t%s\n", b.Exceeded)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if r.Trace == nil {
+		return nil
+	}
+	fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "step\tlabel\tstate")
+	for _, event := range r.Trace.Events {
+		fmt.Fprintf(w, "%d\t%s\t%s\n", event.Step, event.Label, compact(event.State))
+	}
+	if r.Trace.Truncated {
+		fmt.Fprintln(w, "...\ttruncated\t")
+	}
+	return w.Flush()
+}
+
+func specTable(out io.Writer, specs []*algorithm.Spec) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "name\tleetcode\tcategory\ttime\tspace\ttrace\ttitle")
+	for _, spec := range specs {
+		leetcode := "-"
+		if spec.LeetCode != 0 {
+			leetcode = strconv.Itoa(spec.LeetCode)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%v\t%s\n", spec.Name, leetcode, spec.Category, spec.Time, spec.Space, spec.Traceable, spec.Title)
+	}
+	return w.Flush()
+}
+
+func benchTable(out io.Writer, benches []*algorithm.Bench) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "name\tsizes\tmaxSize\tdescription")
+	for _, b := range benches {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", b.Name, compact(b.Sizes), b.MaxSize, b.Description)
+	}
+	return w.Flush()
+}
+
+// verifyTable 每对实现一行，不一致的实现对随后打印缩小后的输入和两边的结果
+func verifyTable(out io.Writer, reports []algorithm.VerifyReport) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "pair\tseed\tcases\tpassed\terror")
+	for _, report := range reports {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%v\t%s\n", report.Pair, report.Seed, report.Cases, report.Passed, report.Error)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	for _, report := range reports {
+		disagreement := report.Minimized
+		if disagreement == nil {
+			disagreement = report.Original
+		}
+		if disagreement == nil {
+			continue
+		}
+		fmt.Fprintf(out, "\n%s:\n  input:     %s\n  reference: %s\n  candidate: %s\n", report.Pair,
+			compact(disagreement.Input), compact(disagreement.Reference), compact(disagreement.Candidate))
+	}
+	return nil
+}
@@ -30,12 +30,19 @@ func InitializeApp(configPath string) (*app.App, error) {
 		// 仓库
 		repository.NewUserRepository,
 		repository.NewTextRiskLogRepository,
+		repository.NewAlgorithmBenchmarkRepository,
+		repository.NewAlgorithmTestCaseRepository,
+		repository.NewAlgorithmSubmissionRepository,
+		repository.NewAlgorithmRunRepository,
 		//wire.Bind(new(repository.UserRepository), new(*repository.UserRepositoryS)),
 
 		// 服务
 		service.NewUserService,
 		//wire.Bind(new(service.UserService), new(*service.UserServiceS)),
 		service.NewTextRiskLogService,
+		service.NewAlgorithmBenchmarkService,
+		service.NewAlgorithmJudgeService,
+		service.NewAlgorithmRunService,
 
 		// 处理器
 		controller.NewUserHandler,
@@ -40,7 +40,14 @@ func InitializeApp(configPath string) (*app.App, error) {
 	volcHandler := controller.NewVolcHandler(userService, textRiskLogService)
 	voiceHandler := controller.NewVoiceHandler(userService)
 	zhiPuHandler := controller.NewZhiPuHandler(userService, textRiskLogService)
-	algorithmHandler := controller.NewAlgorithmHandler(userService)
+	algorithmBenchmarkRepository := repository.NewAlgorithmBenchmarkRepository(db)
+	algorithmBenchmarkService := service.NewAlgorithmBenchmarkService(algorithmBenchmarkRepository)
+	algorithmTestCaseRepository := repository.NewAlgorithmTestCaseRepository(db)
+	algorithmSubmissionRepository := repository.NewAlgorithmSubmissionRepository(db)
+	algorithmJudgeService := service.NewAlgorithmJudgeService(algorithmTestCaseRepository, algorithmSubmissionRepository)
+	algorithmRunRepository := repository.NewAlgorithmRunRepository(db)
+	algorithmRunService := service.NewAlgorithmRunService(algorithmRunRepository)
+	algorithmHandler := controller.NewAlgorithmHandler(userService, algorithmBenchmarkService, algorithmJudgeService, algorithmRunService)
 	v := provideHandlers(userHandler, volcHandler, voiceHandler, zhiPuHandler, algorithmHandler)
 	serverServer := server.NewServer(configConfig, v...)
 	appApp := app.NewApp(configConfig, serverServer)
@@ -0,0 +1,15 @@
+// Package algorithm 常见算法题的纯 Go 实现，不依赖 HTTP，可以被 controller、任务、命令行直接调用
+//
+// 只需要比较的函数使用 cmp.Ordered 约束，需要加减运算的函数使用 Number 约束
+package algorithm
+
+// Integer 所有整数类型
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Number 支持加减乘运算的数值类型
+type Number interface {
+	Integer | ~float32 | ~float64
+}
@@ -0,0 +1,181 @@
+package algorithm
+
+import "cmp"
+
+// TwoSumResult 两数之和结果
+type TwoSumResult[T Number] struct {
+	Found   bool  `json:"found"`
+	Indices []int `json:"indices,omitempty"`
+	Values  []T   `json:"values,omitempty"`
+}
+
+func newTwoSumResult[T Number](nums []T, i, j int) TwoSumResult[T] {
+	return TwoSumResult[T]{
+		Found:   true,
+		Indices: []int{i, j},
+		Values:  []T{nums[i], nums[j]},
+	}
+}
+
+/*
+TwoSumBruteForce leetcode 1
+given an array of integers, return indexes of two numbers that sum up to the request target
+array :=[]int{1,3,4,5}  target := 6  return 0,3
+两层循环 O(n^2)
+*/
+func TwoSumBruteForce[T Number](nums []T, target T) TwoSumResult[T] {
+	return twoSumBruteForce(nums, target, nil)
+}
+
+func twoSumBruteForce[T Number](nums []T, target T, m *Meter) TwoSumResult[T] {
+	for i := 0; i < len(nums); i++ {
+		m.Step(int64(len(nums) - i - 1))
+		for j := i + 1; j < len(nums); j++ {
+			if nums[i]+nums[j] == target {
+				return newTwoSumResult(nums, i, j)
+			}
+		}
+	}
+	return TwoSumResult[T]{}
+}
+
+// TwoSumHashMap 哈希表记录已经遍历过的值 O(n)
+func TwoSumHashMap[T Number](nums []T, target T) TwoSumResult[T] {
+	hashMap := make(map[T]int, len(nums))
+	for i := 0; i < len(nums); i++ {
+		// 只查找当前元素之前的值，避免同一个元素被用两次
+		if j, exist := hashMap[target-nums[i]]; exist {
+			return newTwoSumResult(nums, j, i)
+		}
+		hashMap[nums[i]] = i
+	}
+	return TwoSumResult[T]{}
+}
+
+// StockResult 买卖一次股票的结果
+type StockResult[T Number] struct {
+	MaxProfit T   `json:"maxProfit"`
+	BuyDay    int `json:"buyDay"`
+	SellDay   int `json:"sellDay"`
+}
+
+/*
+MaxProfit leetcode 121 slide window
+given an array  the elements are the price of stock one day, you can only buy and sell once to find
+maximum the profit
+
+	array :=[]int{7,1,5,3,6,4}   the profit is 5(1,6)
+*/
+func MaxProfit[T Number](prices []T) StockResult[T] {
+	var result StockResult[T]
+	var leftPoint int
+	for rightPoint := range prices {
+		if prices[leftPoint] < prices[rightPoint] {
+			if profit := prices[rightPoint] - prices[leftPoint]; profit > result.MaxProfit {
+				result = StockResult[T]{MaxProfit: profit, BuyDay: leftPoint, SellDay: rightPoint}
+			}
+		} else {
+			leftPoint = rightPoint
+		}
+	}
+	return result
+}
+
+/*
+MaxSubarray leetcode 53 slide window
+given an integer array  ,find the contiguous subarray that has the largest sum and return the sum
+
+	array :=[]int{1, -4, 2, 3, 6, 4}   the profit is 2, 3, 6, 4 = 13
+
+全为负数时返回最大的元素，边界见 MaxSubarrayWindow；nums 为空时返回 0
+*/
+func MaxSubarray[T Number](nums []T) T {
+	if len(nums) == 0 {
+		return 0
+	}
+	sum, _ := MaxSubarrayWindow(nums)
+	return sum
+}
+
+/*
+FindMinRotated leetcode 153 二分查找
+假设一个按照升序排列的数组在某个点上进行了旋转（例如，数组 [0,1,2,4,5,6,7] 可能变成 [4,5,6,7,0,1,2]）。请找出其中最小的元素。
+返回最小值的下标，nums 不能为空
+*/
+func FindMinRotated[T cmp.Ordered](nums []T) int {
+	return findMinRotated(nums, nil)
+}
+
+func findMinRotated[T cmp.Ordered](nums []T, t *Trace) int {
+	var left int
+	var right = len(nums) - 1
+	for left < right {
+		mid := left + (right-left)/2
+		if t.Enabled() {
+			t.Record("probe", map[string]interface{}{"left": left, "mid": mid, "right": right, "goRight": nums[mid] > nums[right]})
+		}
+		// 如果中间元素大于最右元素，说明最小值在右半部分
+		if nums[mid] > nums[right] {
+			left = mid + 1
+		} else {
+			// 否则最小值在左半部分（包括中间元素）
+			right = mid
+		}
+	}
+	if t.Enabled() {
+		t.Record("found", map[string]interface{}{"index": left, "value": nums[left]})
+	}
+	return left
+}
+
+/*
+MaxArea leetcode 11 双指针
+container with the most water
+双指针 左指针在最左，右指针在最右，比较两边指针值的高度，如果左边高度比右边高则移动右指针，相反则移动左指针。移动指针宽度肯定变小，
+移动高度低的 有可能面积会变大，所以要移动高度低的指针
+*/
+func MaxArea[T Number](heights []T) T {
+	return maxArea(heights, nil)
+}
+
+func maxArea[T Number](heights []T, t *Trace) T {
+	var left int
+	var right = len(heights) - 1
+	var maxArea T
+	for left < right {
+		var area T
+		move := "left"
+		if heights[left] < heights[right] {
+			area = T(right-left) * heights[left]
+		} else {
+			area = T(right-left) * heights[right]
+			move = "right"
+		}
+		maxArea = max(maxArea, area)
+		if t.Enabled() {
+			t.Record("move-"+move, map[string]interface{}{"left": left, "right": right, "area": area, "maxArea": maxArea})
+		}
+		if move == "left" {
+			left++
+		} else {
+			right--
+		}
+	}
+	return maxArea
+}
+
+// MaxAreaBruteForce 枚举所有左右边界 O(n^2)
+func MaxAreaBruteForce[T Number](heights []T) T {
+	return maxAreaBruteForce(heights, nil)
+}
+
+func maxAreaBruteForce[T Number](heights []T, m *Meter) T {
+	var maxArea T
+	for i := 0; i < len(heights); i++ {
+		m.Step(int64(len(heights) - i - 1))
+		for j := i + 1; j < len(heights); j++ {
+			maxArea = max(maxArea, T(j-i)*min(heights[i], heights[j]))
+		}
+	}
+	return maxArea
+}
@@ -0,0 +1,297 @@
+package algorithm
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+const (
+	// DefaultMaxSolutions 回溯接口默认最多返回的解数
+	DefaultMaxSolutions = 1000
+	// DefaultSearchTimeout 回溯接口默认的超时时间
+	DefaultSearchTimeout = 5 * time.Second
+	// searchCheckEvery 每展开这么多个节点检查一次 context
+	searchCheckEvery = 1024
+)
+
+/*
+Backtrack 通用回溯框架
+three key points
+one : 路径path:记录作出选择的路径（由各个钩子闭包持有）
+two: 选择要素：当时可以做选择的要素 Choices()
+tree:结束条件：找到结果或者没有可做的选择
+
+			func backtrack(){
+			    if 满足条件{
+			       result.add(路径)
+			    }
+			    for 选择列表 {
+		            if 剪枝 { continue }
+		            选择要素
+		            backtrack()
+	                撤销选择要素
+		        }
+			}
+
+Accept 在每个节点调用，返回 true 时记录一个解（需要返回拷贝），记录后仍会继续展开 Choices
+Choices 的返回顺序就是探索顺序，相同输入的结果顺序确定；Prune 可以为 nil
+*/
+type Backtrack[C any, R any] struct {
+	Accept   func() (R, bool)
+	Choices  func() []C
+	Prune    func(choice C) bool
+	Choose   func(choice C)
+	Unchoose func(choice C)
+}
+
+// SearchStats 一次回溯搜索的统计，Truncated 表示达到解数上限，TimedOut 表示 context 结束时还没搜完
+type SearchStats struct {
+	Nodes     int64 `json:"nodes"`
+	Truncated bool  `json:"truncated"`
+	TimedOut  bool  `json:"timedOut"`
+}
+
+// Partial 搜索没有完成，结果只是部分解，不能当作相同输入的答案重放
+func (s SearchStats) Partial() bool {
+	return s.Truncated || s.TimedOut
+}
+
+// Run 从当前状态开始搜索，maxSolutions <= 0 表示不限制；ctx 结束时返回已经找到的解
+// ctx 带有 Meter 时每个节点计一步，预算耗尽时由 Meter 中止
+func (b *Backtrack[C, R]) Run(ctx context.Context, maxSolutions int) ([]R, SearchStats) {
+	var (
+		stats     SearchStats
+		solutions []R
+		explore   func() bool
+	)
+	meter := MeterFrom(ctx)
+	// explore 返回 false 表示整个搜索需要停止
+	explore = func() bool {
+		stats.Nodes++
+		meter.Step(1)
+		if stats.Nodes%searchCheckEvery == 0 && ctx.Err() != nil {
+			// 整个请求的预算耗尽时中止，只是搜索自己的超时则返回已找到的解
+			meter.Check()
+			stats.TimedOut = true
+			return false
+		}
+		if solution, ok := b.Accept(); ok {
+			solutions = append(solutions, solution)
+			if maxSolutions > 0 && len(solutions) >= maxSolutions {
+				stats.Truncated = true
+				return false
+			}
+		}
+		for _, choice := range b.Choices() {
+			if b.Prune != nil && b.Prune(choice) {
+				continue
+			}
+			b.Choose(choice)
+			next := explore()
+			b.Unchoose(choice)
+			if !next {
+				return false
+			}
+		}
+		return true
+	}
+	explore()
+	return solutions, stats
+}
+
+// SearchOptions 回溯接口共用的上限
+type SearchOptions struct {
+	MaxSolutions int `json:"maxSolutions" binding:"min=0,max=100000" doc:"最多返回的解数，0 表示默认 1000"`
+	TimeoutMs    int `json:"timeoutMs" binding:"min=0,max=30000" doc:"超时毫秒数，0 表示默认 5000，超时返回已找到的解"`
+}
+
+// SearchResult 回溯接口结果
+type SearchResult[R any] struct {
+	Count     int `json:"count"`
+	Solutions []R `json:"solutions"`
+	SearchStats
+}
+
+// runSearch 按 SearchOptions 的上限和超时执行搜索
+func runSearch[C any, R any](ctx context.Context, opts SearchOptions, search *Backtrack[C, R]) SearchResult[R] {
+	maxSolutions := opts.MaxSolutions
+	if maxSolutions == 0 {
+		maxSolutions = DefaultMaxSolutions
+	}
+	timeout := DefaultSearchTimeout
+	if opts.TimeoutMs > 0 {
+		timeout = time.Duration(opts.TimeoutMs) * time.Millisecond
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	solutions, stats := search.Run(ctx, maxSolutions)
+	if solutions == nil {
+		solutions = []R{}
+	}
+	return SearchResult[R]{Count: len(solutions), Solutions: solutions, SearchStats: stats}
+}
+
+// allSolutions 不限制解数和时间，没有解时返回空切片
+func allSolutions[C any, R any](search *Backtrack[C, R]) []R {
+	solutions, _ := search.Run(context.Background(), 0)
+	if solutions == nil {
+		return []R{}
+	}
+	return solutions
+}
+
+// combinationSearch 组合类问题共用的状态：从 nums[start:] 中选，path 是已选的数
+// reuse 为 true 时同一个数可以重复选；skipDuplicates 为 true 时同一层跳过相同的数，用于 nums 有重复时去重
+func combinationSearch(nums []int, reuse, skipDuplicates bool, accept func(path []int, sum int) bool, prune func(path []int, sum, next int) bool) *Backtrack[int, []int] {
+	var (
+		path   []int
+		starts = []int{0}
+		sum    int
+	)
+	return &Backtrack[int, []int]{
+		Accept: func() ([]int, bool) {
+			if !accept(path, sum) {
+				return nil, false
+			}
+			return append([]int{}, path...), true
+		},
+		Choices: func() []int {
+			start := starts[len(starts)-1]
+			choices := make([]int, 0, len(nums)-start)
+			for i := start; i < len(nums); i++ {
+				if skipDuplicates && i > start && nums[i] == nums[i-1] {
+					continue
+				}
+				choices = append(choices, i)
+			}
+			return choices
+		},
+		Prune: func(i int) bool { return prune(path, sum, nums[i]) },
+		Choose: func(i int) {
+			path = append(path, nums[i])
+			sum += nums[i]
+			//关键：可以重复选择时下一层仍然从 i 开始
+			if reuse {
+				starts = append(starts, i)
+			} else {
+				starts = append(starts, i+1)
+			}
+		},
+		Unchoose: func(i int) {
+			path = path[:len(path)-1] //回溯
+			sum -= nums[i]
+			starts = starts[:len(starts)-1]
+		},
+	}
+}
+
+// sortedPositive 去掉非正数（会导致无限递归）并升序排列
+func sortedPositive(candidates []int) []int {
+	nums := slices.DeleteFunc(slices.Clone(candidates), func(n int) bool { return n <= 0 })
+	slices.Sort(nums)
+	return nums
+}
+
+// combinationSumSearch candidates 去重后按升序搜索，和超过 target 的分支剪掉
+func combinationSumSearch(candidates []int, target int) *Backtrack[int, []int] {
+	nums := slices.Compact(sortedPositive(candidates))
+	return combinationSearch(nums, true, false,
+		func(path []int, sum int) bool { return sum == target },
+		func(path []int, sum, next int) bool { return sum+next > target })
+}
+
+/*
+CombinationSum leetcode 39   backTrack
+candidates 中的数字可以无限次重复选取，返回所有和为 target 的组合，组合内升序，组合之间按字典序
+*/
+func CombinationSum(candidates []int, target int) [][]int {
+	return allSolutions(combinationSumSearch(candidates, target))
+}
+
+func combinationSum2Search(candidates []int, target int) *Backtrack[int, []int] {
+	return combinationSearch(sortedPositive(candidates), false, true,
+		func(path []int, sum int) bool { return sum == target },
+		func(path []int, sum, next int) bool { return sum+next > target })
+}
+
+/*
+CombinationSum2 leetcode 40
+candidates 可能有重复数字，每个数字只能用一次，结果中不能有重复组合
+排序后同一层跳过与前一个相同的数
+*/
+func CombinationSum2(candidates []int, target int) [][]int {
+	return allSolutions(combinationSum2Search(candidates, target))
+}
+
+func combinationSum3Search(k, n int) *Backtrack[int, []int] {
+	return combinationSearch([]int{1, 2, 3, 4, 5, 6, 7, 8, 9}, false, false,
+		func(path []int, sum int) bool { return len(path) == k && sum == n },
+		func(path []int, sum, next int) bool { return len(path) == k || sum+next > n })
+}
+
+/*
+CombinationSum3 leetcode 216
+从 1-9 中选 k 个不同的数，和为 n
+*/
+func CombinationSum3(k, n int) [][]int {
+	return allSolutions(combinationSum3Search(k, n))
+}
+
+func subsetsSearch(nums []int) *Backtrack[int, []int] {
+	sorted := slices.Clone(nums)
+	slices.Sort(sorted)
+	return combinationSearch(sorted, false, true,
+		func(path []int, sum int) bool { return true },
+		func(path []int, sum, next int) bool { return false })
+}
+
+/*
+Subsets leetcode 78 / 90
+返回所有子集，nums 有重复时结果中不含重复子集；每个节点都是一个解
+*/
+func Subsets(nums []int) [][]int {
+	return allSolutions(subsetsSearch(nums))
+}
+
+/*
+permuteUniqueSearch leetcode 47
+排序后，相同的数只有在前一个已经被使用时才能选，保证相同的数按原顺序出现，从而去重
+*/
+func permuteUniqueSearch(nums []int) *Backtrack[int, []int] {
+	sorted := slices.Clone(nums)
+	slices.Sort(sorted)
+	used := make([]bool, len(sorted))
+	var path []int
+	return &Backtrack[int, []int]{
+		Accept: func() ([]int, bool) {
+			if len(path) != len(sorted) {
+				return nil, false
+			}
+			return append([]int{}, path...), true
+		},
+		Choices: func() []int {
+			var choices []int
+			for i := range sorted {
+				if used[i] || i > 0 && sorted[i] == sorted[i-1] && !used[i-1] {
+					continue
+				}
+				choices = append(choices, i)
+			}
+			return choices
+		},
+		Choose: func(i int) {
+			used[i] = true
+			path = append(path, sorted[i])
+		},
+		Unchoose: func(i int) {
+			used[i] = false
+			path = path[:len(path)-1]
+		},
+	}
+}
+
+// PermuteUnique 返回 nums 的所有不重复排列，按字典序
+func PermuteUnique(nums []int) [][]int {
+	return allSolutions(permuteUniqueSearch(nums))
+}
@@ -0,0 +1,272 @@
+package algorithm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+/*
+nQueensSearch leetcode 51 N 皇后
+逐行放置，每行选一列；列、主对角线 (row-col)、副对角线 (row+col) 已被占用的列剪掉
+*/
+func nQueensSearch(n int) *Backtrack[int, []string] {
+	var (
+		queens = make([]int, 0, n)
+		cols   = make([]bool, n)
+		diag   = make([]bool, 2*n)
+		anti   = make([]bool, 2*n)
+	)
+	return &Backtrack[int, []string]{
+		Accept: func() ([]string, bool) {
+			if len(queens) != n {
+				return nil, false
+			}
+			board := make([]string, n)
+			for row, col := range queens {
+				board[row] = strings.Repeat(".", col) + "Q" + strings.Repeat(".", n-col-1)
+			}
+			return board, true
+		},
+		Choices: func() []int {
+			if len(queens) == n {
+				return nil
+			}
+			choices := make([]int, n)
+			for col := range choices {
+				choices[col] = col
+			}
+			return choices
+		},
+		Prune: func(col int) bool {
+			row := len(queens)
+			return cols[col] || diag[row-col+n] || anti[row+col]
+		},
+		Choose: func(col int) {
+			row := len(queens)
+			cols[col], diag[row-col+n], anti[row+col] = true, true, true
+			queens = append(queens, col)
+		},
+		Unchoose: func(col int) {
+			queens = queens[:len(queens)-1]
+			row := len(queens)
+			cols[col], diag[row-col+n], anti[row+col] = false, false, false
+		},
+	}
+}
+
+// SolveNQueens 返回 n 皇后的所有摆法，每个摆法是 n 行字符串，'Q' 为皇后
+func SolveNQueens(n int) [][]string {
+	return allSolutions(nQueensSearch(n))
+}
+
+// SudokuBoard leetcode 格式的数独，9x9，空格为 "."
+type SudokuBoard [][]string
+
+// Validate 检查格式以及已填数字之间没有冲突
+func (b SudokuBoard) Validate() error {
+	if len(b) != 9 {
+		return fmt.Errorf("board must have 9 rows, got %d", len(b))
+	}
+	var rows, cols, boxes [9][10]bool
+	for r, row := range b {
+		if len(row) != 9 {
+			return fmt.Errorf("board[%d] must have 9 cells, got %d", r, len(row))
+		}
+		for c, cell := range row {
+			if cell == "." {
+				continue
+			}
+			if len(cell) != 1 || cell[0] < '1' || cell[0] > '9' {
+				return fmt.Errorf("board[%d][%d]: want 1-9 or \".\", got %q", r, c, cell)
+			}
+			d, box := cell[0]-'0', r/3*3+c/3
+			if rows[r][d] || cols[c][d] || boxes[box][d] {
+				return fmt.Errorf("board[%d][%d]: %s conflicts with another cell", r, c, cell)
+			}
+			rows[r][d], cols[c][d], boxes[box][d] = true, true, true
+		}
+	}
+	return nil
+}
+
+/*
+sudokuSearch leetcode 37 解数独
+按行优先顺序填空格，每个空格的选择是所在行、列、宫都还没有用过的数字
+*/
+func sudokuSearch(board SudokuBoard) *Backtrack[byte, SudokuBoard] {
+	var (
+		cells             [81]byte
+		empty             []int
+		rows, cols, boxes [9]uint16
+	)
+	for r := 0; r < 9; r++ {
+		for c := 0; c < 9; c++ {
+			if board[r][c] == "." {
+				empty = append(empty, r*9+c)
+				continue
+			}
+			d := board[r][c][0] - '0'
+			cells[r*9+c] = d
+			rows[r] |= 1 << d
+			cols[c] |= 1 << d
+			boxes[r/3*3+c/3] |= 1 << d
+		}
+	}
+	filled := 0
+	return &Backtrack[byte, SudokuBoard]{
+		Accept: func() (SudokuBoard, bool) {
+			if filled != len(empty) {
+				return nil, false
+			}
+			solved := make(SudokuBoard, 9)
+			for r := range solved {
+				solved[r] = make([]string, 9)
+				for c := range solved[r] {
+					solved[r][c] = string('0' + cells[r*9+c])
+				}
+			}
+			return solved, true
+		},
+		Choices: func() []byte {
+			if filled == len(empty) {
+				return nil
+			}
+			r, c := empty[filled]/9, empty[filled]%9
+			used := rows[r] | cols[c] | boxes[r/3*3+c/3]
+			var choices []byte
+			for d := byte(1); d <= 9; d++ {
+				if used&(1<<d) == 0 {
+					choices = append(choices, d)
+				}
+			}
+			return choices
+		},
+		Choose: func(d byte) {
+			r, c := empty[filled]/9, empty[filled]%9
+			cells[r*9+c] = d
+			rows[r] |= 1 << d
+			cols[c] |= 1 << d
+			boxes[r/3*3+c/3] |= 1 << d
+			filled++
+		},
+		Unchoose: func(d byte) {
+			filled--
+			r, c := empty[filled]/9, empty[filled]%9
+			cells[r*9+c] = 0
+			rows[r] &^= 1 << d
+			cols[c] &^= 1 << d
+			boxes[r/3*3+c/3] &^= 1 << d
+		},
+	}
+}
+
+// SolveSudoku 返回数独的第一个解，无解时返回 nil；调用方需要保证 board 通过 Validate
+func SolveSudoku(board SudokuBoard) SudokuBoard {
+	solutions, _ := sudokuSearch(board).Run(context.Background(), 1)
+	if len(solutions) == 0 {
+		return nil
+	}
+	return solutions[0]
+}
+
+// LetterBoard leetcode 格式的字母网格，每个格子是一个字符
+type LetterBoard [][]string
+
+// Validate 网格非空、每行等长、每个格子恰好一个字符
+func (b LetterBoard) Validate() error {
+	if len(b) == 0 || len(b[0]) == 0 {
+		return errors.New("board must not be empty")
+	}
+	for r, row := range b {
+		if len(row) != len(b[0]) {
+			return fmt.Errorf("board[%d] has %d cells, want %d", r, len(row), len(b[0]))
+		}
+		for c, cell := range row {
+			if len([]rune(cell)) != 1 {
+				return fmt.Errorf("board[%d][%d]: want a single character, got %q", r, c, cell)
+			}
+		}
+	}
+	return nil
+}
+
+// grid 转换为字符网格，调用方需要先通过 Validate
+func (b LetterBoard) grid() [][]rune {
+	grid := make([][]rune, len(b))
+	for r, row := range b {
+		grid[r] = make([]rune, len(row))
+		for c, cell := range row {
+			grid[r][c] = []rune(cell)[0]
+		}
+	}
+	return grid
+}
+
+/*
+wordSearch leetcode 79 单词搜索
+路径从任意与 word[0] 相同的格子出发，每一步走到上下左右相邻、未访问过且与下一个字符相同的格子
+解是路径上格子的 [row, col]
+*/
+func wordSearch(board LetterBoard, word string) *Backtrack[[2]int, [][2]int] {
+	letters := []rune(word)
+	grid := make([][]rune, len(board))
+	visited := make([][]bool, len(board))
+	for r := range board {
+		visited[r] = make([]bool, len(board[r]))
+		for _, cell := range board[r] {
+			grid[r] = append(grid[r], []rune(cell)[0])
+		}
+	}
+	var path [][2]int
+	match := func(r, c int) bool {
+		return r >= 0 && r < len(grid) && c >= 0 && c < len(grid[r]) &&
+			!visited[r][c] && grid[r][c] == letters[len(path)]
+	}
+	return &Backtrack[[2]int, [][2]int]{
+		Accept: func() ([][2]int, bool) {
+			if len(path) != len(letters) {
+				return nil, false
+			}
+			return append([][2]int{}, path...), true
+		},
+		Choices: func() [][2]int {
+			if len(path) == len(letters) {
+				return nil
+			}
+			var choices [][2]int
+			if len(path) == 0 {
+				for r := range board {
+					for c := range board[r] {
+						choices = append(choices, [2]int{r, c})
+					}
+				}
+				return choices
+			}
+			last := path[len(path)-1]
+			for _, d := range [4][2]int{{-1, 0}, {0, 1}, {1, 0}, {0, -1}} {
+				choices = append(choices, [2]int{last[0] + d[0], last[1] + d[1]})
+			}
+			return choices
+		},
+		Prune: func(cell [2]int) bool { return !match(cell[0], cell[1]) },
+		Choose: func(cell [2]int) {
+			visited[cell[0]][cell[1]] = true
+			path = append(path, cell)
+		},
+		Unchoose: func(cell [2]int) {
+			visited[cell[0]][cell[1]] = false
+			path = path[:len(path)-1]
+		},
+	}
+}
+
+// WordExist 返回 word 在网格中的第一条路径，不存在时返回 nil；word 为空时返回空路径
+func WordExist(board LetterBoard, word string) [][2]int {
+	solutions, _ := wordSearch(board, word).Run(context.Background(), 1)
+	if len(solutions) == 0 {
+		return nil
+	}
+	return solutions[0]
+}
@@ -0,0 +1,171 @@
+package algorithm
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// CombinationSum3Input 组合总和 III 输入
+type CombinationSum3Input struct {
+	K int `json:"k" binding:"required,min=1,max=9" doc:"选取的数字个数"`
+	N int `json:"n" binding:"required,min=1,max=60" doc:"目标和"`
+	SearchOptions
+}
+
+// PermutationInput 全排列输入，可以有重复数字
+type PermutationInput struct {
+	Nums []int `json:"nums" binding:"required,min=1,max=10"`
+	SearchOptions
+}
+
+// SubsetsInput 子集输入，可以有重复数字
+type SubsetsInput struct {
+	Nums []int `json:"nums" binding:"max=20"`
+	SearchOptions
+}
+
+// NQueensInput N 皇后输入
+type NQueensInput struct {
+	N int `json:"n" binding:"required,min=1,max=14"`
+	SearchOptions
+}
+
+// SudokuInput 解数独输入
+type SudokuInput struct {
+	Board SudokuBoard `json:"board" binding:"required" doc:"9x9，每个格子是 1-9 或 ."`
+	SearchOptions
+}
+
+// Validate 检查数独格式和已填数字
+func (in *SudokuInput) Validate() error {
+	return in.Board.Validate()
+}
+
+// MaxWordBoard 单词搜索网格的最大边长
+const MaxWordBoard = 20
+
+// WordSearchInput 单词搜索输入
+type WordSearchInput struct {
+	Board LetterBoard `json:"board" binding:"required" doc:"每个格子是一个字符"`
+	Word  string      `json:"word" binding:"required,max=100"`
+	SearchOptions
+}
+
+// Validate 检查网格形状
+func (in *WordSearchInput) Validate() error {
+	if len(in.Board) > MaxWordBoard || len(in.Board) > 0 && len(in.Board[0]) > MaxWordBoard {
+		return fmt.Errorf("board must be at most %dx%d", MaxWordBoard, MaxWordBoard)
+	}
+	return in.Board.Validate()
+}
+
+// uniqueCount nums 的不重复排列数 n! / (c1! * c2! * ...)
+func uniqueCount(nums []int) int {
+	counts := make(map[int]int)
+	result := 1
+	for i, n := range nums {
+		counts[n]++
+		// 逐步乘 (i+1) / counts[n]，每一步都是整数
+		result = result * (i + 1) / counts[n]
+	}
+	return result
+}
+
+var exampleSudoku = SudokuBoard{
+	{"5", "3", ".", ".", "7", ".", ".", ".", "."},
+	{"6", ".", ".", "1", "9", "5", ".", ".", "."},
+	{".", "9", "8", ".", ".", ".", ".", "6", "."},
+	{"8", ".", ".", ".", "6", ".", ".", ".", "3"},
+	{"4", ".", ".", "8", ".", "3", ".", ".", "1"},
+	{"7", ".", ".", ".", "2", ".", ".", ".", "6"},
+	{".", "6", ".", ".", ".", ".", "2", "8", "."},
+	{".", ".", ".", "4", "1", "9", ".", ".", "5"},
+	{".", ".", ".", ".", "8", ".", ".", "7", "9"},
+}
+
+func init() {
+	Default.MustRegister(
+		Define(Spec{
+			Name: "combinationSum2", Title: "Combination Sum II", LeetCode: 40,
+			Category: CategoryBacktracking, Time: "O(2^n)", Space: "O(n)",
+		}, CombinationSumInput{Candidates: []int{10, 1, 2, 7, 6, 1, 5}, Target: 8},
+			func(ctx context.Context, in *CombinationSumInput) (SearchResult[[]int], error) {
+				return runSearch(ctx, in.SearchOptions, combinationSum2Search(in.Candidates, in.Target)), nil
+			}),
+		Define(Spec{
+			Name: "combinationSum3", Title: "Combination Sum III", LeetCode: 216,
+			Category: CategoryBacktracking, Time: "O(C(9, k))", Space: "O(k)",
+		}, CombinationSum3Input{K: 3, N: 9},
+			func(ctx context.Context, in *CombinationSum3Input) (SearchResult[[]int], error) {
+				return runSearch(ctx, in.SearchOptions, combinationSum3Search(in.K, in.N)), nil
+			}),
+		Define(Spec{
+			Name: "permuteUnique", Title: "Permutations II", LeetCode: 47,
+			Category: CategoryBacktracking, Time: "O(n * n!)", Space: "O(n)",
+		}, PermutationInput{Nums: []int{1, 1, 2}},
+			func(ctx context.Context, in *PermutationInput) (SearchResult[[]int], error) {
+				return runSearch(ctx, in.SearchOptions, permuteUniqueSearch(in.Nums)), nil
+			}),
+		Define(Spec{
+			Name: "subsets", Title: "Subsets II", LeetCode: 90,
+			Category: CategoryBacktracking, Time: "O(n * 2^n)", Space: "O(n)",
+		}, SubsetsInput{Nums: []int{1, 2, 2}},
+			func(ctx context.Context, in *SubsetsInput) (SearchResult[[]int], error) {
+				return runSearch(ctx, in.SearchOptions, subsetsSearch(in.Nums)), nil
+			}),
+		Define(Spec{
+			Name: "nQueens", Title: "N-Queens", LeetCode: 51,
+			Category: CategoryBacktracking, Time: "O(n!)", Space: "O(n)",
+		}, NQueensInput{N: 4},
+			func(ctx context.Context, in *NQueensInput) (SearchResult[[]string], error) {
+				return runSearch(ctx, in.SearchOptions, nQueensSearch(in.N)), nil
+			}),
+		Define(Spec{
+			Name: "solveSudoku", Title: "Sudoku Solver", LeetCode: 37,
+			Category: CategoryBacktracking, Time: "O(9^m)", Space: "O(m)",
+		}, SudokuInput{Board: exampleSudoku, SearchOptions: SearchOptions{MaxSolutions: 1}},
+			func(ctx context.Context, in *SudokuInput) (SearchResult[SudokuBoard], error) {
+				return runSearch(ctx, in.SearchOptions, sudokuSearch(in.Board)), nil
+			}),
+		Define(Spec{
+			Name: "wordSearch", Title: "Word Search", LeetCode: 79,
+			Category: CategoryBacktracking, Time: "O(m * n * 3^L)", Space: "O(L)",
+		}, WordSearchInput{Board: LetterBoard{{"A", "B", "C", "E"}, {"S", "F", "C", "S"}, {"A", "D", "E", "E"}}, Word: "ABCCED"},
+			func(ctx context.Context, in *WordSearchInput) (SearchResult[[][2]int], error) {
+				return runSearch(ctx, in.SearchOptions, wordSearch(in.Board, in.Word)), nil
+			}),
+	)
+
+	Default.MustRegisterPair(
+		DefinePair("combinationSum2", "combinationSum2 剪枝回溯 vs 枚举子集后按和过滤",
+			func(r *rand.Rand, size int) CombinationSumInput {
+				return CombinationSumInput{Candidates: randInts(r, 1+min(size, 14), 1, 8), Target: 1 + r.Intn(20)}
+			},
+			func(in CombinationSumInput) [][]int {
+				return slices.DeleteFunc(Subsets(in.Candidates), func(subset []int) bool {
+					var sum int
+					for _, n := range subset {
+						sum += n
+					}
+					return sum != in.Target
+				})
+			},
+			func(in CombinationSumInput) [][]int { return CombinationSum2(in.Candidates, in.Target) },
+			nil, nil),
+		DefinePair("permuteUnique", "permuteUnique 排列数 vs 多重集排列公式（并检查有序且不重复）",
+			func(r *rand.Rand, size int) []int { return randInts(r, 1+min(size, 7), 0, 3) },
+			uniqueCount,
+			func(nums []int) int {
+				permutations := PermuteUnique(nums)
+				for i := 1; i < len(permutations); i++ {
+					if slices.Compare(permutations[i-1], permutations[i]) >= 0 {
+						return -1
+					}
+				}
+				return len(permutations)
+			},
+			nil, func(nums []int) [][]int { return shrinkInts(nums, 1) }),
+	)
+}
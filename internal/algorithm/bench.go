@@ -0,0 +1,340 @@
+package algorithm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	// benchMinDuration 每个规模至少运行的总时长
+	benchMinDuration = 20 * time.Millisecond
+	// benchMaxIterations 每个规模最多运行的次数
+	benchMaxIterations = 1 << 20
+	// MaxBenchSizes 一次最多测试的规模个数
+	MaxBenchSizes = 20
+)
+
+// Bench 一个可以按输入规模测速的实现
+type Bench struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Sizes       []int  `json:"sizes"`
+	MaxSize     int    `json:"maxSize"`
+
+	prepare func(r *rand.Rand, size int) func()
+}
+
+// DefineBench 创建 Bench，generate 生成规模为 size 的输入（不计入耗时），run 是被测实现
+func DefineBench[In any](name, description string, sizes []int, maxSize int,
+	generate func(r *rand.Rand, size int) In, run func(In)) *Bench {
+	return &Bench{
+		Name:        name,
+		Description: description,
+		Sizes:       sizes,
+		MaxSize:     maxSize,
+		prepare: func(r *rand.Rand, size int) func() {
+			in := generate(r, size)
+			return func() { run(in) }
+		},
+	}
+}
+
+// BenchInput benchmark 接口参数
+type BenchInput struct {
+	Name    string `json:"name" binding:"required"`
+	Sizes   []int  `json:"sizes" binding:"max=20,dive,min=1"`
+	Seed    int64  `json:"seed"`
+	Release string `json:"release" binding:"max=64" doc:"版本号，用于跨版本比较"`
+}
+
+// BenchRow 一个规模的测量结果
+// AllocsPerOp 和 BytesPerOp 来自进程级的 runtime.MemStats，同一进程中其他请求的分配也会计入，只是近似值，
+// 不参与复杂度拟合；benchmark 之间互斥执行，至少不会互相干扰
+type BenchRow struct {
+	Size        int     `json:"size"`
+	Iterations  int     `json:"iterations"`
+	NsPerOp     float64 `json:"nsPerOp"`
+	AllocsPerOp float64 `json:"allocsPerOp"`
+	BytesPerOp  float64 `json:"bytesPerOp"`
+}
+
+// ComplexityFit 一种复杂度模型的拟合误差
+type ComplexityFit struct {
+	Model    string  `json:"model"`
+	Residual float64 `json:"residual"`
+}
+
+// BenchReport 一次 benchmark 的结果
+type BenchReport struct {
+	Name    string     `json:"name"`
+	Release string     `json:"release,omitempty"`
+	Seed    int64      `json:"seed"`
+	Rows    []BenchRow `json:"rows"`
+	// Complexity 残差最小的模型，Exponent 是 log(t)-log(n) 的斜率，Fits 按残差从小到大排列
+	Complexity string          `json:"complexity"`
+	Exponent   float64         `json:"exponent"`
+	Fits       []ComplexityFit `json:"fits"`
+	Partial    bool            `json:"partial,omitempty"`
+}
+
+// benchSlot 同一时间只运行一个 benchmark，避免并发的 benchmark 互相抢占 CPU、计入对方的内存分配
+var benchSlot = make(chan struct{}, 1)
+
+// RunBench 依次测量每个规模，ctx 结束时返回已完成的部分；其他 benchmark 正在运行时等待它结束
+func RunBench(ctx context.Context, bench *Bench, in BenchInput) (BenchReport, error) {
+	sizes := in.Sizes
+	if len(sizes) == 0 {
+		sizes = bench.Sizes
+	}
+	for _, size := range sizes {
+		if size > bench.MaxSize {
+			return BenchReport{}, fmt.Errorf("%w: size %d exceeds %s limit %d", ErrInvalidInput, size, bench.Name, bench.MaxSize)
+		}
+	}
+	sizes = append([]int(nil), sizes...)
+	sort.Ints(sizes)
+	if in.Seed == 0 {
+		in.Seed = time.Now().UnixNano()
+	}
+	report := BenchReport{Name: bench.Name, Release: in.Release, Seed: in.Seed}
+	select {
+	case benchSlot <- struct{}{}:
+		defer func() { <-benchSlot }()
+	case <-ctx.Done():
+		report.Partial = true
+		return report, nil
+	}
+	r := rand.New(rand.NewSource(in.Seed))
+	for _, size := range sizes {
+		if ctx.Err() != nil {
+			report.Partial = true
+			break
+		}
+		report.Rows = append(report.Rows, measure(bench.prepare(r, size), size))
+	}
+	report.Fits, report.Exponent = fitComplexity(report.Rows)
+	if len(report.Fits) > 0 {
+		report.Complexity = report.Fits[0].Model
+	}
+	return report, nil
+}
+
+// measure 先跑一次预热，再按倍数增加次数直到总时长超过 benchMinDuration
+func measure(run func(), size int) BenchRow {
+	run()
+	var before, after runtime.MemStats
+	iterations := 1
+	for {
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		for i := 0; i < iterations; i++ {
+			run()
+		}
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+		if elapsed >= benchMinDuration || iterations >= benchMaxIterations {
+			n := float64(iterations)
+			return BenchRow{
+				Size:        size,
+				Iterations:  iterations,
+				NsPerOp:     float64(elapsed.Nanoseconds()) / n,
+				AllocsPerOp: float64(after.Mallocs-before.Mallocs) / n,
+				BytesPerOp:  float64(after.TotalAlloc-before.TotalAlloc) / n,
+			}
+		}
+		iterations *= 2
+	}
+}
+
+// complexityModels 候选复杂度，值为 log(f(n))
+var complexityModels = []struct {
+	name string
+	logF func(n float64) float64
+}{
+	{"O(1)", func(n float64) float64 { return 0 }},
+	{"O(log n)", func(n float64) float64 { return math.Log(math.Log2(n) + 1) }},
+	{"O(n)", func(n float64) float64 { return math.Log(n) }},
+	{"O(n log n)", func(n float64) float64 { return math.Log(n) + math.Log(math.Log2(n)+1) }},
+	{"O(n^2)", func(n float64) float64 { return 2 * math.Log(n) }},
+	{"O(n^3)", func(n float64) float64 { return 3 * math.Log(n) }},
+	{"O(2^n)", func(n float64) float64 { return n * math.Ln2 }},
+}
+
+// fitComplexity 在对数空间拟合 t = c * f(n)：log c 取均值，残差为均方误差
+// 同时返回 log(t) 对 log(n) 的最小二乘斜率
+func fitComplexity(rows []BenchRow) ([]ComplexityFit, float64) {
+	if len(rows) < 2 {
+		return nil, 0
+	}
+	logN := make([]float64, len(rows))
+	logT := make([]float64, len(rows))
+	for i, row := range rows {
+		logN[i] = math.Log(float64(row.Size))
+		logT[i] = math.Log(math.Max(row.NsPerOp, 1))
+	}
+	fits := make([]ComplexityFit, 0, len(complexityModels))
+	for _, model := range complexityModels {
+		var mean float64
+		for i, row := range rows {
+			mean += logT[i] - model.logF(float64(row.Size))
+		}
+		mean /= float64(len(rows))
+		var residual float64
+		for i, row := range rows {
+			d := logT[i] - model.logF(float64(row.Size)) - mean
+			residual += d * d
+		}
+		fits = append(fits, ComplexityFit{Model: model.name, Residual: residual / float64(len(rows))})
+	}
+	sort.SliceStable(fits, func(i, j int) bool { return fits[i].Residual < fits[j].Residual })
+	return fits, slope(logN, logT)
+}
+
+func slope(x, y []float64) float64 {
+	var mx, my float64
+	for i := range x {
+		mx += x[i]
+		my += y[i]
+	}
+	mx /= float64(len(x))
+	my /= float64(len(y))
+	var num, den float64
+	for i := range x {
+		num += (x[i] - mx) * (y[i] - my)
+		den += (x[i] - mx) * (x[i] - mx)
+	}
+	if den == 0 {
+		return 0
+	}
+	return num / den
+}
+
+// Table 以文本表格输出结果
+func (r BenchReport) Table() string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "size\titerations\tns/op\tallocs/op\tB/op\t")
+	for _, row := range r.Rows {
+		fmt.Fprintf(w, "%d\t%d\t%.1f\t%.1f\t%.1f\t\n", row.Size, row.Iterations, row.NsPerOp, row.AllocsPerOp, row.BytesPerOp)
+	}
+	w.Flush()
+	fmt.Fprintf(&sb, "%s: estimated %s, log-log slope %.2f\n", r.Name, r.Complexity, r.Exponent)
+	return sb.String()
+}
+
+// RegisterBench 注册 Bench，名称重复时报错
+func (r *Registry) RegisterBench(bench *Bench) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.benches == nil {
+		r.benches = make(map[string]*Bench)
+	}
+	if _, exist := r.benches[bench.Name]; exist {
+		return fmt.Errorf("algorithm: bench %s already registered", bench.Name)
+	}
+	r.benches[bench.Name] = bench
+	return nil
+}
+
+// MustRegisterBench 注册 Bench，失败时 panic，用于 init 阶段
+func (r *Registry) MustRegisterBench(benches ...*Bench) {
+	for _, bench := range benches {
+		if err := r.RegisterBench(bench); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// GetBench 按名称查找 Bench
+func (r *Registry) GetBench(name string) (*Bench, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	bench, exist := r.benches[name]
+	if !exist {
+		return nil, fmt.Errorf("%w: bench %s", ErrNotFound, name)
+	}
+	return bench, nil
+}
+
+// Benches 按名称排序返回所有 Bench
+func (r *Registry) Benches() []*Bench {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	benches := make([]*Bench, 0, len(r.benches))
+	for _, bench := range r.benches {
+		benches = append(benches, bench)
+	}
+	sort.Slice(benches, func(i, j int) bool { return benches[i].Name < benches[j].Name })
+	return benches
+}
+
+// geometricSizes 从 from 开始每次乘 2 直到 to
+func geometricSizes(from, to int) []int {
+	var sizes []int
+	for n := from; n <= to; n *= 2 {
+		sizes = append(sizes, n)
+	}
+	return sizes
+}
+
+// linearSizes 从 from 到 to 步长为 step
+func linearSizes(from, to, step int) []int {
+	var sizes []int
+	for n := from; n <= to; n += step {
+		sizes = append(sizes, n)
+	}
+	return sizes
+}
+
+func init() {
+	ints := func(r *rand.Rand, size int) []int { return randInts(r, size, 0, 1000) }
+	Default.MustRegisterBench(
+		DefineBench("climbStairsRecursive", "climbStairs 递归解", linearSizes(10, 30, 4), 35,
+			func(r *rand.Rand, size int) int { return size },
+			func(n int) { ClimbStairsRecursive(n) }),
+		DefineBench("climbStairsDP", "climbStairs dp 解", geometricSizes(1000, 128000), 1000000,
+			func(r *rand.Rand, size int) int { return size },
+			func(n int) { ClimbStairsDP(n) }),
+		DefineBench("sumUpToTarget", "two sum 暴力解（无解时的最坏情况）", geometricSizes(64, 4096), 20000,
+			func(r *rand.Rand, size int) []int { return ints(r, size) },
+			func(nums []int) { TwoSumBruteForce(nums, -1) }),
+		DefineBench("sumUpToTargetHashMap", "two sum 哈希表（无解时的最坏情况）", geometricSizes(64, 65536), 1000000,
+			func(r *rand.Rand, size int) []int { return ints(r, size) },
+			func(nums []int) { TwoSumHashMap(nums, -1) }),
+		DefineBench("containerWithMostWater", "双指针", geometricSizes(1000, 512000), 1000000,
+			ints, func(heights []int) { MaxArea(heights) }),
+		DefineBench("containerWithMostWaterBF", "暴力解", geometricSizes(64, 4096), 20000,
+			ints, func(heights []int) { MaxAreaBruteForce(heights) }),
+		DefineBench("findMinimumInRotatedArray", "二分查找", geometricSizes(1024, 1<<20), 1<<22,
+			func(r *rand.Rand, size int) []int {
+				nums := make([]int, size)
+				for i := range nums {
+					nums[i] = i
+				}
+				k := r.Intn(size)
+				return append(nums[k:], nums[:k]...)
+			},
+			func(nums []int) { FindMinRotated(nums) }),
+		DefineBench("longestIncreasingSubsequence", "O(n^2) dp", geometricSizes(64, 4096), 20000,
+			ints, func(nums []int) { LongestIncreasingSubsequence(nums) }),
+		DefineBench("coinCharge", "零钱兑换，硬币数固定为 5", geometricSizes(1000, 256000), 1000000,
+			func(r *rand.Rand, size int) int { return size },
+			func(amount int) { CoinChange([]int{1, 2, 5, 10, 25}, amount) }),
+		DefineBench("courseTopology", "链式依赖的拓扑排序", geometricSizes(1000, 128000), 1000000,
+			func(r *rand.Rand, size int) [][2]int {
+				pairs := make([][2]int, size-1)
+				for i := range pairs {
+					pairs[i] = [2]int{i + 1, i}
+				}
+				return pairs
+			},
+			func(pairs [][2]int) { CourseSchedule(len(pairs)+1, pairs) }),
+	)
+}
@@ -0,0 +1,317 @@
+package algorithm
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+)
+
+/*
+PartitionPoint 在 [lo, hi) 中二分查找第一个使 pred 为 true 的 x，都为 false 时返回 hi
+pred 必须单调：存在分界点 p，x < p 时为 false，x >= p 时为 true
+"二分答案" 的题目把可行性判断写成 pred，例如 MinEatingSpeed、SplitArray
+*/
+func PartitionPoint(lo, hi int, pred func(int) bool) int {
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if pred(mid) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// LowerBound 有序数组中第一个不小于 target 的下标，都小于 target 时返回 len(nums)
+func LowerBound[T cmp.Ordered](nums []T, target T) int {
+	return PartitionPoint(0, len(nums), func(i int) bool { return nums[i] >= target })
+}
+
+// UpperBound 有序数组中第一个大于 target 的下标，都不大于 target 时返回 len(nums)
+func UpperBound[T cmp.Ordered](nums []T, target T) int {
+	return PartitionPoint(0, len(nums), func(i int) bool { return nums[i] > target })
+}
+
+/*
+SearchRange leetcode 34 在排序数组中查找元素的第一个和最后一个位置
+[LowerBound, UpperBound) 就是 target 出现的范围，为空时返回 nil
+*/
+func SearchRange[T cmp.Ordered](nums []T, target T) *Window {
+	left, right := LowerBound(nums, target), UpperBound(nums, target)
+	if left == right {
+		return nil
+	}
+	return &Window{Left: left, Right: right - 1}
+}
+
+// checkSorted nums 非递减，strict 时严格递增
+func checkSorted[T cmp.Ordered](field string, nums []T, strict bool) error {
+	for i := 1; i < len(nums); i++ {
+		if nums[i] < nums[i-1] || strict && nums[i] == nums[i-1] {
+			order := "non-decreasing"
+			if strict {
+				order = "strictly increasing"
+			}
+			return fmt.Errorf("%s must be %s, but %s[%d] = %v follows %v", field, order, field, i, nums[i], nums[i-1])
+		}
+	}
+	return nil
+}
+
+/*
+checkRotated nums 是非递减（strict 时严格递增）的数组旋转得到的
+把 nums 看成环，下降（nums[i] > nums[i+1]，包括末尾到开头）至多一处；strict 时环上相邻元素还不能相等
+*/
+func checkRotated[T cmp.Ordered](field string, nums []T, strict bool) error {
+	var descents int
+	for i := range nums {
+		next := nums[(i+1)%len(nums)]
+		if nums[i] > next {
+			descents++
+		}
+		if strict && len(nums) > 1 && nums[i] == next {
+			return fmt.Errorf("%s must not contain duplicates, %v appears twice", field, next)
+		}
+	}
+	if descents > 1 {
+		return fmt.Errorf("%s is not a rotated sorted array", field)
+	}
+	return nil
+}
+
+/*
+SearchRotated leetcode 81 搜索旋转排序数组 II，可以有重复元素
+mid 把数组分成两半，至少有一半是有序的，判断 target 是否在有序的那一半里
+nums[left] == nums[mid] == nums[right] 时无法判断，两端各收缩一格，最坏 O(n)
+返回 target 的一个下标，没有时返回 -1
+*/
+func SearchRotated(nums []int, target int) int {
+	return searchRotated(nums, target, nil)
+}
+
+func searchRotated(nums []int, target int, t *Trace) int {
+	left, right := 0, len(nums)-1
+	for left <= right {
+		mid := left + (right-left)/2
+		if t.Enabled() {
+			t.Record("probe", map[string]interface{}{"left": left, "mid": mid, "right": right, "value": nums[mid]})
+		}
+		switch {
+		case nums[mid] == target:
+			return mid
+		case nums[left] == nums[mid] && nums[mid] == nums[right]:
+			left, right = left+1, right-1
+		case nums[left] <= nums[mid]:
+			// 左半有序
+			if nums[left] <= target && target < nums[mid] {
+				right = mid - 1
+			} else {
+				left = mid + 1
+			}
+		default:
+			// 右半有序
+			if nums[mid] < target && target <= nums[right] {
+				left = mid + 1
+			} else {
+				right = mid - 1
+			}
+		}
+	}
+	return -1
+}
+
+/*
+FindPeak leetcode 162 寻找峰值，nums[-1] = nums[n] = -∞，相邻元素不相等
+nums[mid] < nums[mid+1] 时右边一定有峰值（一直上升到末尾也是峰值），否则左边（含 mid）一定有
+*/
+func FindPeak(nums []int) int {
+	return findPeak(nums, nil)
+}
+
+func findPeak(nums []int, t *Trace) int {
+	return PartitionPoint(0, len(nums)-1, func(mid int) bool {
+		down := nums[mid] > nums[mid+1]
+		if t.Enabled() {
+			t.Record("probe", map[string]interface{}{"mid": mid, "value": nums[mid], "next": nums[mid+1], "goLeft": down})
+		}
+		return down
+	})
+}
+
+// isPeak nums[i] 大于两侧的邻居
+func isPeak(nums []int, i int) bool {
+	return i >= 0 && i < len(nums) && (i == 0 || nums[i] > nums[i-1]) && (i == len(nums)-1 || nums[i] > nums[i+1])
+}
+
+// ErrEmptyArrays 两个数组都为空时没有中位数
+var ErrEmptyArrays = errors.New("at least one array must be non-empty")
+
+/*
+MedianSortedArrays leetcode 4 寻找两个正序数组的中位数 O(log min(m, n))
+在较短的数组 a 上二分切分点 i，b 的切分点 j = (m+n+1)/2 - i，使左半部分 a[:i] + b[:j] 恰好有一半元素
+切分合法的条件是 a[i-1] <= b[j] 且 b[j-1] <= a[i]，返回中位数以及 nums1、nums2 上的切分点
+*/
+func MedianSortedArrays(nums1, nums2 []int) (median float64, cut1, cut2 int, err error) {
+	if len(nums1) > len(nums2) {
+		median, cut2, cut1, err = MedianSortedArrays(nums2, nums1)
+		return median, cut1, cut2, err
+	}
+	m, n := len(nums1), len(nums2)
+	if m+n == 0 {
+		return 0, 0, 0, ErrEmptyArrays
+	}
+	at := func(nums []int, i int) int {
+		switch {
+		case i < 0:
+			return math.MinInt
+		case i >= len(nums):
+			return math.MaxInt
+		}
+		return nums[i]
+	}
+	half := (m + n + 1) / 2
+	i := PartitionPoint(0, m, func(i int) bool { return at(nums1, i) >= at(nums2, half-i-1) })
+	j := half - i
+	left := max(at(nums1, i-1), at(nums2, j-1))
+	if (m+n)%2 == 1 {
+		return float64(left), i, j, nil
+	}
+	right := min(at(nums1, i), at(nums2, j))
+	return (float64(left) + float64(right)) / 2, i, j, nil
+}
+
+// MedianBruteForce 合并两个有序数组后取中间，两个数组不能都为空
+func MedianBruteForce(nums1, nums2 []int) float64 {
+	merged := make([]int, 0, len(nums1)+len(nums2))
+	i, j := 0, 0
+	for i < len(nums1) || j < len(nums2) {
+		if j == len(nums2) || i < len(nums1) && nums1[i] <= nums2[j] {
+			merged = append(merged, nums1[i])
+			i++
+		} else {
+			merged = append(merged, nums2[j])
+			j++
+		}
+	}
+	n := len(merged)
+	if n%2 == 1 {
+		return float64(merged[n/2])
+	}
+	return (float64(merged[n/2-1]) + float64(merged[n/2])) / 2
+}
+
+// eatingHours 速度为 speed 时吃完所有香蕉需要的小时数
+func eatingHours(piles []int, speed int) int {
+	var hours int
+	for _, pile := range piles {
+		hours += (pile + speed - 1) / speed
+	}
+	return hours
+}
+
+/*
+MinEatingSpeed leetcode 875 爱吃香蕉的珂珂
+速度越快用时越少，二分答案：在 [1, max(piles)] 中找第一个 h 小时内能吃完的速度
+O(n log max(piles))，h 不能小于堆数
+*/
+func MinEatingSpeed(piles []int, h int) int {
+	return minEatingSpeed(piles, h, nil)
+}
+
+func minEatingSpeed(piles []int, h int, t *Trace) int {
+	var most int
+	for _, pile := range piles {
+		most = max(most, pile)
+	}
+	return PartitionPoint(1, most, func(speed int) bool {
+		hours := eatingHours(piles, speed)
+		if t.Enabled() {
+			t.Record("probe", map[string]interface{}{"speed": speed, "hours": hours, "feasible": hours <= h})
+		}
+		return hours <= h
+	})
+}
+
+// MinEatingSpeedLinear 从 1 开始逐个尝试速度
+func MinEatingSpeedLinear(piles []int, h int) int {
+	speed := 1
+	for eatingHours(piles, speed) > h {
+		speed++
+	}
+	return speed
+}
+
+// splitParts 每段和不超过 limit 时贪心切分的段数
+func splitParts(nums []int, limit int) int {
+	parts, sum := 1, 0
+	for _, x := range nums {
+		if sum+x > limit {
+			parts, sum = parts+1, 0
+		}
+		sum += x
+	}
+	return parts
+}
+
+/*
+SplitArray leetcode 410 分割数组的最大值，nums 非负，1 <= k <= len(nums)
+最大段和越大，需要的段数越少，二分答案：在 [max(nums), sum(nums)] 中找第一个贪心切分不超过 k 段的上限
+再按这个上限切出恰好 k 段：超过上限时切一刀，剩下的元素刚好够每段一个时每个元素单独成段
+返回最大段和以及每段的边界
+*/
+func SplitArray(nums []int, k int) (int, []Window) {
+	return splitArray(nums, k, nil)
+}
+
+func splitArray(nums []int, k int, t *Trace) (int, []Window) {
+	var most, sum int
+	for _, x := range nums {
+		most, sum = max(most, x), sum+x
+	}
+	limit := PartitionPoint(most, sum, func(limit int) bool {
+		parts := splitParts(nums, limit)
+		if t.Enabled() {
+			t.Record("probe", map[string]interface{}{"limit": limit, "parts": parts, "feasible": parts <= k})
+		}
+		return parts <= k
+	})
+	parts := make([]Window, 0, k)
+	start, sum := 0, 0
+	for i, x := range nums {
+		if i > start && (sum+x > limit || len(nums)-i == k-len(parts)-1) {
+			parts = append(parts, Window{Left: start, Right: i - 1})
+			start, sum = i, 0
+		}
+		sum += x
+	}
+	return limit, append(parts, Window{Left: start, Right: len(nums) - 1})
+}
+
+// SplitArrayDP dp[j][i] 前 i 个数分成 j 段的最小最大段和 O(k * n^2)
+func SplitArrayDP(nums []int, k int) int {
+	n := len(nums)
+	prefix := make([]int, n+1)
+	for i, x := range nums {
+		prefix[i+1] = prefix[i] + x
+	}
+	dp := make([][]int, k+1)
+	for j := range dp {
+		dp[j] = make([]int, n+1)
+		for i := range dp[j] {
+			dp[j][i] = math.MaxInt
+		}
+	}
+	dp[0][0] = 0
+	for j := 1; j <= k; j++ {
+		for i := j; i <= n; i++ {
+			for p := j - 1; p < i; p++ {
+				if dp[j-1][p] != math.MaxInt {
+					dp[j][i] = min(dp[j][i], max(dp[j-1][p], prefix[i]-prefix[p]))
+				}
+			}
+		}
+	}
+	return dp[k][n]
+}
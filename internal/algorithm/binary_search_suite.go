@@ -0,0 +1,420 @@
+package algorithm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// SortedSearchInput 有序数组中查找 target
+type SortedSearchInput struct {
+	Nums   []int `json:"nums" binding:"max=100000" doc:"非递减"`
+	Target int   `json:"target"`
+}
+
+// Validate 检查有序
+func (in *SortedSearchInput) Validate() error {
+	return checkSorted("nums", in.Nums, false)
+}
+
+// RotatedInput 严格递增数组旋转后的输入
+type RotatedInput struct {
+	Nums []int `json:"nums" binding:"required,min=1,max=10000" doc:"严格递增的数组在某处旋转，没有重复元素"`
+}
+
+// Validate 检查是旋转后的严格递增数组
+func (in *RotatedInput) Validate() error {
+	return checkRotated("nums", in.Nums, true)
+}
+
+// RotatedSearchInput 可以有重复元素的旋转数组中查找 target
+type RotatedSearchInput struct {
+	Nums   []int `json:"nums" binding:"required,min=1,max=10000" doc:"非递减的数组在某处旋转"`
+	Target int   `json:"target"`
+}
+
+// Validate 检查是旋转后的非递减数组
+func (in *RotatedSearchInput) Validate() error {
+	return checkRotated("nums", in.Nums, false)
+}
+
+// PeakInput 寻找峰值输入
+type PeakInput struct {
+	Nums []int `json:"nums" binding:"required,min=1,max=10000" doc:"相邻元素不相等"`
+}
+
+// Validate 相邻元素不相等
+func (in *PeakInput) Validate() error {
+	for i := 1; i < len(in.Nums); i++ {
+		if in.Nums[i] == in.Nums[i-1] {
+			return fmt.Errorf("nums[%d] equals its neighbor, adjacent elements must differ", i)
+		}
+	}
+	return nil
+}
+
+// MedianInput 两个有序数组
+type MedianInput struct {
+	Nums1 []int `json:"nums1" binding:"max=10000,dive,min=-1000000000,max=1000000000" doc:"非递减"`
+	Nums2 []int `json:"nums2" binding:"max=10000,dive,min=-1000000000,max=1000000000" doc:"非递减"`
+}
+
+// Validate 两个数组都有序且不都为空
+func (in *MedianInput) Validate() error {
+	if len(in.Nums1)+len(in.Nums2) == 0 {
+		return ErrEmptyArrays
+	}
+	return errors.Join(checkSorted("nums1", in.Nums1, false), checkSorted("nums2", in.Nums2, false))
+}
+
+// KokoInput 爱吃香蕉的珂珂输入
+type KokoInput struct {
+	Piles []int `json:"piles" binding:"required,min=1,max=10000,dive,min=1,max=1000000000"`
+	H     int   `json:"h" binding:"required,min=1,max=1000000000" doc:"小时数，不少于堆数"`
+}
+
+// Validate 每小时只能吃一堆，h 不能少于堆数
+func (in *KokoInput) Validate() error {
+	if in.H < len(in.Piles) {
+		return fmt.Errorf("h %d must be at least the number of piles %d", in.H, len(in.Piles))
+	}
+	return nil
+}
+
+// SplitInput 分割数组输入
+type SplitInput struct {
+	Nums []int `json:"nums" binding:"required,min=1,max=1000,dive,min=0,max=1000000"`
+	K    int   `json:"k" binding:"required,min=1,max=50" doc:"分成的段数，不超过数组长度"`
+}
+
+// Validate k 不超过数组长度
+func (in *SplitInput) Validate() error {
+	if in.K > len(in.Nums) {
+		return fmt.Errorf("k %d exceeds the length of nums %d", in.K, len(in.Nums))
+	}
+	return nil
+}
+
+// SearchRangeResult searchRange 结果，range 为 nil 表示 target 不存在
+type SearchRangeResult struct {
+	LowerBound int     `json:"lowerBound"`
+	UpperBound int     `json:"upperBound"`
+	Range      *Window `json:"range,omitempty"`
+}
+
+// IndexResult searchRotatedDuplicates 结果，没有找到时 index 为 -1
+type IndexResult struct {
+	Found bool `json:"found"`
+	Index int  `json:"index"`
+}
+
+// PeakResult findPeakElement 结果
+type PeakResult struct {
+	Index int `json:"index"`
+	Value int `json:"value"`
+}
+
+// MedianResult medianOfTwoSortedArrays 结果，cut1、cut2 是左半部分在两个数组中的长度
+type MedianResult struct {
+	Median float64 `json:"median"`
+	Cut1   int     `json:"cut1"`
+	Cut2   int     `json:"cut2"`
+}
+
+// EatingSpeedResult kokoEatingBananas 结果
+type EatingSpeedResult struct {
+	Speed int `json:"speed"`
+	Hours int `json:"hours"`
+}
+
+// SplitResult splitArrayLargestSum 结果，parts 是每段的边界
+type SplitResult struct {
+	Largest int      `json:"largest"`
+	Parts   []Window `json:"parts"`
+}
+
+func checkRotatedSearch(in *RotatedSearchInput, expected, got IndexResult) error {
+	if got.Found != expected.Found {
+		return fmt.Errorf("found = %v, want %v", got.Found, expected.Found)
+	}
+	if !got.Found {
+		if got.Index != -1 {
+			return errors.New("index must be -1 when target is not found")
+		}
+		return nil
+	}
+	if got.Index < 0 || got.Index >= len(in.Nums) || in.Nums[got.Index] != in.Target {
+		return fmt.Errorf("nums[%d] is not %d", got.Index, in.Target)
+	}
+	return nil
+}
+
+func checkPeak(in *PeakInput, _, got PeakResult) error {
+	if !isPeak(in.Nums, got.Index) {
+		return fmt.Errorf("index %d is not a peak", got.Index)
+	}
+	if got.Value != in.Nums[got.Index] {
+		return fmt.Errorf("value %d does not match nums[%d] = %d", got.Value, got.Index, in.Nums[got.Index])
+	}
+	return nil
+}
+
+// checkMedian 中位数相同，切分点把合并后的数组分成相等的两半（奇数时左边多一个）且左边不大于右边
+func checkMedian(in *MedianInput, expected, got MedianResult) error {
+	if got.Median != expected.Median {
+		return fmt.Errorf("median = %v, want %v", got.Median, expected.Median)
+	}
+	m, n := len(in.Nums1), len(in.Nums2)
+	if got.Cut1 < 0 || got.Cut1 > m || got.Cut2 < 0 || got.Cut2 > n || got.Cut1+got.Cut2 != (m+n+1)/2 {
+		return fmt.Errorf("cuts %d, %d do not split %d elements in half", got.Cut1, got.Cut2, m+n)
+	}
+	left := slices.Concat(in.Nums1[:got.Cut1], in.Nums2[:got.Cut2])
+	right := slices.Concat(in.Nums1[got.Cut1:], in.Nums2[got.Cut2:])
+	if len(left) > 0 && len(right) > 0 && slices.Max(left) > slices.Min(right) {
+		return fmt.Errorf("left half max %d exceeds right half min %d", slices.Max(left), slices.Min(right))
+	}
+	return nil
+}
+
+// validSplit 恰好 k 段、依次相连覆盖整个数组、最大段和等于 largest
+func validSplit(nums []int, k int, result SplitResult) error {
+	if len(result.Parts) != k {
+		return fmt.Errorf("want %d parts, got %d", k, len(result.Parts))
+	}
+	var next, largest int
+	for i, part := range result.Parts {
+		if part.Left != next || part.Right < part.Left || part.Right >= len(nums) {
+			return fmt.Errorf("parts[%d] = [%d, %d] does not continue from index %d", i, part.Left, part.Right, next)
+		}
+		var sum int
+		for _, x := range nums[part.Left : part.Right+1] {
+			sum += x
+		}
+		largest, next = max(largest, sum), part.Right+1
+	}
+	if next != len(nums) {
+		return fmt.Errorf("parts end at %d, not at the end of nums", next-1)
+	}
+	if largest != result.Largest {
+		return fmt.Errorf("largest part sums to %d, not %d", largest, result.Largest)
+	}
+	return nil
+}
+
+func checkSplit(in *SplitInput, expected, got SplitResult) error {
+	if got.Largest != expected.Largest {
+		return fmt.Errorf("largest = %d, want %d", got.Largest, expected.Largest)
+	}
+	return validSplit(in.Nums, in.K, got)
+}
+
+// randSorted n 个 [lo, hi] 内的非递减整数
+func randSorted(r *rand.Rand, n, lo, hi int) []int {
+	nums := randInts(r, n, lo, hi)
+	slices.Sort(nums)
+	return nums
+}
+
+// rotate 把 nums[k:] 移到前面
+func rotate(nums []int, k int) []int {
+	return append(slices.Clone(nums[k:]), nums[:k]...)
+}
+
+// separateNeighbors 相邻相等时把后一个加一，保证相邻元素不相等
+func separateNeighbors(nums []int) []int {
+	for i := 1; i < len(nums); i++ {
+		if nums[i] == nums[i-1] {
+			nums[i]++
+		}
+	}
+	return nums
+}
+
+func init() {
+	Default.MustRegister(
+		Define(Spec{
+			Name: "searchRange", Title: "Find First and Last Position of Element in Sorted Array", LeetCode: 34,
+			Category: CategoryBinarySearch, Time: "O(log n)", Space: "O(1)",
+		}, SortedSearchInput{Nums: []int{5, 7, 7, 8, 8, 10}, Target: 8},
+			func(ctx context.Context, in *SortedSearchInput) (SearchRangeResult, error) {
+				return SearchRangeResult{
+					LowerBound: LowerBound(in.Nums, in.Target),
+					UpperBound: UpperBound(in.Nums, in.Target),
+					Range:      SearchRange(in.Nums, in.Target),
+				}, nil
+			}),
+		Define(Spec{
+			Name: "searchRotatedDuplicates", Title: "Search in Rotated Sorted Array II", LeetCode: 81,
+			Category: CategoryBinarySearch, Time: "O(log n), O(n) with many duplicates", Space: "O(1)", Traceable: true,
+		}, RotatedSearchInput{Nums: []int{2, 5, 6, 0, 0, 1, 2}, Target: 0},
+			func(ctx context.Context, in *RotatedSearchInput) (IndexResult, error) {
+				index := searchRotated(in.Nums, in.Target, TraceFrom(ctx))
+				return IndexResult{Found: index >= 0, Index: index}, nil
+			}),
+		Define(Spec{
+			Name: "findPeakElement", Title: "Find Peak Element", LeetCode: 162,
+			Category: CategoryBinarySearch, Time: "O(log n)", Space: "O(1)", Traceable: true,
+		}, PeakInput{Nums: []int{1, 2, 1, 3, 5, 6, 4}},
+			func(ctx context.Context, in *PeakInput) (PeakResult, error) {
+				index := findPeak(in.Nums, TraceFrom(ctx))
+				return PeakResult{Index: index, Value: in.Nums[index]}, nil
+			}),
+		Define(Spec{
+			Name: "medianOfTwoSortedArrays", Title: "Median of Two Sorted Arrays", LeetCode: 4,
+			Category: CategoryBinarySearch, Time: "O(log min(m, n))", Space: "O(1)",
+		}, MedianInput{Nums1: []int{1, 3}, Nums2: []int{2}},
+			func(ctx context.Context, in *MedianInput) (MedianResult, error) {
+				median, cut1, cut2, err := MedianSortedArrays(in.Nums1, in.Nums2)
+				if err != nil {
+					return MedianResult{}, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+				}
+				return MedianResult{Median: median, Cut1: cut1, Cut2: cut2}, nil
+			}),
+		Define(Spec{
+			Name: "kokoEatingBananas", Title: "Koko Eating Bananas", LeetCode: 875,
+			Category: CategoryBinarySearch, Time: "O(n log max(piles))", Space: "O(1)", Traceable: true,
+		}, KokoInput{Piles: []int{3, 6, 7, 11}, H: 8},
+			func(ctx context.Context, in *KokoInput) (EatingSpeedResult, error) {
+				speed := minEatingSpeed(in.Piles, in.H, TraceFrom(ctx))
+				return EatingSpeedResult{Speed: speed, Hours: eatingHours(in.Piles, speed)}, nil
+			}),
+		Define(Spec{
+			Name: "splitArrayLargestSum", Title: "Split Array Largest Sum", LeetCode: 410,
+			Category: CategoryBinarySearch, Time: "O(n log sum(nums))", Space: "O(k)", Traceable: true,
+		}, SplitInput{Nums: []int{7, 2, 5, 10, 8}, K: 2},
+			func(ctx context.Context, in *SplitInput) (SplitResult, error) {
+				largest, parts := splitArray(in.Nums, in.K, TraceFrom(ctx))
+				return SplitResult{Largest: largest, Parts: parts}, nil
+			}),
+	)
+
+	Default.MustRegisterPair(
+		DefinePair("searchRange", "searchRange lower/upper bound vs 线性扫描",
+			func(r *rand.Rand, size int) SortedSearchInput {
+				return SortedSearchInput{Nums: randSorted(r, r.Intn(size+1), 0, size/2), Target: r.Intn(size/2+3) - 1}
+			},
+			func(in SortedSearchInput) [2]int {
+				first, last := slices.Index(in.Nums, in.Target), -1
+				for i, x := range in.Nums {
+					if x == in.Target {
+						last = i
+					}
+				}
+				return [2]int{first, last}
+			},
+			func(in SortedSearchInput) [2]int {
+				if window := SearchRange(in.Nums, in.Target); window != nil {
+					return [2]int{window.Left, window.Right}
+				}
+				return [2]int{-1, -1}
+			},
+			nil, nil),
+		DefinePair("searchRotatedDuplicates", "searchRotatedDuplicates 二分 vs 线性扫描（并检查下标）",
+			func(r *rand.Rand, size int) RotatedSearchInput {
+				nums := randSorted(r, size, 0, size/2)
+				return RotatedSearchInput{Nums: rotate(nums, r.Intn(size)), Target: r.Intn(size/2+3) - 1}
+			},
+			func(in RotatedSearchInput) bool { return slices.Contains(in.Nums, in.Target) },
+			func(in RotatedSearchInput) bool {
+				index := SearchRotated(in.Nums, in.Target)
+				return index >= 0 && in.Nums[index] == in.Target
+			},
+			nil, nil),
+		DefinePair("findPeakElement", "findPeakElement 二分找到的下标是峰值",
+			func(r *rand.Rand, size int) []int { return separateNeighbors(randInts(r, size, 0, 10)) },
+			func(nums []int) bool { return true },
+			func(nums []int) bool { return isPeak(nums, FindPeak(nums)) },
+			nil, nil),
+		DefinePair("medianOfTwoSortedArrays", "medianOfTwoSortedArrays 切分点二分 vs 合并",
+			func(r *rand.Rand, size int) MedianInput {
+				return MedianInput{Nums1: randSorted(r, r.Intn(size+1), -20, 20), Nums2: randSorted(r, 1+r.Intn(size), -20, 20)}
+			},
+			func(in MedianInput) float64 { return MedianBruteForce(in.Nums1, in.Nums2) },
+			func(in MedianInput) float64 {
+				median, cut1, cut2, _ := MedianSortedArrays(in.Nums1, in.Nums2)
+				result := MedianResult{Median: median, Cut1: cut1, Cut2: cut2}
+				if checkMedian(&in, result, result) != nil {
+					return -1000
+				}
+				return median
+			},
+			nil, nil),
+		DefinePair("kokoEatingBananas", "kokoEatingBananas 二分答案 vs 逐个尝试速度",
+			func(r *rand.Rand, size int) KokoInput {
+				piles := randInts(r, 1+r.Intn(size), 1, 50)
+				return KokoInput{Piles: piles, H: len(piles) + r.Intn(3*size)}
+			},
+			func(in KokoInput) int { return MinEatingSpeedLinear(in.Piles, in.H) },
+			func(in KokoInput) int { return MinEatingSpeed(in.Piles, in.H) },
+			nil, nil),
+		DefinePair("splitArrayLargestSum", "splitArrayLargestSum 二分答案 vs dp（并检查切分）",
+			func(r *rand.Rand, size int) SplitInput {
+				nums := randInts(r, size, 0, 20)
+				return SplitInput{Nums: nums, K: 1 + r.Intn(min(len(nums), 6))}
+			},
+			func(in SplitInput) int { return SplitArrayDP(in.Nums, in.K) },
+			func(in SplitInput) int {
+				largest, parts := SplitArray(in.Nums, in.K)
+				if validSplit(in.Nums, in.K, SplitResult{Largest: largest, Parts: parts}) != nil {
+					return -1
+				}
+				return largest
+			},
+			nil, nil),
+	)
+
+	Default.MustRegisterChecker(
+		DefineChecker("searchRotatedDuplicates", checkRotatedSearch),
+		DefineChecker("findPeakElement", checkPeak),
+		DefineChecker("medianOfTwoSortedArrays", checkMedian),
+		DefineChecker("splitArrayLargestSum", checkSplit),
+	)
+
+	Default.MustRegisterGenerator(
+		DefineGenerator("searchRange", 0, 20, []string{FlavorRandom, FlavorEmpty, FlavorAllEqual}, func(r *rand.Rand, opts GenerateOptions) SortedSearchInput {
+			nums := opts.ints(r, opts.n(0, 100000))
+			slices.Sort(nums)
+			return SortedSearchInput{Nums: nums, Target: opts.value(r)}
+		}),
+		DefineGenerator("searchRotatedDuplicates", 0, 20, []string{FlavorRandom, FlavorRotated, FlavorAllEqual}, func(r *rand.Rand, opts GenerateOptions) RotatedSearchInput {
+			nums := opts.ints(r, opts.n(1, 10000))
+			slices.Sort(nums)
+			if opts.Flavor == FlavorRandom {
+				nums = rotate(nums, r.Intn(len(nums)))
+			}
+			return RotatedSearchInput{Nums: nums, Target: opts.value(r)}
+		}),
+		DefineGenerator("findPeakElement", -100, 100, []string{FlavorRandom, FlavorSorted}, func(r *rand.Rand, opts GenerateOptions) PeakInput {
+			if opts.Flavor == FlavorSorted {
+				nums := distinctInts(r, opts, opts.n(1, 10000))
+				slices.Sort(nums)
+				return PeakInput{Nums: nums}
+			}
+			// 相邻相等时加一可能超出 max，这里不截断
+			return PeakInput{Nums: separateNeighbors(opts.ints(r, opts.n(1, 10000)))}
+		}),
+		DefineGenerator("medianOfTwoSortedArrays", -100, 100, []string{FlavorRandom, FlavorEmpty, FlavorAllEqual}, func(r *rand.Rand, opts GenerateOptions) MedianInput {
+			opts = opts.within(-1000000000, 1000000000)
+			n := opts.n(1, 10000)
+			// empty 时 nums1 为空，nums2 随机
+			nums1 := opts.ints(r, r.Intn(n+1))
+			if opts.Flavor == FlavorEmpty {
+				opts.Flavor = FlavorRandom
+			}
+			nums2 := opts.ints(r, n)
+			slices.Sort(nums1)
+			slices.Sort(nums2)
+			return MedianInput{Nums1: nums1, Nums2: nums2}
+		}),
+		DefineGenerator("kokoEatingBananas", 1, 100, []string{FlavorRandom, FlavorAllEqual}, func(r *rand.Rand, opts GenerateOptions) KokoInput {
+			piles := opts.within(1, 1000000000).ints(r, opts.n(1, 10000))
+			return KokoInput{Piles: piles, H: len(piles) + r.Intn(3*len(piles)+1)}
+		}),
+		DefineGenerator("splitArrayLargestSum", 0, 100, []string{FlavorRandom, FlavorAllEqual}, func(r *rand.Rand, opts GenerateOptions) SplitInput {
+			nums := opts.within(0, 1000000).ints(r, opts.n(1, 1000))
+			return SplitInput{Nums: nums, K: 1 + r.Intn(min(len(nums), 50))}
+		}),
+	)
+}
@@ -0,0 +1,162 @@
+package algorithm
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// TestBounds 下界、上界和查找范围，包含空数组、单个元素和负数
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		nums         []int
+		target       int
+		lower, upper int
+		span         *Window
+	}{
+		{[]int{5, 7, 7, 8, 8, 10}, 8, 3, 5, &Window{3, 4}},
+		{[]int{5, 7, 7, 8, 8, 10}, 6, 1, 1, nil},
+		{[]int{}, 0, 0, 0, nil},
+		{[]int{1}, 1, 0, 1, &Window{0, 0}},
+		{[]int{1}, 2, 1, 1, nil},
+		{[]int{-9, -5, -5, -1}, -5, 1, 3, &Window{1, 2}},
+		{[]int{-9, -5, -5, -1}, -10, 0, 0, nil},
+		{[]int{math.MinInt, 0, math.MaxInt}, math.MaxInt, 2, 3, &Window{2, 2}},
+	}
+	for _, test := range tests {
+		lower, upper, span := LowerBound(test.nums, test.target), UpperBound(test.nums, test.target), SearchRange(test.nums, test.target)
+		if lower != test.lower || upper != test.upper || !reflect.DeepEqual(span, test.span) {
+			t.Errorf("%v target %d: bounds %d %d range %v, want %d %d %v", test.nums, test.target, lower, upper, span, test.lower, test.upper, test.span)
+		}
+	}
+	if got := PartitionPoint(0, 10, func(int) bool { return false }); got != 10 {
+		t.Errorf("PartitionPoint all false = %d, want 10", got)
+	}
+}
+
+// TestSearchRotated 旋转数组中查找，包含重复元素、单个元素和全为负数
+func TestSearchRotated(t *testing.T) {
+	tests := []struct {
+		nums   []int
+		target int
+		found  bool
+	}{
+		{[]int{2, 5, 6, 0, 0, 1, 2}, 0, true},
+		{[]int{2, 5, 6, 0, 0, 1, 2}, 3, false},
+		{[]int{1, 0, 1, 1, 1}, 0, true},
+		{[]int{3}, 3, true},
+		{[]int{3}, 4, false},
+		{[]int{-2, -1, -5, -4}, -5, true},
+	}
+	for _, test := range tests {
+		i := SearchRotated(test.nums, test.target)
+		if found := i >= 0; found != test.found || found && test.nums[i] != test.target {
+			t.Errorf("SearchRotated(%v, %d) = %d, found want %v", test.nums, test.target, i, test.found)
+		}
+	}
+}
+
+// TestFindPeak 峰值下标，单调数组的峰值在端点
+func TestFindPeak(t *testing.T) {
+	tests := []struct {
+		nums []int
+		peak int
+	}{
+		{[]int{1, 2, 3, 1}, 2},
+		{[]int{7}, 0},
+		{[]int{5, 4, 3}, 0},
+		{[]int{-3, -2, -1}, 2},
+	}
+	for _, test := range tests {
+		if got := FindPeak(test.nums); got != test.peak {
+			t.Errorf("FindPeak(%v) = %d, want %d", test.nums, got, test.peak)
+		}
+	}
+	if nums := []int{1, 2, 1, 3, 5, 6, 4}; !isPeak(nums, FindPeak(nums)) {
+		t.Errorf("FindPeak(%v) = %d is not a peak", nums, FindPeak(nums))
+	}
+}
+
+// TestMedianSortedArrays 中位数和切分点，一个数组为空、两个都为空和负数
+func TestMedianSortedArrays(t *testing.T) {
+	tests := []struct {
+		nums1, nums2 []int
+		median       float64
+	}{
+		{[]int{1, 3}, []int{2}, 2},
+		{[]int{1, 2}, []int{3, 4}, 2.5},
+		{[]int{}, []int{1}, 1},
+		{[]int{-5, -3}, []int{-4, -1}, -3.5},
+		{[]int{-1000000000}, []int{1000000000}, 0},
+	}
+	for _, test := range tests {
+		median, cut1, cut2, err := MedianSortedArrays(test.nums1, test.nums2)
+		if err != nil || median != test.median || cut1+cut2 != (len(test.nums1)+len(test.nums2)+1)/2 {
+			t.Errorf("MedianSortedArrays(%v, %v) = %v cuts %d %d err %v, want %v", test.nums1, test.nums2, median, cut1, cut2, err, test.median)
+		}
+	}
+	if _, _, _, err := MedianSortedArrays(nil, nil); !errors.Is(err, ErrEmptyArrays) {
+		t.Errorf("empty arrays: err = %v, want ErrEmptyArrays", err)
+	}
+}
+
+// TestBinarySearchAnswer 二分答案的已知结果
+func TestBinarySearchAnswer(t *testing.T) {
+	speeds := []struct {
+		piles []int
+		h     int
+		speed int
+	}{
+		{[]int{3, 6, 7, 11}, 8, 4},
+		{[]int{30, 11, 23, 4, 20}, 5, 30},
+		{[]int{30, 11, 23, 4, 20}, 6, 23},
+		{[]int{1}, 1, 1},
+		{[]int{1000000000}, 2, 500000000},
+	}
+	for _, test := range speeds {
+		if got := MinEatingSpeed(test.piles, test.h); got != test.speed {
+			t.Errorf("MinEatingSpeed(%v, %d) = %d, want %d", test.piles, test.h, got, test.speed)
+		}
+	}
+	splits := []struct {
+		nums  []int
+		k     int
+		limit int
+		parts []Window
+	}{
+		{[]int{7, 2, 5, 10, 8}, 2, 18, []Window{{0, 2}, {3, 4}}},
+		{[]int{1, 4, 4}, 3, 4, []Window{{0, 0}, {1, 1}, {2, 2}}},
+		{[]int{9}, 1, 9, []Window{{0, 0}}},
+		{[]int{0, 0, 0}, 2, 0, []Window{{0, 1}, {2, 2}}},
+	}
+	for _, test := range splits {
+		limit, parts := SplitArray(test.nums, test.k)
+		if limit != test.limit || !reflect.DeepEqual(parts, test.parts) {
+			t.Errorf("SplitArray(%v, %d) = %d %v, want %d %v", test.nums, test.k, limit, parts, test.limit, test.parts)
+		}
+	}
+}
+
+// TestBinarySearchInputValidation 无序、空输入和不满足题目约束的输入在执行前被拒绝
+func TestBinarySearchInputValidation(t *testing.T) {
+	tests := []struct{ name, input string }{
+		{"searchRange", `{"nums":[3,1,2],"target":1}`},
+		{"searchRotatedDuplicates", `{"nums":[],"target":1}`},
+		{"searchRotatedDuplicates", `{"nums":[3,1,2,0],"target":1}`},
+		{"findPeakElement", `{"nums":[1,1]}`},
+		{"medianOfTwoSortedArrays", `{"nums1":[],"nums2":[]}`},
+		{"kokoEatingBananas", `{"piles":[1,2,3],"h":2}`},
+		{"splitArrayLargestSum", `{"nums":[1,2],"k":3}`},
+	}
+	for _, test := range tests {
+		spec, err := Default.Get(test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := spec.Execute(context.Background(), []byte(test.input)); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s %s: err = %v, want ErrInvalidInput", test.name, test.input, err)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package algorithm
+
+import (
+	"fmt"
+	"slices"
+)
+
+/*
+PopCountKernighan leetcode 191
+given an integer and  return the the number of 1 bits
+n&(n-1) 将数字最右边的1变成0，循环次数等于 1 的个数
+*/
+func PopCountKernighan(n uint64) int {
+	var count int
+	for n != 0 {
+		n &= n - 1
+		count++
+	}
+	return count
+}
+
+// PopCountShift 逐位检查，每次向右移动一位
+func PopCountShift(n uint64) int {
+	var count int
+	for n > 0 {
+		if n&1 != 0 {
+			count++
+		}
+		n >>= 1
+	}
+	return count
+}
+
+// PopCount64 有符号整数按 64 位补码计算 1 的个数，负数的符号位也算在内
+func PopCount64(n int64) int {
+	return PopCountKernighan(uint64(n))
+}
+
+// FormatBinary 无符号整数的二进制表示，width 大于 0 时左侧补 0 到 width 位
+func FormatBinary(n uint64, width int) string {
+	return fmt.Sprintf("%0*b", width, n)
+}
+
+// FormatSigned 有符号整数的二进制表示，负数为 64 位补码
+func FormatSigned(n int64) string {
+	if n < 0 {
+		return FormatBinary(uint64(n), 64)
+	}
+	return FormatBinary(uint64(n), 0)
+}
+
+/*
+CountBits leetcode 338 比特位计数，返回 0..n 每个数中 1 的个数
+i >> 1 比 i 小，已经算过，bits[i] = bits[i>>1] + i&1
+*/
+func CountBits(n int) []int {
+	bits := make([]int, n+1)
+	for i := 1; i <= n; i++ {
+		bits[i] = bits[i>>1] + i&1
+	}
+	return bits
+}
+
+/*
+ReverseBits leetcode 190 颠倒二进制位
+分治：先交换相邻的 1 位，再交换相邻的 2 位、4 位、8 位，最后交换高低 16 位
+*/
+func ReverseBits(n uint32) uint32 {
+	n = n>>1&0x55555555 | n&0x55555555<<1
+	n = n>>2&0x33333333 | n&0x33333333<<2
+	n = n>>4&0x0f0f0f0f | n&0x0f0f0f0f<<4
+	n = n>>8&0x00ff00ff | n&0x00ff00ff<<8
+	return n>>16 | n<<16
+}
+
+// SingleNumber leetcode 136 只出现一次的数字，其余都出现两次，x ^ x = 0
+func SingleNumber(nums []int) int {
+	var single int
+	for _, x := range nums {
+		single ^= x
+	}
+	return single
+}
+
+/*
+SingleNumberThrice leetcode 137 只出现一次的数字 II，其余都出现三次
+每一位上 1 出现的次数模 3 用两位状态 (twos, ones) 表示：00 -> 01 -> 10 -> 00，最后 ones 就是答案
+*/
+func SingleNumberThrice(nums []int) int {
+	var ones, twos int
+	for _, x := range nums {
+		ones = (ones ^ x) &^ twos
+		twos = (twos ^ x) &^ ones
+	}
+	return ones
+}
+
+/*
+SingleNumberPair leetcode 260 只出现一次的数字 III，恰好两个数只出现一次，其余都出现两次
+全部异或得到 a ^ b，取最低位的 1（a、b 在这一位上不同）把数字分成两组，每组各异或出一个
+返回升序的 a、b 以及用来分组的那一位
+*/
+func SingleNumberPair(nums []int) (a, b, bit int) {
+	xor := SingleNumber(nums)
+	bit = xor & -xor
+	for _, x := range nums {
+		if x&bit != 0 {
+			a ^= x
+		}
+	}
+	b = xor ^ a
+	return min(a, b), max(a, b), bit
+}
+
+// singleByCount 计数找出出现次数不是 k 的数，升序返回
+func singleByCount(nums []int, k int) []int {
+	count := make(map[int]int, len(nums))
+	for _, x := range nums {
+		count[x]++
+	}
+	var singles []int
+	for x, c := range count {
+		if c != k {
+			singles = append(singles, x)
+		}
+	}
+	slices.Sort(singles)
+	return singles
+}
+
+// MissingNumber leetcode 268 丢失的数字，nums 是 [0, n] 中 n 个不同的数，下标和值一起异或
+func MissingNumber(nums []int) int {
+	missing := len(nums)
+	for i, x := range nums {
+		missing ^= i ^ x
+	}
+	return missing
+}
+
+/*
+GetSum leetcode 371 两整数之和，不使用 + 和 -
+a ^ b 是不进位的和，(a & b) << 1 是进位，把进位加回去直到没有进位，最多 64 轮
+返回和以及进位的轮数
+*/
+func GetSum(a, b int) (int, int) {
+	return getSum(a, b, nil)
+}
+
+func getSum(a, b int, t *Trace) (int, int) {
+	var rounds int
+	for b != 0 {
+		if t.Enabled() {
+			t.Record("carry", map[string]interface{}{"a": FormatSigned(int64(a)), "b": FormatSigned(int64(b))})
+		}
+		a, b = a^b, (a&b)<<1
+		rounds++
+	}
+	return a, rounds
+}
+
+// IsPowerOfTwo leetcode 231 2 的幂只有一个 1，n & (n-1) 去掉它之后为 0
+func IsPowerOfTwo(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// IsPowerOfFour leetcode 342 4 的幂是 2 的幂，且唯一的 1 在偶数位上
+func IsPowerOfFour(n int) bool {
+	return IsPowerOfTwo(n) && n&0x5555555555555555 != 0
+}
+
+// GrayCode leetcode 89 格雷编码，第 i 个是 i ^ (i >> 1)
+func GrayCode(n int) []int {
+	codes := make([]int, 1<<n)
+	for i := range codes {
+		codes[i] = i ^ i>>1
+	}
+	return codes
+}
+
+// GrayCodeReflect 镜像构造：n 位格雷码是 n-1 位的序列，再接上它的倒序并在最高位加 1
+func GrayCodeReflect(n int) []int {
+	codes := []int{0}
+	for i := 0; i < n; i++ {
+		for j := len(codes) - 1; j >= 0; j-- {
+			codes = append(codes, codes[j]|1<<i)
+		}
+	}
+	return codes
+}
@@ -0,0 +1,467 @@
+package algorithm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"slices"
+)
+
+// PopCountInput numberOfOneBits 输入，n 和 u 二选一
+type PopCountInput struct {
+	N *int64  `json:"n" doc:"有符号整数，负数按 64 位补码计算"`
+	U *uint64 `json:"u" doc:"无符号整数，可以超过 int64 的范围"`
+}
+
+// Validate n 和 u 恰好有一个
+func (in *PopCountInput) Validate() error {
+	if (in.N == nil) == (in.U == nil) {
+		return errors.New("exactly one of n and u is required")
+	}
+	return nil
+}
+
+// value 按 64 位无符号整数解释输入，以及对应的二进制表示
+func (in *PopCountInput) value() (uint64, string) {
+	if in.U != nil {
+		return *in.U, FormatBinary(*in.U, 0)
+	}
+	return uint64(*in.N), FormatSigned(*in.N)
+}
+
+// CountingBitsInput countingBits 输入
+type CountingBitsInput struct {
+	N int `json:"n" binding:"min=0,max=100000"`
+}
+
+// ReverseBitsInput reverseBits 输入
+type ReverseBitsInput struct {
+	N *uint32 `json:"n" binding:"required" doc:"32 位无符号整数"`
+}
+
+// SingleNumberInput singleNumber 系列输入
+type SingleNumberInput struct {
+	Nums []int `json:"nums" binding:"required,min=1,max=30000"`
+}
+
+// SingleTwiceInput 恰好一个数出现一次，其余都出现两次
+type SingleTwiceInput struct{ SingleNumberInput }
+
+// Validate 检查出现次数
+func (in *SingleTwiceInput) Validate() error { return checkMultiplicity(in.Nums, 2, 1) }
+
+// SingleThriceInput 恰好一个数出现一次，其余都出现三次
+type SingleThriceInput struct{ SingleNumberInput }
+
+// Validate 检查出现次数
+func (in *SingleThriceInput) Validate() error { return checkMultiplicity(in.Nums, 3, 1) }
+
+// SinglePairInput 恰好两个数出现一次，其余都出现两次
+type SinglePairInput struct{ SingleNumberInput }
+
+// Validate 检查出现次数
+func (in *SinglePairInput) Validate() error { return checkMultiplicity(in.Nums, 2, 2) }
+
+// checkMultiplicity 恰好 singles 个数出现一次，其余都出现 k 次
+func checkMultiplicity(nums []int, k, singles int) error {
+	count := make(map[int]int, len(nums))
+	for _, x := range nums {
+		count[x]++
+	}
+	var once int
+	for x, c := range count {
+		switch c {
+		case 1:
+			once++
+		case k:
+		default:
+			return fmt.Errorf("%d appears %d times, every number must appear once or %d times", x, c, k)
+		}
+	}
+	if once != singles {
+		return fmt.Errorf("%d numbers appear once, want exactly %d", once, singles)
+	}
+	return nil
+}
+
+// MissingNumberInput missingNumber 输入
+type MissingNumberInput struct {
+	Nums []int `json:"nums" binding:"max=10000" doc:"[0, n] 中 n 个不同的数"`
+}
+
+// Validate 检查取值范围和重复
+func (in *MissingNumberInput) Validate() error {
+	seen := make([]bool, len(in.Nums)+1)
+	for i, x := range in.Nums {
+		if x < 0 || x > len(in.Nums) {
+			return fmt.Errorf("nums[%d] = %d is out of range [0, %d]", i, x, len(in.Nums))
+		}
+		if seen[x] {
+			return fmt.Errorf("%d appears more than once", x)
+		}
+		seen[x] = true
+	}
+	return nil
+}
+
+// SumInput getSum 输入
+type SumInput struct {
+	A int `json:"a" binding:"min=-2147483648,max=2147483647"`
+	B int `json:"b" binding:"min=-2147483648,max=2147483647"`
+}
+
+// PowerInput powerOfTwo / powerOfFour 输入
+type PowerInput struct {
+	N *int `json:"n" binding:"required"`
+}
+
+// GrayCodeInput grayCode 输入
+type GrayCodeInput struct {
+	N int `json:"n" binding:"min=0,max=16"`
+}
+
+// PopCountResult numberOfOneBits 结果，count、number、mathBits 分别是逐位检查、n&(n-1) 和 math/bits 的结果
+type PopCountResult struct {
+	Count    int    `json:"count"`
+	Number   int    `json:"number"`
+	MathBits int    `json:"mathBits"`
+	Binary   string `json:"binary"`
+}
+
+// CountingBitsResult countingBits 结果
+type CountingBitsResult struct {
+	Counts []int    `json:"counts"`
+	Binary []string `json:"binary"`
+}
+
+// ReverseBitsResult reverseBits 结果，二进制表示都是 32 位
+type ReverseBitsResult struct {
+	Value    uint32 `json:"value"`
+	Binary   string `json:"binary"`
+	Reversed string `json:"reversed"`
+}
+
+// SingleResult singleNumber 结果
+type SingleResult struct {
+	Single int    `json:"single"`
+	Binary string `json:"binary"`
+}
+
+// SinglePairResult singleNumberIII 结果，bit 是两个数不同的最低位
+type SinglePairResult struct {
+	Singles [2]int    `json:"singles"`
+	Binary  [2]string `json:"binary"`
+	Bit     string    `json:"bit"`
+}
+
+// MissingResult missingNumber 结果
+type MissingResult struct {
+	Missing int    `json:"missing"`
+	Binary  string `json:"binary"`
+}
+
+// SumResult getSum 结果，rounds 是进位的轮数
+type SumResult struct {
+	Sum    int    `json:"sum"`
+	Binary string `json:"binary"`
+	Rounds int    `json:"rounds"`
+}
+
+// PowerResult powerOfTwo / powerOfFour 结果，是幂时 exponent 为指数
+type PowerResult struct {
+	Power    bool   `json:"power"`
+	Exponent *int   `json:"exponent,omitempty"`
+	Binary   string `json:"binary"`
+}
+
+// GrayCodeResult grayCode 结果
+type GrayCodeResult struct {
+	Codes  []int    `json:"codes"`
+	Binary []string `json:"binary"`
+}
+
+func singleResult(x int) SingleResult {
+	return SingleResult{Single: x, Binary: FormatSigned(int64(x))}
+}
+
+// powerResult base 为 2 或 4
+func powerResult(n, base int, power bool) PowerResult {
+	result := PowerResult{Power: power, Binary: FormatSigned(int64(n))}
+	if power {
+		result.Exponent = intPtr(bits.TrailingZeros64(uint64(n)) / bits.TrailingZeros(uint(base)))
+	}
+	return result
+}
+
+// validGrayCode 2^n 个 [0, 2^n) 内的不同数，从 0 开始，相邻（包括首尾）恰好差一位
+func validGrayCode(n int, codes []int) error {
+	if len(codes) != 1<<n {
+		return fmt.Errorf("want %d codes, got %d", 1<<n, len(codes))
+	}
+	if codes[0] != 0 {
+		return errors.New("the sequence must start with 0")
+	}
+	seen := make([]bool, len(codes))
+	for i, code := range codes {
+		if code < 0 || code >= len(codes) || seen[code] {
+			return fmt.Errorf("codes[%d] = %d is out of range or repeated", i, code)
+		}
+		seen[code] = true
+		if next := codes[(i+1)%len(codes)]; len(codes) > 1 && bits.OnesCount(uint(code^next)) != 1 {
+			return fmt.Errorf("codes[%d] = %d and its successor %d differ in more than one bit", i, code, next)
+		}
+	}
+	return nil
+}
+
+func checkGrayCode(in *GrayCodeInput, _, got GrayCodeResult) error {
+	return validGrayCode(in.N, got.Codes)
+}
+
+// randSingles 随机选 singles 个只出现一次的数，再选 pairs 个不同的数各重复 k 次，打乱顺序
+func randSingles(r *rand.Rand, opts GenerateOptions, k, singles, pairs int) []int {
+	// 范围至少要容纳 singles 个不同的数
+	opts.Max = max(opts.Max, opts.Min+singles-1)
+	values := distinctInts(r, opts, singles+pairs)
+	nums := slices.Clone(values[:min(singles, len(values))])
+	for _, x := range values[len(nums):] {
+		for i := 0; i < k; i++ {
+			nums = append(nums, x)
+		}
+	}
+	r.Shuffle(len(nums), func(i, j int) { nums[i], nums[j] = nums[j], nums[i] })
+	return nums
+}
+
+// powerCandidate 一半概率取不超过 max 的 2 的幂，其余是范围内的随机数
+func powerCandidate(r *rand.Rand, opts GenerateOptions) int {
+	if opts.Max > 0 && r.Intn(2) == 0 {
+		return 1 << r.Intn(bits.Len(uint(opts.Max)))
+	}
+	return opts.value(r)
+}
+
+func init() {
+	Default.MustRegister(
+		Define(Spec{
+			Name: "countingBits", Title: "Counting Bits", LeetCode: 338,
+			Category: CategoryBitManipulation, Time: "O(n)", Space: "O(n)",
+		}, CountingBitsInput{N: 5},
+			func(ctx context.Context, in *CountingBitsInput) (CountingBitsResult, error) {
+				counts := CountBits(in.N)
+				binary := make([]string, len(counts))
+				for i := range binary {
+					binary[i] = FormatBinary(uint64(i), 0)
+				}
+				return CountingBitsResult{Counts: counts, Binary: binary}, nil
+			}),
+		Define(Spec{
+			Name: "reverseBits", Title: "Reverse Bits", LeetCode: 190,
+			Category: CategoryBitManipulation, Time: "O(1)", Space: "O(1)",
+		}, ReverseBitsInput{N: new(uint32)},
+			func(ctx context.Context, in *ReverseBitsInput) (ReverseBitsResult, error) {
+				value := ReverseBits(*in.N)
+				return ReverseBitsResult{Value: value, Binary: FormatBinary(uint64(*in.N), 32), Reversed: FormatBinary(uint64(value), 32)}, nil
+			}),
+		Define(Spec{
+			Name: "singleNumber", Title: "Single Number", LeetCode: 136,
+			Category: CategoryBitManipulation, Time: "O(n)", Space: "O(1)",
+		}, SingleTwiceInput{SingleNumberInput{Nums: []int{4, 1, 2, 1, 2}}},
+			func(ctx context.Context, in *SingleTwiceInput) (SingleResult, error) {
+				return singleResult(SingleNumber(in.Nums)), nil
+			}),
+		Define(Spec{
+			Name: "singleNumberII", Title: "Single Number II", LeetCode: 137,
+			Category: CategoryBitManipulation, Time: "O(n)", Space: "O(1)",
+		}, SingleThriceInput{SingleNumberInput{Nums: []int{0, 1, 0, 1, 0, 1, 99}}},
+			func(ctx context.Context, in *SingleThriceInput) (SingleResult, error) {
+				return singleResult(SingleNumberThrice(in.Nums)), nil
+			}),
+		Define(Spec{
+			Name: "singleNumberIII", Title: "Single Number III", LeetCode: 260,
+			Category: CategoryBitManipulation, Time: "O(n)", Space: "O(1)",
+		}, SinglePairInput{SingleNumberInput{Nums: []int{1, 2, 1, 3, 2, 5}}},
+			func(ctx context.Context, in *SinglePairInput) (SinglePairResult, error) {
+				a, b, bit := SingleNumberPair(in.Nums)
+				return SinglePairResult{
+					Singles: [2]int{a, b},
+					Binary:  [2]string{FormatSigned(int64(a)), FormatSigned(int64(b))},
+					Bit:     FormatSigned(int64(bit)),
+				}, nil
+			}),
+		Define(Spec{
+			Name: "missingNumber", Title: "Missing Number", LeetCode: 268,
+			Category: CategoryBitManipulation, Time: "O(n)", Space: "O(1)",
+		}, MissingNumberInput{Nums: []int{9, 6, 4, 2, 3, 5, 7, 0, 1}},
+			func(ctx context.Context, in *MissingNumberInput) (MissingResult, error) {
+				missing := MissingNumber(in.Nums)
+				return MissingResult{Missing: missing, Binary: FormatBinary(uint64(missing), 0)}, nil
+			}),
+		Define(Spec{
+			Name: "getSum", Title: "Sum of Two Integers", LeetCode: 371,
+			Category: CategoryBitManipulation, Time: "O(64)", Space: "O(1)", Traceable: true,
+		}, SumInput{A: 2, B: 3},
+			func(ctx context.Context, in *SumInput) (SumResult, error) {
+				sum, rounds := getSum(in.A, in.B, TraceFrom(ctx))
+				return SumResult{Sum: sum, Binary: FormatSigned(int64(sum)), Rounds: rounds}, nil
+			}),
+		Define(Spec{
+			Name: "powerOfTwo", Title: "Power of Two", LeetCode: 231,
+			Category: CategoryBitManipulation, Time: "O(1)", Space: "O(1)",
+		}, PowerInput{N: intPtr(16)},
+			func(ctx context.Context, in *PowerInput) (PowerResult, error) {
+				return powerResult(*in.N, 2, IsPowerOfTwo(*in.N)), nil
+			}),
+		Define(Spec{
+			Name: "powerOfFour", Title: "Power of Four", LeetCode: 342,
+			Category: CategoryBitManipulation, Time: "O(1)", Space: "O(1)",
+		}, PowerInput{N: intPtr(16)},
+			func(ctx context.Context, in *PowerInput) (PowerResult, error) {
+				return powerResult(*in.N, 4, IsPowerOfFour(*in.N)), nil
+			}),
+		Define(Spec{
+			Name: "grayCode", Title: "Gray Code", LeetCode: 89,
+			Category: CategoryBitManipulation, Time: "O(2^n)", Space: "O(2^n)",
+		}, GrayCodeInput{N: 2},
+			func(ctx context.Context, in *GrayCodeInput) (GrayCodeResult, error) {
+				codes := GrayCode(in.N)
+				binary := make([]string, len(codes))
+				for i, code := range codes {
+					binary[i] = FormatBinary(uint64(code), in.N)
+				}
+				return GrayCodeResult{Codes: codes, Binary: binary}, nil
+			}),
+	)
+
+	Default.MustRegisterPair(
+		DefinePair("numberOfOneBitsMathBits", "numberOfOneBits int64 补码 vs math/bits",
+			func(r *rand.Rand, size int) int64 { return int64(r.Uint64()) >> uint(r.Intn(64)) },
+			func(n int64) int { return bits.OnesCount64(uint64(n)) },
+			PopCount64, nil, nil),
+		DefinePair("countingBits", "countingBits dp vs math/bits",
+			func(r *rand.Rand, size int) int { return r.Intn(size * 10) },
+			func(n int) []int {
+				counts := make([]int, n+1)
+				for i := range counts {
+					counts[i] = bits.OnesCount(uint(i))
+				}
+				return counts
+			},
+			CountBits, nil, nil),
+		DefinePair("reverseBits", "reverseBits 分治 vs math/bits",
+			func(r *rand.Rand, size int) uint32 { return r.Uint32() },
+			bits.Reverse32, ReverseBits, nil, nil),
+		DefinePair("singleNumber", "singleNumber 异或 vs 计数",
+			func(r *rand.Rand, size int) []int {
+				return randSingles(r, GenerateOptions{Min: -size, Max: size}, 2, 1, r.Intn(size))
+			},
+			func(nums []int) int { return singleByCount(nums, 2)[0] },
+			SingleNumber, nil, nil),
+		DefinePair("singleNumberII", "singleNumberII 状态机 vs 计数",
+			func(r *rand.Rand, size int) []int {
+				return randSingles(r, GenerateOptions{Min: -size, Max: size}, 3, 1, r.Intn(size))
+			},
+			func(nums []int) int { return singleByCount(nums, 3)[0] },
+			SingleNumberThrice, nil, nil),
+		DefinePair("singleNumberIII", "singleNumberIII 最低位分组 vs 计数",
+			func(r *rand.Rand, size int) []int {
+				return randSingles(r, GenerateOptions{Min: -size, Max: size}, 2, 2, r.Intn(size))
+			},
+			func(nums []int) [2]int { return [2]int(singleByCount(nums, 2)) },
+			func(nums []int) [2]int {
+				a, b, _ := SingleNumberPair(nums)
+				return [2]int{a, b}
+			},
+			nil, nil),
+		DefinePair("missingNumber", "missingNumber 异或 vs 求和",
+			func(r *rand.Rand, size int) []int {
+				nums := r.Perm(size + 1)
+				missing := r.Intn(size + 1)
+				return slices.DeleteFunc(nums, func(x int) bool { return x == missing })
+			},
+			func(nums []int) int {
+				sum := len(nums) * (len(nums) + 1) / 2
+				for _, x := range nums {
+					sum -= x
+				}
+				return sum
+			},
+			MissingNumber, nil, nil),
+		DefinePair("getSum", "getSum 位运算 vs +",
+			func(r *rand.Rand, size int) [2]int {
+				return [2]int{int(r.Int31()) - r.Intn(1<<31), int(r.Int31()) - r.Intn(1<<31)}
+			},
+			func(in [2]int) int { return in[0] + in[1] },
+			func(in [2]int) int {
+				sum, _ := GetSum(in[0], in[1])
+				return sum
+			},
+			nil, nil),
+		DefinePair("powerOfTwo", "powerOfTwo n&(n-1) vs math/bits",
+			func(r *rand.Rand, size int) int {
+				return powerCandidate(r, GenerateOptions{Min: -size, Max: size * size})
+			},
+			func(n int) bool { return n > 0 && bits.OnesCount(uint(n)) == 1 },
+			IsPowerOfTwo, nil, nil),
+		DefinePair("powerOfFour", "powerOfFour 掩码 vs 连除 4",
+			func(r *rand.Rand, size int) int {
+				return powerCandidate(r, GenerateOptions{Min: -size, Max: size * size})
+			},
+			func(n int) bool {
+				for n > 1 && n%4 == 0 {
+					n /= 4
+				}
+				return n == 1
+			},
+			IsPowerOfFour, nil, nil),
+		DefinePair("grayCode", "grayCode i^(i>>1) vs 镜像构造",
+			func(r *rand.Rand, size int) int { return r.Intn(min(size, 12) + 1) },
+			GrayCodeReflect, GrayCode, nil, nil),
+	)
+
+	Default.MustRegisterChecker(
+		DefineChecker("grayCode", checkGrayCode),
+	)
+
+	Default.MustRegisterGenerator(
+		DefineGenerator("countingBits", 0, 0, []string{FlavorRandom}, func(r *rand.Rand, opts GenerateOptions) CountingBitsInput {
+			return CountingBitsInput{N: opts.n(0, 100000)}
+		}),
+		DefineGenerator("reverseBits", 0, 1<<32-1, []string{FlavorRandom}, func(r *rand.Rand, opts GenerateOptions) ReverseBitsInput {
+			n := uint32(opts.within(0, 1<<32-1).value(r))
+			return ReverseBitsInput{N: &n}
+		}),
+		DefineGenerator("singleNumber", -100, 100, []string{FlavorRandom}, func(r *rand.Rand, opts GenerateOptions) SingleTwiceInput {
+			return SingleTwiceInput{SingleNumberInput{Nums: randSingles(r, opts, 2, 1, opts.n(0, 14999))}}
+		}),
+		DefineGenerator("singleNumberII", -100, 100, []string{FlavorRandom}, func(r *rand.Rand, opts GenerateOptions) SingleThriceInput {
+			return SingleThriceInput{SingleNumberInput{Nums: randSingles(r, opts, 3, 1, opts.n(0, 9999))}}
+		}),
+		DefineGenerator("singleNumberIII", -100, 100, []string{FlavorRandom}, func(r *rand.Rand, opts GenerateOptions) SinglePairInput {
+			return SinglePairInput{SingleNumberInput{Nums: randSingles(r, opts, 2, 2, opts.n(0, 14999))}}
+		}),
+		DefineGenerator("missingNumber", 0, 0, []string{FlavorRandom, FlavorEmpty}, func(r *rand.Rand, opts GenerateOptions) MissingNumberInput {
+			if opts.Flavor == FlavorEmpty {
+				return MissingNumberInput{Nums: []int{}}
+			}
+			n := opts.n(1, 10000)
+			missing := r.Intn(n + 1)
+			return MissingNumberInput{Nums: slices.Delete(r.Perm(n+1), missing, missing+1)}
+		}),
+		DefineGenerator("getSum", -1000, 1000, []string{FlavorRandom}, func(r *rand.Rand, opts GenerateOptions) SumInput {
+			opts = opts.within(-1<<31, 1<<31-1)
+			return SumInput{A: opts.value(r), B: opts.value(r)}
+		}),
+		DefineGenerator("powerOfTwo", -100, 1<<40, []string{FlavorRandom}, func(r *rand.Rand, opts GenerateOptions) PowerInput {
+			return PowerInput{N: intPtr(powerCandidate(r, opts))}
+		}),
+		DefineGenerator("powerOfFour", -100, 1<<40, []string{FlavorRandom}, func(r *rand.Rand, opts GenerateOptions) PowerInput {
+			return PowerInput{N: intPtr(powerCandidate(r, opts))}
+		}),
+		DefineGenerator("grayCode", 0, 0, []string{FlavorRandom}, func(r *rand.Rand, opts GenerateOptions) GrayCodeInput {
+			return GrayCodeInput{N: opts.n(0, 16)}
+		}),
+	)
+}
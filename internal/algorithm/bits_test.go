@@ -0,0 +1,204 @@
+package algorithm
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestPopCount 1 的个数，包含 64 位边界：最高位、全 1 和有符号的负数
+func TestPopCount(t *testing.T) {
+	tests := []struct {
+		n     uint64
+		count int
+	}{
+		{0, 0},
+		{1, 1},
+		{11, 3},
+		{1 << 63, 1},
+		{math.MaxUint64, 64},
+		{0xAAAAAAAAAAAAAAAA, 32},
+		{math.MaxInt64, 63},
+	}
+	for _, test := range tests {
+		if got, shift := PopCountKernighan(test.n), PopCountShift(test.n); got != test.count || shift != test.count {
+			t.Errorf("PopCount(%#x) = %d / %d, want %d", test.n, got, shift, test.count)
+		}
+	}
+	signed := []struct {
+		n     int64
+		count int
+	}{
+		{-1, 64},
+		{math.MinInt64, 1},
+		{-2, 63},
+	}
+	for _, test := range signed {
+		if got := PopCount64(test.n); got != test.count {
+			t.Errorf("PopCount64(%d) = %d, want %d", test.n, got, test.count)
+		}
+	}
+}
+
+// TestFormatBinary 负数按 64 位补码输出，width 补 0
+func TestFormatBinary(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{FormatBinary(5, 0), "101"},
+		{FormatBinary(5, 8), "00000101"},
+		{FormatBinary(0, 0), "0"},
+		{FormatSigned(-1), strings.Repeat("1", 64)},
+		{FormatSigned(math.MinInt64), "1" + strings.Repeat("0", 63)},
+		{FormatSigned(math.MaxInt64), strings.Repeat("1", 63)},
+	}
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("got %s, want %s", test.got, test.want)
+		}
+	}
+}
+
+// TestBitTricks 各题的已知答案，包含单个元素、全为负数和 64 位极值
+func TestBitTricks(t *testing.T) {
+	if got := CountBits(5); !reflect.DeepEqual(got, []int{0, 1, 1, 2, 1, 2}) {
+		t.Errorf("CountBits(5) = %v", got)
+	}
+	if got := CountBits(0); !reflect.DeepEqual(got, []int{0}) {
+		t.Errorf("CountBits(0) = %v", got)
+	}
+	reverse := []struct{ n, want uint32 }{
+		{0b00000010100101000001111010011100, 0b00111001011110000010100101000000},
+		{0xFFFFFFFD, 0xBFFFFFFF},
+		{1, 1 << 31},
+		{0, 0},
+	}
+	for _, test := range reverse {
+		if got := ReverseBits(test.n); got != test.want {
+			t.Errorf("ReverseBits(%032b) = %032b, want %032b", test.n, got, test.want)
+		}
+	}
+
+	singles := []struct {
+		nums []int
+		want int
+	}{
+		{[]int{4, 1, 2, 1, 2}, 4},
+		{[]int{-7}, -7},
+		{[]int{-3, -5, -3}, -5},
+		{[]int{math.MinInt64, 9, 9}, math.MinInt64},
+	}
+	for _, test := range singles {
+		if got := SingleNumber(test.nums); got != test.want {
+			t.Errorf("SingleNumber(%v) = %d, want %d", test.nums, got, test.want)
+		}
+	}
+	thrice := []struct {
+		nums []int
+		want int
+	}{
+		{[]int{0, 1, 0, 1, 0, 1, 99}, 99},
+		{[]int{-2, -2, 1, 1, -3, 1, -3, -3, -4, -2}, -4},
+		{[]int{math.MaxInt64}, math.MaxInt64},
+		{[]int{math.MinInt64, -1, -1, -1}, math.MinInt64},
+	}
+	for _, test := range thrice {
+		if got := SingleNumberThrice(test.nums); got != test.want {
+			t.Errorf("SingleNumberThrice(%v) = %d, want %d", test.nums, got, test.want)
+		}
+	}
+	if a, b, bit := SingleNumberPair([]int{1, 2, 1, 3, 2, 5}); a != 3 || b != 5 || bit != 2 {
+		t.Errorf("SingleNumberPair = %d %d %d, want 3 5 2", a, b, bit)
+	}
+	if a, b, _ := SingleNumberPair([]int{-1, math.MinInt64}); a != math.MinInt64 || b != -1 {
+		t.Errorf("SingleNumberPair(-1, MinInt64) = %d %d", a, b)
+	}
+
+	missing := []struct {
+		nums []int
+		want int
+	}{
+		{[]int{3, 0, 1}, 2},
+		{[]int{}, 0},
+		{[]int{0}, 1},
+		{[]int{9, 6, 4, 2, 3, 5, 7, 0, 1}, 8},
+	}
+	for _, test := range missing {
+		if got := MissingNumber(test.nums); got != test.want {
+			t.Errorf("MissingNumber(%v) = %d, want %d", test.nums, got, test.want)
+		}
+	}
+
+	sums := []struct{ a, b, sum int }{
+		{1, 2, 3},
+		{-2, 3, 1},
+		{-5, -7, -12},
+		{math.MinInt32, math.MinInt32, 2 * math.MinInt32},
+		{math.MaxInt32, math.MaxInt32, 2 * math.MaxInt32},
+		{-1, 1, 0},
+	}
+	for _, test := range sums {
+		if got, _ := GetSum(test.a, test.b); got != test.sum {
+			t.Errorf("GetSum(%d, %d) = %d, want %d", test.a, test.b, got, test.sum)
+		}
+	}
+
+	powers := []struct {
+		n         int
+		two, four bool
+	}{
+		{1, true, true},
+		{2, true, false},
+		{16, true, true},
+		{0, false, false},
+		{-4, false, false},
+		{math.MinInt64, false, false},
+		{1 << 62, true, true},
+		{1 << 61, true, false},
+	}
+	for _, test := range powers {
+		if two, four := IsPowerOfTwo(test.n), IsPowerOfFour(test.n); two != test.two || four != test.four {
+			t.Errorf("%d: power of two %v four %v, want %v %v", test.n, two, four, test.two, test.four)
+		}
+	}
+
+	if got := GrayCode(2); !reflect.DeepEqual(got, []int{0, 1, 3, 2}) {
+		t.Errorf("GrayCode(2) = %v", got)
+	}
+	if got := GrayCodeReflect(0); !reflect.DeepEqual(got, []int{0}) {
+		t.Errorf("GrayCodeReflect(0) = %v", got)
+	}
+}
+
+// TestPopCountUnsigned 通过 JSON 传入超过 int64 的无符号数，负数按补码计数
+func TestPopCountUnsigned(t *testing.T) {
+	spec, err := Default.Get("numberOfOneBits")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		input string
+		count int
+	}{
+		{`{"u":18446744073709551615}`, 64},
+		{`{"n":-9223372036854775808}`, 1},
+		{`{"n":-1}`, 64},
+	}
+	for _, test := range tests {
+		output, err := spec.Execute(context.Background(), []byte(test.input))
+		if err != nil {
+			t.Fatalf("%s: %v", test.input, err)
+		}
+		if result := output.(PopCountResult); result.Count != test.count || result.Number != test.count || result.MathBits != test.count {
+			t.Errorf("%s: %+v, want %d", test.input, result, test.count)
+		}
+	}
+	for _, input := range []string{`{}`, `{"n":1,"u":1}`, `{"u":-1}`} {
+		if _, err := spec.Execute(context.Background(), []byte(input)); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: err = %v, want ErrInvalidInput", input, err)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package algorithm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// ResourceTime 超过截止时间
+	ResourceTime = "time"
+	// ResourceSteps 超过步数上限
+	ResourceSteps = "steps"
+	// ResourceMemory 超过内存上限
+	ResourceMemory = "memory"
+
+	// meterCheckEvery 每计这么多步检查一次截止时间
+	meterCheckEvery = 1024
+)
+
+// ErrBudgetExceeded 执行超出预算，具体原因见 BudgetExceededError
+var ErrBudgetExceeded = errors.New("budget exceeded")
+
+// BudgetExceededError 哪一项预算被耗尽
+type BudgetExceededError struct {
+	Resource string
+	Used     int64
+	Limit    int64
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("%v: %s used %d of %d", ErrBudgetExceeded, e.Resource, e.Used, e.Limit)
+}
+
+// Is 使 errors.Is(err, ErrBudgetExceeded) 成立
+func (e *BudgetExceededError) Is(target error) bool {
+	return target == ErrBudgetExceeded
+}
+
+// Budget 一次执行的预算，为 0 的项不限制
+// 步数和内存由算法自己计量：步数是内层循环的迭代次数或递归调用次数，内存是 dp 表等工作空间的字节数
+type Budget struct {
+	Timeout   time.Duration
+	MaxSteps  int64
+	MaxMemory int64
+}
+
+var (
+	// DefaultBudget 调用方没有指定时的预算
+	DefaultBudget = Budget{Timeout: 10 * time.Second, MaxSteps: 1_000_000_000, MaxMemory: 256 << 20}
+	// MaxBudget 调用方可以指定的最大预算
+	MaxBudget = Budget{Timeout: 60 * time.Second, MaxSteps: 10_000_000_000, MaxMemory: 1 << 30}
+	// MaxAsyncBudget 异步任务可以指定的最大预算，只放宽时间和步数
+	MaxAsyncBudget = Budget{Timeout: 10 * time.Minute, MaxSteps: 100_000_000_000, MaxMemory: 1 << 30}
+)
+
+// Clamp 把为 0 的项替换为默认值，超过 MaxBudget 的项降为上限
+func (b Budget) Clamp() Budget {
+	return b.ClampTo(MaxBudget)
+}
+
+// ClampTo 把为 0 的项替换为默认值，超过 limit 的项降为 limit
+func (b Budget) ClampTo(limit Budget) Budget {
+	clamp := func(value, fallback, limit int64) int64 {
+		if value <= 0 {
+			value = fallback
+		}
+		return min(value, limit)
+	}
+	return Budget{
+		Timeout:   time.Duration(clamp(int64(b.Timeout), int64(DefaultBudget.Timeout), int64(limit.Timeout))),
+		MaxSteps:  clamp(b.MaxSteps, DefaultBudget.MaxSteps, limit.MaxSteps),
+		MaxMemory: clamp(b.MaxMemory, DefaultBudget.MaxMemory, limit.MaxMemory),
+	}
+}
+
+// BudgetUsage 预算使用情况，Exceeded 是被耗尽的那一项
+type BudgetUsage struct {
+	Exceeded  string `json:"exceeded,omitempty"`
+	ElapsedMs int64  `json:"elapsedMs"`
+	TimeoutMs int64  `json:"timeoutMs"`
+	Steps     int64  `json:"steps"`
+	MaxSteps  int64  `json:"maxSteps"`
+	Memory    int64  `json:"memory"`
+	MaxMemory int64  `json:"maxMemory"`
+}
+
+// Meter 计量一次执行消耗的预算，nil 表示不计量
+// 超出预算时 Step/Alloc 以 panic 中止算法，由 Spec.Run 恢复为 BudgetExceededError，算法不需要逐层返回错误
+type Meter struct {
+	ctx      context.Context
+	budget   Budget
+	start    time.Time
+	steps    atomic.Int64
+	memory   atomic.Int64
+	peak     atomic.Int64
+	exceeded atomic.Pointer[BudgetExceededError]
+}
+
+// budgetAbort Meter 中止执行时 panic 的值
+type budgetAbort struct {
+	err error
+}
+
+type meterKey struct{}
+
+// WithBudget 按预算设置截止时间并把 Meter 放进 context，调用方需要调用返回的 cancel
+func WithBudget(ctx context.Context, budget Budget) (context.Context, *Meter, context.CancelFunc) {
+	var cancel context.CancelFunc = func() {}
+	if budget.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, budget.Timeout)
+	}
+	m := &Meter{ctx: ctx, budget: budget, start: time.Now()}
+	return context.WithValue(ctx, meterKey{}, m), m, cancel
+}
+
+// MeterFrom 取出 context 中的 Meter，没有时返回 nil
+func MeterFrom(ctx context.Context) *Meter {
+	m, _ := ctx.Value(meterKey{}).(*Meter)
+	return m
+}
+
+// Step 计 n 步，超过步数上限或截止时间时中止
+func (m *Meter) Step(n int64) {
+	if m == nil {
+		return
+	}
+	steps := m.steps.Add(n)
+	if m.budget.MaxSteps > 0 && steps > m.budget.MaxSteps {
+		m.abort(ResourceSteps, steps, m.budget.MaxSteps)
+	}
+	if steps/meterCheckEvery != (steps-n)/meterCheckEvery {
+		m.Check()
+	}
+}
+
+// Check 检查截止时间，context 被取消时同样中止
+func (m *Meter) Check() {
+	if m == nil {
+		return
+	}
+	switch err := m.ctx.Err(); {
+	case errors.Is(err, context.DeadlineExceeded):
+		m.abort(ResourceTime, time.Since(m.start).Milliseconds(), m.budget.Timeout.Milliseconds())
+	case err != nil:
+		panic(budgetAbort{err: err})
+	}
+}
+
+// Alloc 申请 bytes 字节的工作空间，超过内存上限时中止
+func (m *Meter) Alloc(bytes int64) {
+	if m == nil {
+		return
+	}
+	memory := m.memory.Add(bytes)
+	for {
+		peak := m.peak.Load()
+		if memory <= peak || m.peak.CompareAndSwap(peak, memory) {
+			break
+		}
+	}
+	if m.budget.MaxMemory > 0 && memory > m.budget.MaxMemory {
+		m.abort(ResourceMemory, memory, m.budget.MaxMemory)
+	}
+}
+
+// Free 归还 Alloc 申请的空间
+func (m *Meter) Free(bytes int64) {
+	if m == nil {
+		return
+	}
+	m.memory.Add(-bytes)
+}
+
+func (m *Meter) abort(resource string, used, limit int64) {
+	err := &BudgetExceededError{Resource: resource, Used: used, Limit: limit}
+	m.exceeded.CompareAndSwap(nil, err)
+	panic(budgetAbort{err: err})
+}
+
+// Usage 当前的预算使用情况
+func (m *Meter) Usage() *BudgetUsage {
+	if m == nil {
+		return nil
+	}
+	usage := &BudgetUsage{
+		ElapsedMs: time.Since(m.start).Milliseconds(),
+		TimeoutMs: m.budget.Timeout.Milliseconds(),
+		Steps:     m.steps.Load(),
+		MaxSteps:  m.budget.MaxSteps,
+		Memory:    m.peak.Load(),
+		MaxMemory: m.budget.MaxMemory,
+	}
+	if err := m.exceeded.Load(); err != nil {
+		usage.Exceeded = err.Resource
+	}
+	return usage
+}
+
+// recoverBudget 把 Meter 的 panic 转换为错误，其他 panic 继续向上抛出
+func recoverBudget(err *error) {
+	if r := recover(); r != nil {
+		abort, ok := r.(budgetAbort)
+		if !ok {
+			panic(r)
+		}
+		*err = abort.err
+	}
+}
+
+// intBytes n 个 int 占用的字节数，用于 Alloc
+func intBytes(n int) int64 {
+	return int64(n) * 8
+}
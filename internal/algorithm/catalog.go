@@ -0,0 +1,457 @@
+package algorithm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// MaxRecursiveStairs 递归版本 climbStairs 是 2^n，超过后不再计算
+const MaxRecursiveStairs = 30
+
+// TwoSumInput sumUpToTarget / sumUpToTargetHashMap 输入
+type TwoSumInput struct {
+	Nums   []int `json:"nums" binding:"required,min=2,max=10000"`
+	Target *int  `json:"target" binding:"required"`
+}
+
+// PricesInput 股票价格输入
+type PricesInput struct {
+	Prices []int `json:"prices" binding:"required,min=1,max=10000,dive,min=0" doc:"每天的股票价格"`
+}
+
+// NumsInput 单个整数数组输入
+type NumsInput struct {
+	Nums []int `json:"nums" binding:"required,min=1,max=10000"`
+}
+
+// HeightsInput 柱子高度输入
+type HeightsInput struct {
+	Heights []int `json:"heights" binding:"required,min=2,max=10000,dive,min=0"`
+}
+
+// MaxIntStairs int 模式下 climbStairs 的最大台阶数，fn(91) 开始溢出 int64
+const MaxIntStairs = 90
+
+// MaxBigStairs big 模式下 climbStairs 的最大台阶数，结果约 0.21n 位
+const MaxBigStairs = 100000
+
+// ClimbStairsInput 台阶数输入
+type ClimbStairsInput struct {
+	N int `json:"n" binding:"required,min=1,max=1000000000000000000" doc:"台阶数，int 模式至多 90，big 模式至多 100000，mod 模式至多 10^18"`
+	CountingOption
+}
+
+// Validate 按模式检查台阶数上限
+func (in *ClimbStairsInput) Validate() error {
+	switch {
+	case in.Mode == CountBig && in.N > MaxBigStairs:
+		return fmt.Errorf("n must be at most %d with mode big", MaxBigStairs)
+	case in.Mode != CountBig && in.Mode != CountMod && in.N > MaxIntStairs:
+		return fmt.Errorf("n must be at most %d with mode int, use mode big or mod", MaxIntStairs)
+	}
+	return in.CountingOption.Validate()
+}
+
+// WitnessOption 为 true 时 dp 类算法同时返回回溯 dp 表得到的一个具体解
+type WitnessOption struct {
+	Witness bool `json:"witness" doc:"同时返回一个具体解"`
+}
+
+// SequenceInput 需要返回具体子序列的整数数组输入
+type SequenceInput struct {
+	NumsInput
+	WitnessOption
+}
+
+// CoinChangeInput 零钱兑换输入
+type CoinChangeInput struct {
+	Coins  []int `json:"coins" binding:"required,min=1,max=100,dive,min=1"`
+	Amount int   `json:"amount" binding:"min=0,max=10000"`
+	WitnessOption
+}
+
+// TwoStringInput 两个字符串输入
+type TwoStringInput struct {
+	Text1 string `json:"text1" binding:"max=1000"`
+	Text2 string `json:"text2" binding:"max=1000"`
+	WitnessOption
+}
+
+// CombinationSumInput 组合总和输入
+type CombinationSumInput struct {
+	Candidates []int `json:"candidates" binding:"required,min=1,max=100,dive,min=1"`
+	Target     int   `json:"target" binding:"required,min=1,max=500"`
+	SearchOptions
+}
+
+// HousesInput 每间房子的金额
+type HousesInput struct {
+	Houses []int `json:"houses" binding:"required,min=1,max=10000,dive,min=0"`
+	WitnessOption
+}
+
+// DecodeInput 数字串输入
+// limit 大于 0 时按字典序分页返回具体解码
+type DecodeInput struct {
+	Text   string `json:"text" binding:"required,numeric,max=1000"`
+	Offset int    `json:"offset" binding:"min=0" doc:"跳过的解码数"`
+	Limit  int    `json:"limit" binding:"min=0,max=1000" doc:"返回的解码数，为 0 时只计数"`
+	CountingOption
+}
+
+// Validate numeric 标签允许正负号和小数点，这里只接受纯数字
+func (in *DecodeInput) Validate() error {
+	for _, ch := range in.Text {
+		if ch < '0' || ch > '9' {
+			return fmt.Errorf("text must only contain digits 0-9, got %q", ch)
+		}
+	}
+	return in.CountingOption.Validate()
+}
+
+// CoinWaysInput 零钱兑换 II 输入
+type CoinWaysInput struct {
+	Coins  []int `json:"coins" binding:"required,min=1,max=100,dive,min=1"`
+	Amount int   `json:"amount" binding:"min=0,max=10000"`
+	CountingOption
+}
+
+// AdjListInput leetcode 格式邻接表输入
+type AdjListInput struct {
+	AdjList [][]int `json:"adjList" binding:"required,min=1,max=1000" doc:"adjList[i] 是节点 i+1 的邻居"`
+}
+
+// Validate 检查邻居编号都在 [1, n] 范围内
+func (in *AdjListInput) Validate() error {
+	n := len(in.AdjList)
+	for i, neighbors := range in.AdjList {
+		for _, neighbor := range neighbors {
+			if neighbor < 1 || neighbor > n {
+				return fmt.Errorf("adjList[%d]: neighbor %d out of range [1,%d]", i, neighbor, n)
+			}
+		}
+	}
+	return nil
+}
+
+// CourseInput 课程表输入
+type CourseInput struct {
+	NumCourses     int     `json:"numCourses" binding:"required,min=1,max=10000"`
+	Prerequisites  [][]int `json:"prerequisites" binding:"max=50000" doc:"[ai, bi] 表示学习 ai 之前必须先学习 bi"`
+	MaxPerSemester int     `json:"maxPerSemester" binding:"min=0,max=10000" doc:"每学期最多修的课程数，0 表示不限"`
+}
+
+// Validate 每个先修关系必须是 [ai, bi] 且课程编号在 [0, numCourses) 内
+func (in *CourseInput) Validate() error {
+	for i, pair := range in.Prerequisites {
+		if len(pair) != 2 {
+			return fmt.Errorf("prerequisites[%d]: want [course, prerequisite], got %v", i, pair)
+		}
+		for _, course := range pair {
+			if course < 0 || course >= in.NumCourses {
+				return fmt.Errorf("prerequisites[%d]: course %d out of range [0,%d)", i, course, in.NumCourses)
+			}
+		}
+	}
+	return nil
+}
+
+// Pairs 转换为 CourseSchedule 使用的 [2]int
+func (in *CourseInput) Pairs() [][2]int {
+	pairs := make([][2]int, len(in.Prerequisites))
+	for i, pair := range in.Prerequisites {
+		pairs[i] = [2]int{pair[0], pair[1]}
+	}
+	return pairs
+}
+
+// MaxSumResult maxSubarray 结果，left、right 是子数组的边界
+type MaxSumResult struct {
+	MaxSum int `json:"maxSum"`
+	Window
+}
+
+// MinimumResult findMinimumInRotatedArray 结果
+type MinimumResult struct {
+	Minimum int `json:"minimum"`
+	Index   int `json:"index"`
+}
+
+// MaxAreaResult containerWithMostWater 结果，同时返回双指针和暴力解
+type MaxAreaResult struct {
+	MaxArea   int `json:"maxArea"`
+	MaxAreaBF int `json:"maxAreaBF"`
+}
+
+// ClimbStairsResult climbStairs 三种解法的结果，递归只在 n 较小时计算
+// big/mod 模式只返回矩阵快速幂的结果 value
+type ClimbStairsResult struct {
+	Fibonacci *int        `json:"fibonacci,omitempty"`
+	Dynamic   *int        `json:"dynamic,omitempty"`
+	B         *int        `json:"B,omitempty"`
+	Value     *CountValue `json:"value,omitempty"`
+}
+
+// CountResult 只有一个数量的结果
+type CountResult struct {
+	Count int `json:"count"`
+}
+
+// SubsequenceResult longestIncreasingSubsequence 结果，witness 时返回子序列及其下标
+type SubsequenceResult struct {
+	Count       int   `json:"count"`
+	Subsequence []int `json:"subsequence,omitempty"`
+	Indices     []int `json:"indices,omitempty"`
+}
+
+// LCSResult twoStringLongestCommonSubsequence 结果
+type LCSResult struct {
+	Count     int           `json:"count"`
+	Alignment *LCSAlignment `json:"alignment,omitempty"`
+}
+
+// CoinChangeResult coinCharge 结果，witness 时返回使用的硬币
+type CoinChangeResult struct {
+	Count int   `json:"count"`
+	Coins []int `json:"coins,omitempty"`
+}
+
+// RobResult rubHouse 结果，witness 时返回偷的房子下标
+type RobResult struct {
+	MaxSum int   `json:"maxSum"`
+	Houses []int `json:"houses,omitempty"`
+}
+
+// DecodeResult decodeLetter 结果，decodings 是从 offset 开始的一页解码
+// int 模式返回 count，big/mod 模式返回 value
+type DecodeResult struct {
+	Count     *int        `json:"count,omitempty"`
+	Value     *CountValue `json:"value,omitempty"`
+	Decodings []string    `json:"decodings,omitempty"`
+}
+
+// CountingResult 计数类接口结果，int 模式返回 count，big/mod 模式返回 value
+type CountingResult struct {
+	Count *int        `json:"count,omitempty"`
+	Value *CountValue `json:"value,omitempty"`
+}
+
+// CoursePlanResult courseTopology 结果
+// order 是可以完成的课程的学习顺序，blocked 是因环无法完成的课程，cycles 是阻塞它们的环
+type CoursePlanResult struct {
+	TopologyResult
+	Blocked   []int   `json:"blocked"`
+	Cycles    [][]int `json:"cycles"`
+	Semesters [][]int `json:"semesters"`
+}
+
+// CloneGraphResult cloneGraph 结果，clone 是 DFS 克隆按输入格式序列化的结果，dfs、bfs 是两种克隆与原图的比较
+type CloneGraphResult struct {
+	Clone CloneGraphInput `json:"clone"`
+	DFS   CloneCheck      `json:"dfs"`
+	BFS   CloneCheck      `json:"bfs"`
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func init() {
+	Default.MustRegister(
+		Define(Spec{
+			Name: "sumUpToTarget", Title: "Two Sum (brute force)", LeetCode: 1,
+			Category: CategoryHashTable, Time: "O(n^2)", Space: "O(1)",
+		}, TwoSumInput{Nums: []int{1, 3, 4, 5, 8}, Target: intPtr(6)},
+			func(ctx context.Context, in *TwoSumInput) (TwoSumResult[int], error) {
+				return twoSumBruteForce(in.Nums, *in.Target, MeterFrom(ctx)), nil
+			}),
+		Define(Spec{
+			Name: "sumUpToTargetHashMap", Title: "Two Sum (hash map)", LeetCode: 1,
+			Category: CategoryHashTable, Time: "O(n)", Space: "O(n)",
+		}, TwoSumInput{Nums: []int{1, 3, 4, 5, 8}, Target: intPtr(6)},
+			func(ctx context.Context, in *TwoSumInput) (TwoSumResult[int], error) {
+				return TwoSumHashMap(in.Nums, *in.Target), nil
+			}),
+		Define(Spec{
+			Name: "buySold", Title: "Best Time to Buy and Sell Stock", LeetCode: 121,
+			Category: CategorySlidingWindow, Time: "O(n)", Space: "O(1)",
+		}, PricesInput{Prices: []int{7, 1, 5, 3, 6, 4}},
+			func(ctx context.Context, in *PricesInput) (StockResult[int], error) {
+				return MaxProfit(in.Prices), nil
+			}),
+		Define(Spec{
+			Name: "maxSubarray", Title: "Maximum Subarray", LeetCode: 53,
+			Category: CategorySlidingWindow, Time: "O(n)", Space: "O(1)",
+		}, NumsInput{Nums: []int{1, -4, 2, 3, 6, 4}},
+			func(ctx context.Context, in *NumsInput) (MaxSumResult, error) {
+				sum, window := MaxSubarrayWindow(in.Nums)
+				return MaxSumResult{MaxSum: sum, Window: window}, nil
+			}),
+		Define(Spec{
+			Name: "findMinimumInRotatedArray", Title: "Find Minimum in Rotated Sorted Array", LeetCode: 153,
+			Category: CategoryBinarySearch, Time: "O(log n)", Space: "O(1)", Traceable: true,
+		}, RotatedInput{Nums: []int{4, 5, 6, 7, 0, 1, 2}},
+			func(ctx context.Context, in *RotatedInput) (MinimumResult, error) {
+				index := findMinRotated(in.Nums, TraceFrom(ctx))
+				return MinimumResult{Minimum: in.Nums[index], Index: index}, nil
+			}),
+		Define(Spec{
+			Name: "containerWithMostWater", Title: "Container With Most Water", LeetCode: 11,
+			Category: CategoryTwoPointer, Time: "O(n)", Space: "O(1)", Traceable: true,
+		}, HeightsInput{Heights: []int{1, 5, 6, 7, 4, 3, 4}},
+			func(ctx context.Context, in *HeightsInput) (MaxAreaResult, error) {
+				return MaxAreaResult{MaxArea: maxArea(in.Heights, TraceFrom(ctx)), MaxAreaBF: maxAreaBruteForce(in.Heights, MeterFrom(ctx))}, nil
+			}),
+		Define(Spec{
+			Name: "numberOfOneBits", Title: "Number of 1 Bits", LeetCode: 191,
+			Category: CategoryBitManipulation, Time: "O(log n)", Space: "O(1)",
+		}, PopCountInput{N: new(int64)},
+			func(ctx context.Context, in *PopCountInput) (PopCountResult, error) {
+				n, binary := in.value()
+				return PopCountResult{Count: PopCountShift(n), Number: PopCountKernighan(n), MathBits: bits.OnesCount64(n), Binary: binary}, nil
+			}),
+		Define(Spec{
+			Name: "climbStairs", Title: "Climbing Stairs", LeetCode: 70,
+			Category: CategoryDP, Time: "O(n), big/mod O(log n)", Space: "O(1)",
+		}, ClimbStairsInput{N: 5},
+			func(ctx context.Context, in *ClimbStairsInput) (ClimbStairsResult, error) {
+				if in.Mode == CountBig || in.Mode == CountMod {
+					n := uint64(in.N)
+					_, value, err := in.count(
+						func(a Arith[*big.Int]) *big.Int { return ClimbStairsCount(a, n) },
+						func(a Arith[uint64]) uint64 { return ClimbStairsCount(a, n) })
+					return ClimbStairsResult{Value: value}, err
+				}
+				result := ClimbStairsResult{Dynamic: intPtr(ClimbStairsDP(in.N)), B: intPtr(ClimbStairs(in.N))}
+				if in.N <= MaxRecursiveStairs {
+					result.Fibonacci = intPtr(climbStairsRecursive(in.N, MeterFrom(ctx)))
+				}
+				return result, nil
+			}),
+		Define(Spec{
+			Name: "coinCharge", Title: "Coin Change", LeetCode: 322,
+			Category: CategoryDP, Time: "O(amount * coins)", Space: "O(amount)", Traceable: true,
+		}, CoinChangeInput{Coins: []int{1, 2, 5, 2, 5, 10}, Amount: 10},
+			func(ctx context.Context, in *CoinChangeInput) (CoinChangeResult, error) {
+				count, used := coinChange(in.Coins, in.Amount, TraceFrom(ctx), MeterFrom(ctx))
+				result := CoinChangeResult{Count: count}
+				if in.Witness {
+					result.Coins = used
+				}
+				return result, nil
+			}),
+		Define(Spec{
+			Name: "coinChargeWays", Title: "Coin Change II", LeetCode: 518,
+			Category: CategoryDP, Time: "O(amount * coins)", Space: "O(amount)",
+		}, CoinWaysInput{Coins: []int{1, 2, 5}, Amount: 5},
+			func(ctx context.Context, in *CoinWaysInput) (CountingResult, error) {
+				m := MeterFrom(ctx)
+				count, value, err := in.count(
+					func(a Arith[*big.Int]) *big.Int { return coinChangeWays(a, in.Coins, in.Amount, m) },
+					func(a Arith[uint64]) uint64 { return coinChangeWays(a, in.Coins, in.Amount, m) })
+				return CountingResult{Count: count, Value: value}, err
+			}),
+		Define(Spec{
+			Name: "longestIncreasingSubsequence", Title: "Longest Increasing Subsequence", LeetCode: 300,
+			Category: CategoryDP, Time: "O(n^2)", Space: "O(n)",
+		}, SequenceInput{NumsInput: NumsInput{Nums: []int{10, 9, 2, 5, 3, 7, 101, 18}}},
+			func(ctx context.Context, in *SequenceInput) (SubsequenceResult, error) {
+				indices := lisIndices(in.Nums, MeterFrom(ctx))
+				if !in.Witness {
+					return SubsequenceResult{Count: len(indices)}, nil
+				}
+				result := SubsequenceResult{Count: len(indices), Indices: indices, Subsequence: make([]int, len(indices))}
+				for i, index := range indices {
+					result.Subsequence[i] = in.Nums[index]
+				}
+				return result, nil
+			}),
+		Define(Spec{
+			Name: "twoStringLongestCommonSubsequence", Title: "Longest Common Subsequence", LeetCode: 1143,
+			Category: CategoryDP, Time: "O(m * n)", Space: "O(m * n)",
+		}, TwoStringInput{Text1: "abcde", Text2: "ace"},
+			func(ctx context.Context, in *TwoStringInput) (LCSResult, error) {
+				if !in.Witness {
+					return LCSResult{Count: lcsTable([]byte(in.Text1), []byte(in.Text2), MeterFrom(ctx))[len(in.Text1)][len(in.Text2)]}, nil
+				}
+				alignment := alignLongestCommonSubsequence(in.Text1, in.Text2, MeterFrom(ctx))
+				return LCSResult{Count: len(alignment.Sequence), Alignment: &alignment}, nil
+			}),
+		Define(Spec{
+			Name: "backTrack", Title: "Combination Sum", LeetCode: 39,
+			Category: CategoryBacktracking, Time: "O(n^(target/min))", Space: "O(target/min)",
+		}, CombinationSumInput{Candidates: []int{2, 3, 6, 7}, Target: 7},
+			func(ctx context.Context, in *CombinationSumInput) (SearchResult[[]int], error) {
+				return runSearch(ctx, in.SearchOptions, combinationSumSearch(in.Candidates, in.Target)), nil
+			}),
+		Define(Spec{
+			Name: "rubHouse", Title: "House Robber", LeetCode: 198,
+			Category: CategoryDP, Time: "O(n)", Space: "O(n)",
+		}, HousesInput{Houses: []int{2, 7, 9, 3, 1}},
+			func(ctx context.Context, in *HousesInput) (RobResult, error) {
+				result := RobResult{MaxSum: Rob(in.Houses)}
+				if in.Witness {
+					result.Houses = RobHouses(in.Houses)
+				}
+				return result, nil
+			}),
+		Define(Spec{
+			Name: "decodeLetter", Title: "Decode Ways", LeetCode: 91,
+			Category: CategoryDP, Time: "O(n)", Space: "O(n)",
+		}, DecodeInput{Text: "226"},
+			func(ctx context.Context, in *DecodeInput) (DecodeResult, error) {
+				count, value, err := in.count(
+					func(a Arith[*big.Int]) *big.Int { return NumDecodingsCount(a, in.Text) },
+					func(a Arith[uint64]) uint64 { return NumDecodingsCount(a, in.Text) })
+				if err != nil {
+					return DecodeResult{}, err
+				}
+				return DecodeResult{Count: count, Value: value, Decodings: Decodings(in.Text, in.Offset, in.Limit)}, nil
+			}),
+		Define(Spec{
+			Name: "cloneGraph", Title: "Clone Graph", LeetCode: 133,
+			Category: CategoryGraph, Time: "O(V + E)", Space: "O(V)",
+		}, CloneGraphInput{AdjList: [][]int{{2, 4}, {1, 3}, {2, 4}, {1, 3}}},
+			func(ctx context.Context, in *CloneGraphInput) (CloneGraphResult, error) {
+				node := in.Decode()
+				dfs := CloneGraphDFS(node)
+				clone, err := in.Encode(dfs)
+				if err != nil {
+					return CloneGraphResult{}, err
+				}
+				return CloneGraphResult{
+					Clone: clone,
+					DFS:   CompareClone(node, dfs),
+					BFS:   CompareClone(node, CloneGraphBFS(node)),
+				}, nil
+			}),
+		Define(Spec{
+			Name: "courseTopology", Title: "Course Schedule II", LeetCode: 210,
+			Category: CategoryGraph, Time: "O(V + E)", Space: "O(V + E)", Traceable: true,
+		}, CourseInput{NumCourses: 4, Prerequisites: [][]int{{1, 0}, {2, 0}, {3, 1}, {3, 2}}},
+			func(ctx context.Context, in *CourseInput) (CoursePlanResult, error) {
+				pairs := in.Pairs()
+				result := CoursePlanResult{
+					TopologyResult: courseSchedule(in.NumCourses, pairs, TraceFrom(ctx)),
+					Blocked:        []int{},
+					Cycles:         [][]int{},
+					Semesters:      CourseSemesters(in.NumCourses, pairs, in.MaxPerSemester),
+				}
+				if !result.CanFinish {
+					taken := make([]bool, in.NumCourses)
+					for _, course := range result.Order {
+						taken[course] = true
+					}
+					for course, ok := range taken {
+						if !ok {
+							result.Blocked = append(result.Blocked, course)
+						}
+					}
+					result.Cycles = CourseCycles(in.NumCourses, pairs)
+				}
+				return result, nil
+			}),
+	)
+}
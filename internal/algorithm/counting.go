@@ -0,0 +1,229 @@
+package algorithm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"slices"
+)
+
+const (
+	// CountInt 默认模式，结果必须能放进 int64
+	CountInt = "int"
+	// CountBig 用 math/big 计算精确结果
+	CountBig = "big"
+	// CountMod 对调用方给出的素数取模
+	CountMod = "mod"
+)
+
+// Arith 计数类 dp 使用的算术，同一个算法可以用 int、math/big 或模素数计算
+// 实现不修改参数，Add/Mul 总是返回新值
+type Arith[T any] interface {
+	Zero() T
+	One() T
+	Add(a, b T) T
+	Mul(a, b T) T
+}
+
+// BigArith 任意精度整数
+type BigArith struct{}
+
+func (BigArith) Zero() *big.Int             { return new(big.Int) }
+func (BigArith) One() *big.Int              { return big.NewInt(1) }
+func (BigArith) Add(a, b *big.Int) *big.Int { return new(big.Int).Add(a, b) }
+func (BigArith) Mul(a, b *big.Int) *big.Int { return new(big.Int).Mul(a, b) }
+
+// ModArith 模 P 的整数，P 是小于 2^63 的素数，乘法用 128 位中间结果不会溢出
+type ModArith struct {
+	P uint64
+}
+
+// NewModArith 检查 p 是素数
+func NewModArith(p uint64) (ModArith, error) {
+	if p < 2 || p >= 1<<63 || !new(big.Int).SetUint64(p).ProbablyPrime(20) {
+		return ModArith{}, fmt.Errorf("modulus must be a prime below 2^63, got %d", p)
+	}
+	return ModArith{P: p}, nil
+}
+
+func (a ModArith) Zero() uint64           { return 0 }
+func (a ModArith) One() uint64            { return 1 % a.P }
+func (a ModArith) Add(x, y uint64) uint64 { return (x + y) % a.P }
+
+func (a ModArith) Mul(x, y uint64) uint64 {
+	hi, lo := bits.Mul64(x, y)
+	return bits.Rem64(hi, lo, a.P)
+}
+
+// Matrix 方阵
+type Matrix[T any] [][]T
+
+// Identity n 阶单位矩阵
+func Identity[T any](a Arith[T], n int) Matrix[T] {
+	m := make(Matrix[T], n)
+	for i := range m {
+		m[i] = make([]T, n)
+		for j := range m[i] {
+			m[i][j] = a.Zero()
+		}
+		m[i][i] = a.One()
+	}
+	return m
+}
+
+// MatMul 矩阵乘法 O(k^3)
+func MatMul[T any](a Arith[T], x, y Matrix[T]) Matrix[T] {
+	n := len(x)
+	z := make(Matrix[T], n)
+	for i := range z {
+		z[i] = make([]T, n)
+		for j := range z[i] {
+			sum := a.Zero()
+			for k := 0; k < n; k++ {
+				sum = a.Add(sum, a.Mul(x[i][k], y[k][j]))
+			}
+			z[i][j] = sum
+		}
+	}
+	return z
+}
+
+// MatPow 快速幂，O(k^3 log n)
+func MatPow[T any](a Arith[T], m Matrix[T], n uint64) Matrix[T] {
+	result := Identity(a, len(m))
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result = MatMul(a, result, m)
+		}
+		if n > 1 {
+			m = MatMul(a, m, m)
+		}
+	}
+	return result
+}
+
+/*
+LinearRecurrence 常系数线性递推的第 n 项（从 0 开始）
+s[i] = coeffs[0]*s[i-1] + coeffs[1]*s[i-2] + ... + coeffs[k-1]*s[i-k]，前 k 项为 initial
+用伴随矩阵把状态 (s[i], s[i-1], ..., s[i-k+1]) 推进一步，n 很大时用快速幂 O(k^3 log n)
+*/
+func LinearRecurrence[T any](a Arith[T], coeffs, initial []T, n uint64) T {
+	k := len(initial)
+	if n < uint64(k) {
+		return initial[n]
+	}
+	step := Identity(a, k)
+	step[0] = slices.Clone(coeffs)
+	for i := 1; i < k; i++ {
+		step[i][i] = a.Zero()
+		step[i][i-1] = a.One()
+	}
+	power := MatPow(a, step, n-uint64(k-1))
+	result := a.Zero()
+	for j := 0; j < k; j++ {
+		result = a.Add(result, a.Mul(power[0][j], initial[k-1-j]))
+	}
+	return result
+}
+
+// ClimbStairsCount climbStairs 的矩阵快速幂解法，ways(0) = ways(1) = 1，O(log n)
+func ClimbStairsCount[T any](a Arith[T], n uint64) T {
+	return LinearRecurrence(a, []T{a.One(), a.One()}, []T{a.One(), a.One()}, n)
+}
+
+// NumDecodingsCount NumDecodings 按给定算术计数，只保留 dp 的前两项
+func NumDecodingsCount[T any](a Arith[T], text string) T {
+	if len(text) == 0 {
+		return a.Zero()
+	}
+	// prev2 = dp[i-2], prev1 = dp[i-1]
+	prev2, prev1 := a.One(), a.One()
+	for i := 0; i < len(text); i++ {
+		if text[i] < '0' || text[i] > '9' {
+			return a.Zero()
+		}
+		current := a.Zero()
+		if text[i] != '0' {
+			current = prev1
+		}
+		if i > 0 && (text[i-1] == '1' || text[i-1] == '2' && text[i] <= '6') {
+			current = a.Add(current, prev2)
+		}
+		prev2, prev1 = prev1, current
+	}
+	return prev1
+}
+
+/*
+CoinChangeWays leetcode 518 零钱兑换 II
+凑出 amount 的硬币组合数，硬币面值相同的只算一种，组合不计顺序
+外层遍历硬币、内层遍历金额，每种组合只按硬币顺序被数一次
+*/
+func CoinChangeWays[T any](a Arith[T], coins []int, amount int) T {
+	return coinChangeWays(a, coins, amount, nil)
+}
+
+func coinChangeWays[T any](a Arith[T], coins []int, amount int, m *Meter) T {
+	m.Alloc(intBytes(amount + 1))
+	dp := make([]T, amount+1)
+	for i := range dp {
+		dp[i] = a.Zero()
+	}
+	dp[0] = a.One()
+	for _, coin := range slices.Compact(sortedPositive(coins)) {
+		m.Step(int64(amount))
+		for x := coin; x <= amount; x++ {
+			dp[x] = a.Add(dp[x], dp[x-coin])
+		}
+	}
+	return dp[amount]
+}
+
+// ErrCountOverflow int 模式下结果超出 int64
+var ErrCountOverflow = errors.New("count overflows int64, use mode big or mod")
+
+// CountingOption 计数类接口的算术模式
+type CountingOption struct {
+	Mode    string `json:"mode,omitempty" binding:"omitempty,oneof=int big mod" doc:"int（默认）、big（精确大整数）或 mod（对 modulus 取模）"`
+	Modulus int64  `json:"modulus,omitempty" binding:"min=0" doc:"mode 为 mod 时的素数模数"`
+}
+
+// Validate mod 模式需要素数模数，其他模式不能给出模数
+func (o *CountingOption) Validate() error {
+	if o.Mode != CountMod {
+		if o.Modulus != 0 {
+			return errors.New("modulus is only used with mode mod")
+		}
+		return nil
+	}
+	_, err := NewModArith(uint64(o.Modulus))
+	return err
+}
+
+// CountValue 按 mode 计算的计数，value 是十进制字符串，避免超出 JSON 数字的精度
+type CountValue struct {
+	Mode    string `json:"mode"`
+	Modulus int64  `json:"modulus,omitempty"`
+	Value   string `json:"value"`
+	Digits  int    `json:"digits"`
+}
+
+// count 按模式计算：int 模式用精确值检查溢出，返回 int 结果；big/mod 模式返回 CountValue
+// 两个函数通常是同一个泛型算法分别实例化为 *big.Int 和 uint64
+func (o CountingOption) count(exact func(Arith[*big.Int]) *big.Int, mod func(Arith[uint64]) uint64) (*int, *CountValue, error) {
+	var value string
+	switch o.Mode {
+	case CountBig:
+		value = exact(BigArith{}).String()
+	case CountMod:
+		value = fmt.Sprint(mod(ModArith{P: uint64(o.Modulus)}))
+	default:
+		count := exact(BigArith{})
+		if !count.IsInt64() {
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalidInput, ErrCountOverflow)
+		}
+		return intPtr(int(count.Int64())), nil, nil
+	}
+	return nil, &CountValue{Mode: o.Mode, Modulus: o.Modulus, Value: value, Digits: len(value)}, nil
+}
@@ -0,0 +1,408 @@
+package algorithm
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+)
+
+/*
+ClimbStairsRecursive leetcode 70 climb stairs
+
+	it takes n steps to reach the top  ,every time  you can climb either one or two steps  ,how many
+
+ways can  you climb to  the top?
+fn(n) 是n个台阶 有多少种方法的函数
+fn(n) = fn(n-1)+fn(n-2)
+如果用递归计算 会存在很多重复的计算 时间复杂度大概为 2^n
+*/
+func ClimbStairsRecursive(n int) int {
+	return climbStairsRecursive(n, nil)
+}
+
+// climbStairsRecursive 每次递归调用计一步
+func climbStairsRecursive(n int, m *Meter) int {
+	m.Step(1)
+	if n <= 2 {
+		return max(n, 0)
+	}
+	return climbStairsRecursive(n-1, m) + climbStairsRecursive(n-2, m)
+}
+
+// ClimbStairsDP dynamic programming 处理  extra space O(n)  runtime O(N)
+// fn(1)=1 fn(2)=2 fn(n)=fn(n-1)+fn(n-2)
+func ClimbStairsDP(n int) int {
+	if n <= 2 {
+		return max(n, 0)
+	}
+	dp := make([]int, n+1)
+	dp[1] = 1
+	dp[2] = 2
+	for i := 3; i <= n; i++ {
+		dp[i] = dp[i-1] + dp[i-2]
+	}
+	return dp[n]
+}
+
+// ClimbStairs 只保留前两项 空间复杂度为 O(1)
+func ClimbStairs(n int) int {
+	if n <= 2 {
+		return max(n, 0)
+	}
+	a, b := 1, 2
+	for i := 3; i <= n; i++ {
+		a, b = b, a+b
+	}
+	return b
+}
+
+/*
+CoinChange leetcode 322 背包问题  动态规划
+动态规划：考虑所有可能性，可以回头看，类似走迷宫，一般会记录中间状态，一点是最优解）
+贪心算法：一条路走到黑，不一定是最优解，只考虑当前最优解，一般不需要记录中间状态。
+一个切片 里边包含 各种整数的零钱，给一个值amount，返回相加等于amount的最少元素个数，凑不齐返回 -1
+想要找到fn(m)的最小值，最后一步一定要取一个零钱 这个零钱可能是任何一个
+fn(m) = min(fn(m),fn(m-c1)+1,fn(m-c2)+1,....)
+*/
+func CoinChange(coins []int, amount int) int {
+	count, _ := coinChange(coins, amount, nil, nil)
+	return count
+}
+
+// CoinChangeCoins 返回凑出 amount 的一组最少硬币（降序），凑不齐返回 nil
+// 沿 dp 表记录的每个金额最后使用的硬币回溯
+func CoinChangeCoins(coins []int, amount int) []int {
+	_, used := coinChange(coins, amount, nil, nil)
+	return used
+}
+
+func coinChange(coins []int, amount int, t *Trace, m *Meter) (int, []int) {
+	m.Alloc(intBytes(2 * (amount + 1)))
+	dp := make([]int, amount+1)
+	last := make([]int, amount+1)
+	for i := range dp {
+		dp[i] = amount + 1
+	}
+	dp[0] = 0
+	for i := 1; i <= amount; i++ {
+		m.Step(int64(len(coins)))
+		via := -1
+		for _, coin := range coins {
+			if coin > 0 && i >= coin && dp[i-coin]+1 < dp[i] {
+				dp[i] = dp[i-coin] + 1
+				via = coin
+			}
+		}
+		last[i] = via
+		if t.Enabled() {
+			t.Record("dp-row", map[string]interface{}{"amount": i, "coins": dp[i], "lastCoin": via})
+		}
+	}
+	if t.Enabled() {
+		t.Record("dp-table", map[string]interface{}{"dp": slices.Clone(dp)})
+	}
+	// 检查是否能凑齐目标金额
+	if dp[amount] > amount {
+		return -1, nil
+	}
+	used := make([]int, 0, dp[amount])
+	for rest := amount; rest > 0; rest -= last[rest] {
+		used = append(used, last[rest])
+	}
+	slices.SortFunc(used, func(a, b int) int { return b - a })
+	return dp[amount], used
+}
+
+/*
+LongestIncreasingSubsequence leetcode 300   动态规划
+given an integer array nums, find the longest increasing subsequence
+Input: nums = [10,9,2,5,3,7,101,18]
+Output: 4   [2,3,7,101]
+fn[i] 代表以 nums[i] 结尾的最长递增子序列长度
+*/
+func LongestIncreasingSubsequence[T cmp.Ordered](nums []T) int {
+	fn := lisTable(nums, nil)
+	if len(fn) == 0 {
+		return 0
+	}
+	return slices.Max(fn)
+}
+
+// LongestIncreasingSubsequenceIndices 返回一个最长递增子序列的下标
+// 从 fn 最大的位置往前找 nums[j] < nums[i] 且 fn[j] == fn[i]-1 的 j
+func LongestIncreasingSubsequenceIndices[T cmp.Ordered](nums []T) []int {
+	return lisIndices(nums, nil)
+}
+
+func lisIndices[T cmp.Ordered](nums []T, m *Meter) []int {
+	fn := lisTable(nums, m)
+	if len(fn) == 0 {
+		return nil
+	}
+	end := slices.Index(fn, slices.Max(fn))
+	indices := make([]int, fn[end])
+	indices[len(indices)-1] = end
+	for k, i := len(indices)-2, end; k >= 0; k-- {
+		j := i - 1
+		for nums[j] >= nums[i] || fn[j] != fn[i]-1 {
+			j--
+		}
+		indices[k], i = j, j
+	}
+	return indices
+}
+
+func lisTable[T cmp.Ordered](nums []T, m *Meter) []int {
+	m.Alloc(intBytes(len(nums)))
+	fn := make([]int, len(nums))
+	for i := 0; i < len(nums); i++ {
+		m.Step(int64(i))
+		fn[i] = 1
+		for j := 0; j < i; j++ {
+			if nums[i] > nums[j] {
+				fn[i] = max(fn[i], fn[j]+1)
+			}
+		}
+	}
+	return fn
+}
+
+/*
+LongestCommonSubsequence leetcode 1143   动态规划
+given two sequence text1 text2, return the common longest  subsequence length
+Input: text1 = "abcde", text2 = "ace"
+Output: 3
+dp[i][j] 代表 text1前i个元素 text2前j个元素 最长相同的长度
+如果 最后一位相同 那么 dp[i][j]=dp[i-1][j-1]+1
+如果最后一位 不相同 dp[i][j] = max(dp[i][j-1],dp[i-1][j])
+*/
+func LongestCommonSubsequence[T comparable](text1, text2 []T) int {
+	return lcsTable(text1, text2, nil)[len(text1)][len(text2)]
+}
+
+// LongestCommonSubsequencePairs 返回一个最长公共子序列在两个序列中的下标对 [i, j]
+// 从 dp[m][n] 回溯：末尾相同则同时取走，否则走向较大的一侧
+func LongestCommonSubsequencePairs[T comparable](text1, text2 []T) [][2]int {
+	return lcsPairs(text1, text2, nil)
+}
+
+func lcsPairs[T comparable](text1, text2 []T, m *Meter) [][2]int {
+	dp := lcsTable(text1, text2, m)
+	i, j := len(text1), len(text2)
+	pairs := make([][2]int, dp[i][j])
+	for k := len(pairs) - 1; k >= 0; {
+		switch {
+		case text1[i-1] == text2[j-1]:
+			i, j = i-1, j-1
+			pairs[k] = [2]int{i, j}
+			k--
+		case dp[i-1][j] >= dp[i][j-1]:
+			i--
+		default:
+			j--
+		}
+	}
+	return pairs
+}
+
+func lcsTable[T comparable](text1, text2 []T, meter *Meter) [][]int {
+	m, n := len(text1), len(text2)
+	meter.Alloc(intBytes((m + 1) * (n + 1)))
+	dp := make([][]int, m+1)
+	for i := range dp {
+		dp[i] = make([]int, n+1)
+	}
+	for i := 1; i <= m; i++ {
+		meter.Step(int64(n))
+		for j := 1; j <= n; j++ {
+			if text1[i-1] == text2[j-1] {
+				dp[i][j] = dp[i-1][j-1] + 1
+			} else {
+				dp[i][j] = max(dp[i][j-1], dp[i-1][j])
+			}
+		}
+	}
+	return dp
+}
+
+// LongestCommonSubsequenceString 按字节比较两个字符串
+func LongestCommonSubsequenceString(text1, text2 string) int {
+	return LongestCommonSubsequence([]byte(text1), []byte(text2))
+}
+
+// LCSAlignment 按字节对齐的两个字符串，不在公共子序列中的位置用 '-' 补齐
+type LCSAlignment struct {
+	Sequence string `json:"sequence"`
+	Text1    string `json:"text1"`
+	Text2    string `json:"text2"`
+}
+
+// AlignLongestCommonSubsequence 返回最长公共子序列以及据此对齐的两个字符串
+func AlignLongestCommonSubsequence(text1, text2 string) LCSAlignment {
+	return alignLongestCommonSubsequence(text1, text2, nil)
+}
+
+func alignLongestCommonSubsequence(text1, text2 string, m *Meter) LCSAlignment {
+	var sequence, aligned1, aligned2 strings.Builder
+	i, j := 0, 0
+	flush := func(toI, toJ int) {
+		for ; i < toI; i++ {
+			aligned1.WriteByte(text1[i])
+			aligned2.WriteByte('-')
+		}
+		for ; j < toJ; j++ {
+			aligned1.WriteByte('-')
+			aligned2.WriteByte(text2[j])
+		}
+	}
+	for _, pair := range lcsPairs([]byte(text1), []byte(text2), m) {
+		flush(pair[0], pair[1])
+		sequence.WriteByte(text1[i])
+		aligned1.WriteByte(text1[i])
+		aligned2.WriteByte(text2[j])
+		i, j = i+1, j+1
+	}
+	flush(len(text1), len(text2))
+	return LCSAlignment{Sequence: sequence.String(), Text1: aligned1.String(), Text2: aligned2.String()}
+}
+
+/*
+Rob leetcode 198  dynamic programming
+given an integer array ,each term represent the money that the house has , if two near  house were  broken
+the alert system will trigger , return the most money the thief can rub
+dp[i] = max(dp[i-1], dp[i-2]+houses[i])
+*/
+func Rob[T Number](houses []T) T {
+	var zero T
+	if len(houses) == 0 {
+		return zero
+	}
+	return robTable(houses)[len(houses)-1]
+}
+
+// RobHouses 返回一种金额最大的偷法，下标升序
+// 从最后一间往前：dp[i] == dp[i-1] 说明不偷 i 也能达到最优，否则偷 i 并跳过 i-1
+func RobHouses[T Number](houses []T) []int {
+	dp := robTable(houses)
+	var robbed []int
+	for i := len(houses) - 1; i >= 0; {
+		if i > 0 && dp[i] == dp[i-1] {
+			i--
+			continue
+		}
+		robbed = append(robbed, i)
+		i -= 2
+	}
+	slices.Reverse(robbed)
+	return robbed
+}
+
+func robTable[T Number](houses []T) []T {
+	if len(houses) == 0 {
+		return nil
+	}
+	dp := make([]T, len(houses))
+	dp[0] = houses[0]
+	if len(houses) > 1 {
+		dp[1] = max(houses[0], houses[1])
+	}
+	for i := 2; i < len(houses); i++ {
+		dp[i] = max(dp[i-1], dp[i-2]+houses[i])
+	}
+	return dp
+}
+
+/*
+NumDecodings leetcode 91  dynamic programming
+given a string which is made of (0-9) number , decode the numbers to letters  such as '1'->A ,'2'->'B' '26'->Z ，
+how many ways it can decode into，包含非数字字符时返回 0
+*/
+func NumDecodings(text string) int {
+	if len(text) == 0 {
+		return 0
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] < '0' || text[i] > '9' {
+			return 0
+		}
+	}
+	dp := make([]int, len(text)+1)
+	dp[0] = 1
+	if text[0] != '0' {
+		dp[1] = 1
+	}
+	for i := 2; i <= len(text); i++ {
+		//singe letter
+		if text[i-1] != '0' {
+			dp[i] += dp[i-1]
+		}
+		// 双数字解码
+		twoDigit := int(text[i-2]-'0')*10 + int(text[i-1]-'0')
+		if twoDigit >= 10 && twoDigit <= 26 {
+			dp[i] += dp[i-2]
+		}
+	}
+	return dp[len(text)]
+}
+
+/*
+Decodings 按字典序返回 text 的第 offset 个开始的至多 limit 种解码
+suffix[i] 是 text[i:] 的解码数（饱和到 math.MaxInt），单字母分支的结果总是排在双字母分支之前，
+数量不超过剩余 offset 的分支整棵跳过，不需要枚举前面的解码
+*/
+func Decodings(text string, offset, limit int) []string {
+	if len(text) == 0 || limit <= 0 || strings.ContainsFunc(text, func(ch rune) bool { return ch < '0' || ch > '9' }) {
+		return nil
+	}
+	suffix := make([]int, len(text)+2)
+	suffix[len(text)] = 1
+	for i := len(text) - 1; i >= 0; i-- {
+		if text[i] == '0' {
+			continue
+		}
+		suffix[i] = suffix[i+1]
+		if i+1 < len(text) && (text[i] == '1' || text[i] == '2' && text[i+1] <= '6') {
+			suffix[i] = saturatingAdd(suffix[i], suffix[i+2])
+		}
+	}
+	var (
+		result  []string
+		letters []byte
+		walk    func(i int)
+	)
+	walk = func(i int) {
+		if len(result) == limit {
+			return
+		}
+		if i == len(text) {
+			result = append(result, string(letters))
+			return
+		}
+		for width := 1; width <= 2 && i+width <= len(text); width++ {
+			value := int(text[i] - '0')
+			if width == 2 {
+				value = value*10 + int(text[i+1]-'0')
+			}
+			if value < 1 || value > 26 || width == 2 && value < 10 || suffix[i+width] == 0 {
+				continue
+			}
+			if offset >= suffix[i+width] {
+				offset -= suffix[i+width]
+				continue
+			}
+			letters = append(letters, byte('A'+value-1))
+			walk(i + width)
+			letters = letters[:len(letters)-1]
+		}
+	}
+	walk(0)
+	return result
+}
+
+func saturatingAdd(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
@@ -0,0 +1,190 @@
+package ds
+
+// entry 双向链表节点
+type entry[K comparable, V any] struct {
+	key        K
+	value      V
+	freq       int
+	prev, next *entry[K, V]
+}
+
+// list 带哨兵的双向循环链表，哨兵的 next 是最新的节点，prev 是最旧的节点
+type list[K comparable, V any] struct {
+	root entry[K, V]
+	size int
+}
+
+func newList[K comparable, V any]() *list[K, V] {
+	l := &list[K, V]{}
+	l.root.prev, l.root.next = &l.root, &l.root
+	return l
+}
+
+// pushFront 插到最前
+func (l *list[K, V]) pushFront(e *entry[K, V]) {
+	e.prev, e.next = &l.root, l.root.next
+	l.root.next.prev = e
+	l.root.next = e
+	l.size++
+}
+
+func (l *list[K, V]) remove(e *entry[K, V]) {
+	e.prev.next, e.next.prev = e.next, e.prev
+	e.prev, e.next = nil, nil
+	l.size--
+}
+
+// back 最旧的节点，链表为空时返回 nil
+func (l *list[K, V]) back() *entry[K, V] {
+	if l.size == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+/*
+LRU leetcode 146 LRU 缓存，哈希表 + 双向链表，Get 和 Put 都是 O(1)
+访问过的节点移到链表头，容量满时淘汰链表尾（最久没有访问的）
+*/
+type LRU[K comparable, V any] struct {
+	capacity int
+	items    map[K]*entry[K, V]
+	order    *list[K, V]
+}
+
+// NewLRU 容量为 capacity（至少为 1）的 LRU 缓存
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	return &LRU[K, V]{capacity: max(capacity, 1), items: make(map[K]*entry[K, V]), order: newList[K, V]()}
+}
+
+// Len 缓存中的元素个数
+func (c *LRU[K, V]) Len() int {
+	return c.order.size
+}
+
+// Get 查找 key 并标记为最近使用
+func (c *LRU[K, V]) Get(key K) (value V, ok bool) {
+	e, ok := c.items[key]
+	if !ok {
+		return value, false
+	}
+	c.order.remove(e)
+	c.order.pushFront(e)
+	return e.value, true
+}
+
+// Put 写入 key，容量满时淘汰最久没有使用的元素，返回被淘汰的 key
+func (c *LRU[K, V]) Put(key K, value V) (evicted K, ok bool) {
+	if e, exist := c.items[key]; exist {
+		e.value = value
+		c.order.remove(e)
+		c.order.pushFront(e)
+		return evicted, false
+	}
+	if c.order.size == c.capacity {
+		oldest := c.order.back()
+		c.order.remove(oldest)
+		delete(c.items, oldest.key)
+		evicted, ok = oldest.key, true
+	}
+	e := &entry[K, V]{key: key, value: value}
+	c.items[key] = e
+	c.order.pushFront(e)
+	return evicted, ok
+}
+
+// Keys 从最近使用到最久没有使用的 key
+func (c *LRU[K, V]) Keys() []K {
+	keys := make([]K, 0, c.order.size)
+	for e := c.order.root.next; e != &c.order.root; e = e.next {
+		keys = append(keys, e.key)
+	}
+	return keys
+}
+
+/*
+LFU leetcode 460 LFU 缓存，Get 和 Put 都是 O(1)
+每个使用频次一条双向链表（同频次内按 LRU 排序），记录当前最小频次
+容量满时淘汰最小频次链表中最久没有使用的元素；新元素频次为 1，最小频次随之变为 1
+*/
+type LFU[K comparable, V any] struct {
+	capacity int
+	minFreq  int
+	items    map[K]*entry[K, V]
+	freqs    map[int]*list[K, V]
+}
+
+// NewLFU 容量为 capacity（至少为 1）的 LFU 缓存
+func NewLFU[K comparable, V any](capacity int) *LFU[K, V] {
+	return &LFU[K, V]{capacity: max(capacity, 1), items: make(map[K]*entry[K, V]), freqs: make(map[int]*list[K, V])}
+}
+
+// Len 缓存中的元素个数
+func (c *LFU[K, V]) Len() int {
+	return len(c.items)
+}
+
+// touch 频次加一，从旧频次链表移到新频次链表的头部
+func (c *LFU[K, V]) touch(e *entry[K, V]) {
+	old := c.freqs[e.freq]
+	old.remove(e)
+	if old.size == 0 {
+		delete(c.freqs, e.freq)
+		if c.minFreq == e.freq {
+			c.minFreq++
+		}
+	}
+	e.freq++
+	c.bucket(e.freq).pushFront(e)
+}
+
+func (c *LFU[K, V]) bucket(freq int) *list[K, V] {
+	l, ok := c.freqs[freq]
+	if !ok {
+		l = newList[K, V]()
+		c.freqs[freq] = l
+	}
+	return l
+}
+
+// Get 查找 key 并增加使用频次
+func (c *LFU[K, V]) Get(key K) (value V, ok bool) {
+	e, ok := c.items[key]
+	if !ok {
+		return value, false
+	}
+	c.touch(e)
+	return e.value, true
+}
+
+// Freq key 的使用频次，不存在时为 0，不增加频次
+func (c *LFU[K, V]) Freq(key K) int {
+	if e, ok := c.items[key]; ok {
+		return e.freq
+	}
+	return 0
+}
+
+// Put 写入 key 并增加使用频次，容量满时淘汰频次最低、同频次中最久没有使用的元素，返回被淘汰的 key
+func (c *LFU[K, V]) Put(key K, value V) (evicted K, ok bool) {
+	if e, exist := c.items[key]; exist {
+		e.value = value
+		c.touch(e)
+		return evicted, false
+	}
+	if len(c.items) == c.capacity {
+		l := c.freqs[c.minFreq]
+		victim := l.back()
+		l.remove(victim)
+		if l.size == 0 {
+			delete(c.freqs, c.minFreq)
+		}
+		delete(c.items, victim.key)
+		evicted, ok = victim.key, true
+	}
+	e := &entry[K, V]{key: key, value: value, freq: 1}
+	c.items[key] = e
+	c.bucket(1).pushFront(e)
+	c.minFreq = 1
+	return evicted, ok
+}
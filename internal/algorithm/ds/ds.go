@@ -0,0 +1,12 @@
+/*
+Package ds 通用数据结构：二叉堆、单调栈与单调队列、LRU 与 LFU 缓存、线段树、树状数组
+
+都是泛型实现，不依赖 algorithm 的注册表，可以单独引用；算法题的端点在 algorithm 包中
+*/
+package ds
+
+// Number 可以求和的数值类型
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64
+}
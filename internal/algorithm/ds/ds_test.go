@@ -0,0 +1,188 @@
+package ds
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// TestHeap 小顶堆和大顶堆依次弹出有序序列，包含负数和单个元素
+func TestHeap(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	tests := []struct {
+		items, sorted []int
+	}{
+		{[]int{5, 3, 8, 1, 9, 2}, []int{1, 2, 3, 5, 8, 9}},
+		{[]int{-1, -7, -3}, []int{-7, -3, -1}},
+		{[]int{4}, []int{4}},
+		{[]int{}, []int{}},
+		{[]int{2, 2, 1, 1}, []int{1, 1, 2, 2}},
+	}
+	for _, test := range tests {
+		built := NewHeapFrom(append([]int(nil), test.items...), less)
+		pushed := NewHeap(less)
+		for _, x := range test.items {
+			pushed.Push(x)
+		}
+		for _, h := range []*Heap[int]{built, pushed} {
+			got := []int{}
+			for h.Len() > 0 {
+				got = append(got, h.Pop())
+			}
+			if !reflect.DeepEqual(got, test.sorted) {
+				t.Errorf("heap of %v pops %v, want %v", test.items, got, test.sorted)
+			}
+		}
+	}
+
+	h := NewHeapFrom([]int{3, 5}, less)
+	if got := h.PushPop(1); got != 1 || h.Peek() != 3 {
+		t.Errorf("PushPop(1) = %d, top %d, want 1 and 3", got, h.Peek())
+	}
+	if got := h.PushPop(4); got != 3 || h.Peek() != 4 {
+		t.Errorf("PushPop(4) = %d, top %d, want 3 and 4", got, h.Peek())
+	}
+	if got := NewHeap(less).PushPop(7); got != 7 {
+		t.Errorf("PushPop on empty heap = %d, want 7", got)
+	}
+	maxHeap := NewHeapFrom([]int{1, 3, 2}, func(a, b int) bool { return a > b })
+	if got := maxHeap.Pop(); got != 3 {
+		t.Errorf("max heap Pop = %d, want 3", got)
+	}
+}
+
+// TestMonoStack 下一个更大元素：被弹出的元素的答案是弹出它的元素
+func TestMonoStack(t *testing.T) {
+	nums := []int{2, 1, 2, 4, 3}
+	next := []int{-1, -1, -1, -1, -1}
+	s := NewMonoStack(func(top, v int) bool { return top < v })
+	for i, x := range nums {
+		for _, popped := range s.Push(i, x) {
+			next[popped.Index] = x
+		}
+	}
+	if want := []int{4, 2, 4, -1, -1}; !reflect.DeepEqual(next, want) {
+		t.Errorf("next greater = %v, want %v", next, want)
+	}
+	if top, ok := s.Top(); !ok || top != (Indexed[int]{Index: 4, Value: 3}) {
+		t.Errorf("Top = %v %v", top, ok)
+	}
+	if _, ok := NewMonoStack(func(top, v int) bool { return true }).Top(); ok {
+		t.Error("Top of empty stack: ok = true")
+	}
+}
+
+// TestMonoDeque 滑动窗口最大值，窗口大小为 1 和全为负数
+func TestMonoDeque(t *testing.T) {
+	tests := []struct {
+		nums   []int
+		k      int
+		maxima []int
+	}{
+		{[]int{1, 3, -1, -3, 5, 3, 6, 7}, 3, []int{3, 3, 5, 5, 6, 7}},
+		{[]int{-4, -2, -8}, 2, []int{-2, -2}},
+		{[]int{9}, 1, []int{9}},
+		{[]int{5, 4, 3, 2, 1}, 1, []int{5, 4, 3, 2, 1}},
+	}
+	for _, test := range tests {
+		d := NewMonoDeque(func(back, v int) bool { return back <= v })
+		var maxima []int
+		for i, x := range test.nums {
+			d.Push(i, x)
+			d.Evict(i - test.k + 1)
+			if i >= test.k-1 {
+				front, _ := d.Front()
+				maxima = append(maxima, front.Value)
+			}
+		}
+		if !reflect.DeepEqual(maxima, test.maxima) {
+			t.Errorf("window %d over %v = %v, want %v", test.k, test.nums, maxima, test.maxima)
+		}
+	}
+	if _, ok := NewMonoDeque(func(back, v int) bool { return true }).Front(); ok {
+		t.Error("Front of empty deque: ok = true")
+	}
+}
+
+// TestRangeQueries 树状数组和线段树的区间和、线段树的区间最小值，空区间返回单位元
+func TestRangeQueries(t *testing.T) {
+	values := []int{1, -3, 5, 7, -9, 11}
+	f := NewFenwick(values)
+	s := NewSegmentTree(append([]int(nil), values...), func(a, b int) int { return a + b }, 0)
+	queries := []struct{ left, right, sum int }{{0, 6, 12}, {1, 3, 2}, {2, 2, 0}, {5, 6, 11}, {0, 1, 1}}
+	for _, q := range queries {
+		if got := f.RangeSum(q.left, q.right); got != q.sum {
+			t.Errorf("Fenwick.RangeSum(%d, %d) = %d, want %d", q.left, q.right, got, q.sum)
+		}
+		if got := s.Query(q.left, q.right); got != q.sum {
+			t.Errorf("SegmentTree.Query(%d, %d) = %d, want %d", q.left, q.right, got, q.sum)
+		}
+	}
+	f.Add(4, 10)
+	s.Set(4, 1)
+	if f.PrefixSum(6) != 22 || s.Query(0, 6) != 22 || s.Get(4) != 1 {
+		t.Errorf("after update: fenwick %d, segment tree %d, want 22", f.PrefixSum(6), s.Query(0, 6))
+	}
+
+	minimum := NewSegmentTree([]int{4, -2, 7, -5}, func(a, b int) int { return min(a, b) }, math.MaxInt)
+	if got := minimum.Query(0, 3); got != -2 {
+		t.Errorf("min [0, 3) = %d, want -2", got)
+	}
+	if got := minimum.Query(1, 1); got != math.MaxInt {
+		t.Errorf("min of empty range = %d, want identity", got)
+	}
+	// 不满足交换律的 combine 按原顺序合并
+	concat := NewSegmentTree([]string{"a", "b", "c", "d", "e"}, func(a, b string) string { return a + b }, "")
+	if got := concat.Query(1, 5); got != "bcde" {
+		t.Errorf("concat [1, 5) = %q, want bcde", got)
+	}
+	single := NewFenwick([]int64{math.MinInt64})
+	if single.Len() != 1 || single.PrefixSum(1) != math.MinInt64 {
+		t.Errorf("single element fenwick sum = %d", single.PrefixSum(1))
+	}
+}
+
+// TestCaches LRU 与 LFU 的淘汰顺序，容量为 1 时每次写入新 key 都会淘汰
+func TestCaches(t *testing.T) {
+	lru := NewLRU[int, string](2)
+	lru.Put(1, "a")
+	lru.Put(2, "b")
+	lru.Get(1)
+	if evicted, ok := lru.Put(3, "c"); !ok || evicted != 2 {
+		t.Errorf("LRU evicted %d %v, want 2", evicted, ok)
+	}
+	if got := lru.Keys(); !reflect.DeepEqual(got, []int{3, 1}) {
+		t.Errorf("LRU keys = %v, want [3 1]", got)
+	}
+	if _, ok := lru.Put(1, "z"); ok {
+		t.Error("LRU overwrite evicted a key")
+	}
+	if v, _ := lru.Get(1); v != "z" {
+		t.Errorf("LRU Get(1) = %q, want z", v)
+	}
+
+	lfu := NewLFU[int, int](2)
+	lfu.Put(1, 1)
+	lfu.Put(2, 2)
+	lfu.Get(1)
+	if evicted, ok := lfu.Put(3, 3); !ok || evicted != 2 {
+		t.Errorf("LFU evicted %d %v, want 2", evicted, ok)
+	}
+	lfu.Get(3)
+	// 1 和 3 频次都是 2，1 更久没有使用
+	if evicted, ok := lfu.Put(4, 4); !ok || evicted != 1 {
+		t.Errorf("LFU evicted %d %v, want 1", evicted, ok)
+	}
+	if lfu.Freq(3) != 2 || lfu.Freq(1) != 0 || lfu.Len() != 2 {
+		t.Errorf("LFU freq(3) = %d freq(1) = %d len %d", lfu.Freq(3), lfu.Freq(1), lfu.Len())
+	}
+
+	one := NewLFU[string, int](1)
+	one.Put("x", 1)
+	if evicted, ok := one.Put("y", 2); !ok || evicted != "x" {
+		t.Errorf("capacity 1 evicted %q %v, want x", evicted, ok)
+	}
+	if _, ok := one.Get("x"); ok {
+		t.Error("evicted key still present")
+	}
+}
@@ -0,0 +1,47 @@
+package ds
+
+/*
+Fenwick 树状数组，单点增加和前缀和都是 O(log n)
+tree[i]（下标从 1 开始）保存 (i - lowbit(i), i] 的和，lowbit(i) = i & -i
+*/
+type Fenwick[T Number] struct {
+	tree []T
+}
+
+// NewFenwick 用 values 建树 O(n)：每个节点把自己的和加到父节点 i + lowbit(i)
+func NewFenwick[T Number](values []T) *Fenwick[T] {
+	f := &Fenwick[T]{tree: make([]T, len(values)+1)}
+	copy(f.tree[1:], values)
+	for i := 1; i < len(f.tree); i++ {
+		if parent := i + i&-i; parent < len(f.tree) {
+			f.tree[parent] += f.tree[i]
+		}
+	}
+	return f
+}
+
+// Len 元素个数
+func (f *Fenwick[T]) Len() int {
+	return len(f.tree) - 1
+}
+
+// Add 第 i 个元素（从 0 开始）加上 delta
+func (f *Fenwick[T]) Add(i int, delta T) {
+	for i++; i < len(f.tree); i += i & -i {
+		f.tree[i] += delta
+	}
+}
+
+// PrefixSum 前 n 个元素的和，即 [0, n)
+func (f *Fenwick[T]) PrefixSum(n int) T {
+	var sum T
+	for ; n > 0; n -= n & -n {
+		sum += f.tree[n]
+	}
+	return sum
+}
+
+// RangeSum [left, right) 的和
+func (f *Fenwick[T]) RangeSum(left, right int) T {
+	return f.PrefixSum(right) - f.PrefixSum(left)
+}
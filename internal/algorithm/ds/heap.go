@@ -0,0 +1,93 @@
+package ds
+
+// Heap 二叉堆，less(a, b) 为 true 时 a 更靠近堆顶；less 为 < 时是小顶堆
+type Heap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// NewHeap 空堆
+func NewHeap[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// NewHeapFrom 用 items 原地建堆，从最后一个非叶子节点开始依次下沉 O(n)，items 归堆所有
+func NewHeapFrom[T any](items []T, less func(a, b T) bool) *Heap[T] {
+	h := &Heap[T]{items: items, less: less}
+	for i := len(items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// Len 元素个数
+func (h *Heap[T]) Len() int {
+	return len(h.items)
+}
+
+// Push 放到末尾再上浮 O(log n)
+func (h *Heap[T]) Push(item T) {
+	h.items = append(h.items, item)
+	h.up(len(h.items) - 1)
+}
+
+// Peek 堆顶元素，调用方需要保证堆非空
+func (h *Heap[T]) Peek() T {
+	return h.items[0]
+}
+
+// Pop 取出堆顶：与末尾交换后删除末尾，新的堆顶下沉 O(log n)，调用方需要保证堆非空
+func (h *Heap[T]) Pop() T {
+	top := h.items[0]
+	last := len(h.items) - 1
+	h.items[0] = h.items[last]
+	var zero T
+	h.items[last] = zero
+	h.items = h.items[:last]
+	if last > 0 {
+		h.down(0)
+	}
+	return top
+}
+
+// PushPop 先放入 item 再取出堆顶，比分别调用少一次调整；item 比堆顶更靠前时直接返回 item
+func (h *Heap[T]) PushPop(item T) T {
+	if len(h.items) == 0 || !h.less(h.items[0], item) {
+		return item
+	}
+	item, h.items[0] = h.items[0], item
+	h.down(0)
+	return item
+}
+
+// Items 堆中的元素，按堆的数组顺序（不是有序的），不要修改
+func (h *Heap[T]) Items() []T {
+	return h.items
+}
+
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.items[i], h.items[parent]) {
+			return
+		}
+		h.items[i], h.items[parent] = h.items[parent], h.items[i]
+		i = parent
+	}
+}
+
+func (h *Heap[T]) down(i int) {
+	for {
+		best := i
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < len(h.items) && h.less(h.items[child], h.items[best]) {
+				best = child
+			}
+		}
+		if best == i {
+			return
+		}
+		h.items[i], h.items[best] = h.items[best], h.items[i]
+		i = best
+	}
+}
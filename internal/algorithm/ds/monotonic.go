@@ -0,0 +1,93 @@
+package ds
+
+// Indexed 带下标的元素
+type Indexed[T any] struct {
+	Index int `json:"index"`
+	Value T   `json:"value"`
+}
+
+/*
+MonoStack 单调栈：压入 v 之前，把栈顶所有 pop(top, v) 为 true 的元素弹出
+例如 pop 为 top < v 时栈从底到顶非递增，被 v 弹出的元素的"下一个更大元素"就是 v
+*/
+type MonoStack[T any] struct {
+	items []Indexed[T]
+	pop   func(top, v T) bool
+}
+
+// NewMonoStack 空的单调栈
+func NewMonoStack[T any](pop func(top, v T) bool) *MonoStack[T] {
+	return &MonoStack[T]{pop: pop}
+}
+
+// Push 压入下标为 index 的 v，返回被弹出的元素（从栈顶开始）
+func (s *MonoStack[T]) Push(index int, v T) []Indexed[T] {
+	var popped []Indexed[T]
+	for len(s.items) > 0 && s.pop(s.items[len(s.items)-1].Value, v) {
+		popped = append(popped, s.items[len(s.items)-1])
+		s.items = s.items[:len(s.items)-1]
+	}
+	s.items = append(s.items, Indexed[T]{Index: index, Value: v})
+	return popped
+}
+
+// Top 栈顶元素，栈为空时 ok 为 false
+func (s *MonoStack[T]) Top() (top Indexed[T], ok bool) {
+	if len(s.items) == 0 {
+		return top, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+// Items 栈中的元素，从栈底到栈顶，不要修改
+func (s *MonoStack[T]) Items() []Indexed[T] {
+	return s.items
+}
+
+/*
+MonoDeque 单调队列，用于滑动窗口最值：队尾压入 v 之前弹出所有 drop(back, v) 为 true 的元素
+drop 为 back <= v 时队首是窗口最大值；元素按下标递增，Evict 从队首移除滑出窗口的元素
+*/
+type MonoDeque[T any] struct {
+	items []Indexed[T]
+	head  int
+	drop  func(back, v T) bool
+}
+
+// NewMonoDeque 空的单调队列
+func NewMonoDeque[T any](drop func(back, v T) bool) *MonoDeque[T] {
+	return &MonoDeque[T]{drop: drop}
+}
+
+// Len 元素个数
+func (d *MonoDeque[T]) Len() int {
+	return len(d.items) - d.head
+}
+
+// Push 从队尾压入下标为 index 的 v，index 必须大于已有元素的下标
+func (d *MonoDeque[T]) Push(index int, v T) {
+	for d.Len() > 0 && d.drop(d.items[len(d.items)-1].Value, v) {
+		d.items = d.items[:len(d.items)-1]
+	}
+	d.items = append(d.items, Indexed[T]{Index: index, Value: v})
+}
+
+// Evict 从队首移除下标小于 index 的元素
+func (d *MonoDeque[T]) Evict(index int) {
+	for d.Len() > 0 && d.items[d.head].Index < index {
+		d.head++
+	}
+	// 已移除的部分超过一半时整理底层数组，保证均摊 O(1) 且不泄漏
+	if d.head > len(d.items)/2 {
+		d.items = append(d.items[:0], d.items[d.head:]...)
+		d.head = 0
+	}
+}
+
+// Front 队首元素，队列为空时 ok 为 false
+func (d *MonoDeque[T]) Front() (front Indexed[T], ok bool) {
+	if d.Len() == 0 {
+		return front, false
+	}
+	return d.items[d.head], true
+}
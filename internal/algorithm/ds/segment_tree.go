@@ -0,0 +1,64 @@
+package ds
+
+/*
+SegmentTree 线段树，支持单点修改和区间查询，都是 O(log n)
+combine 必须满足结合律，identity 是它的单位元，例如求和 (+, 0)、最小值 (min, +∞)
+自底向上的非递归实现：叶子在 tree[n:2n]，tree[i] = combine(tree[2i], tree[2i+1])
+*/
+type SegmentTree[T any] struct {
+	n        int
+	tree     []T
+	combine  func(a, b T) T
+	identity T
+}
+
+// NewSegmentTree 用 values 建树 O(n)
+func NewSegmentTree[T any](values []T, combine func(a, b T) T, identity T) *SegmentTree[T] {
+	n := len(values)
+	s := &SegmentTree[T]{n: n, tree: make([]T, 2*n), combine: combine, identity: identity}
+	copy(s.tree[n:], values)
+	for i := n - 1; i > 0; i-- {
+		s.tree[i] = combine(s.tree[2*i], s.tree[2*i+1])
+	}
+	return s
+}
+
+// Len 元素个数
+func (s *SegmentTree[T]) Len() int {
+	return s.n
+}
+
+// Set 把第 i 个元素改为 v，再依次更新祖先
+func (s *SegmentTree[T]) Set(i int, v T) {
+	i += s.n
+	s.tree[i] = v
+	for i > 1 {
+		i /= 2
+		s.tree[i] = s.combine(s.tree[2*i], s.tree[2*i+1])
+	}
+}
+
+// Get 第 i 个元素
+func (s *SegmentTree[T]) Get(i int) T {
+	return s.tree[i+s.n]
+}
+
+/*
+Query 区间 [left, right) 的聚合值，区间为空时返回 identity
+左右边界同时向上收缩，左边界是右孩子、右边界是左孩子时把对应节点并入结果
+左右两侧分别累积，保证不满足交换律的 combine 也按原顺序合并
+*/
+func (s *SegmentTree[T]) Query(left, right int) T {
+	leftAcc, rightAcc := s.identity, s.identity
+	for left, right = left+s.n, right+s.n; left < right; left, right = left/2, right/2 {
+		if left%2 == 1 {
+			leftAcc = s.combine(leftAcc, s.tree[left])
+			left++
+		}
+		if right%2 == 1 {
+			right--
+			rightAcc = s.combine(s.tree[right], rightAcc)
+		}
+	}
+	return s.combine(leftAcc, rightAcc)
+}
@@ -0,0 +1,237 @@
+package algorithm
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"time"
+)
+
+const (
+	// FlavorRandom 均匀随机
+	FlavorRandom = "random"
+	// FlavorEmpty 空数组或空字符串
+	FlavorEmpty = "empty"
+	// FlavorAllEqual 所有元素相同
+	FlavorAllEqual = "allEqual"
+	// FlavorSorted 升序
+	FlavorSorted = "sorted"
+	// FlavorRotated 旋转点在 0 的旋转数组，即没有旋转的升序数组
+	FlavorRotated = "rotated"
+	// FlavorDAG 无环：有向图是 DAG，无向图是森林
+	FlavorDAG = "dag"
+	// FlavorCyclic 保证至少有一个环
+	FlavorCyclic = "cyclic"
+	// FlavorZeros 含大量 '0' 的数字串
+	FlavorZeros = "zeros"
+	// FlavorUnicode 含中文等多字节字符的字符串
+	FlavorUnicode = "unicode"
+	// FlavorBST 二叉搜索树
+	FlavorBST = "bst"
+	// FlavorSkewed 每个节点只有一个孩子，退化成链
+	FlavorSkewed = "skewed"
+
+	// DefaultGenerateSize 没有指定 size 时的输入规模
+	DefaultGenerateSize = 10
+	// MaxGenerateValue 取值范围端点的绝对值上限
+	MaxGenerateValue = 1 << 40
+)
+
+// GenerateInput 生成测试输入的参数，相同的参数和种子生成相同的输入
+type GenerateInput struct {
+	Size   int    `json:"size" binding:"min=0,max=10000" doc:"规模（数组长度、节点数、n 等），0 表示默认 10，超过题目上限时截断"`
+	Min    *int   `json:"min" doc:"元素取值下限，省略时使用题目的默认范围"`
+	Max    *int   `json:"max" doc:"元素取值上限，省略时使用题目的默认范围"`
+	Seed   int64  `json:"seed" doc:"随机种子，0 表示随机选择，结果中返回实际使用的种子"`
+	Flavor string `json:"flavor" doc:"random（默认）、empty、allEqual、sorted、rotated、dag、cyclic、zeros，各题支持的见 flavors"`
+	Count  int    `json:"count" binding:"min=0,max=100" doc:"生成的输入个数，0 表示 1"`
+}
+
+// Validate 取值范围不能为空，端点不超过 ±MaxGenerateValue
+func (in *GenerateInput) Validate() error {
+	for _, bound := range []*int{in.Min, in.Max} {
+		if bound != nil && (*bound > MaxGenerateValue || *bound < -MaxGenerateValue) {
+			return fmt.Errorf("min and max must be within ±%d", MaxGenerateValue)
+		}
+	}
+	if in.Min != nil && in.Max != nil && *in.Min > *in.Max {
+		return fmt.Errorf("min %d is greater than max %d", *in.Min, *in.Max)
+	}
+	return nil
+}
+
+// GenerateOptions 补全默认值后的生成参数，传给各题的生成函数
+type GenerateOptions struct {
+	Size   int    `json:"size"`
+	Min    int    `json:"min"`
+	Max    int    `json:"max"`
+	Flavor string `json:"flavor"`
+}
+
+// n 把 size 截断到 [least, most]
+func (o GenerateOptions) n(least, most int) int {
+	return min(max(o.Size, least), most)
+}
+
+// within 把取值范围截断到 [lo, hi]，与之不相交时取最近的端点
+func (o GenerateOptions) within(lo, hi int) GenerateOptions {
+	o.Min = min(max(o.Min, lo), hi)
+	o.Max = min(max(o.Max, lo), hi)
+	return o
+}
+
+// value 取值范围内的一个随机数
+func (o GenerateOptions) value(r *rand.Rand) int {
+	return o.Min + r.Intn(o.Max-o.Min+1)
+}
+
+// ints 按 flavor 生成 n 个取值范围内的整数，empty 时返回空切片
+func (o GenerateOptions) ints(r *rand.Rand, n int) []int {
+	if o.Flavor == FlavorEmpty {
+		return []int{}
+	}
+	nums := make([]int, n)
+	for i := range nums {
+		nums[i] = o.value(r)
+	}
+	switch o.Flavor {
+	case FlavorAllEqual:
+		for i := range nums {
+			nums[i] = nums[0]
+		}
+	case FlavorSorted, FlavorRotated:
+		slices.Sort(nums)
+	}
+	return nums
+}
+
+// Generator 一道题的随机输入生成器
+type Generator struct {
+	Name     string   `json:"name"`
+	Flavors  []string `json:"flavors"`
+	Min      int      `json:"min"`
+	Max      int      `json:"max"`
+	generate func(r *rand.Rand, opts GenerateOptions) interface{}
+}
+
+// DefineGenerator 创建生成器，name 是算法名，[lo, hi] 是默认取值范围，flavors 的第一个是默认 flavor
+func DefineGenerator[In any](name string, lo, hi int, flavors []string, generate func(r *rand.Rand, opts GenerateOptions) In) *Generator {
+	return &Generator{
+		Name:     name,
+		Flavors:  flavors,
+		Min:      lo,
+		Max:      hi,
+		generate: func(r *rand.Rand, opts GenerateOptions) interface{} { return generate(r, opts) },
+	}
+}
+
+// RegisterGenerator 注册生成器
+func (r *Registry) RegisterGenerator(generator *Generator) error {
+	if len(generator.Flavors) == 0 {
+		return fmt.Errorf("algorithm: generator %s has no flavors", generator.Name)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.generators == nil {
+		r.generators = make(map[string]*Generator)
+	}
+	if _, exist := r.generators[generator.Name]; exist {
+		return fmt.Errorf("algorithm: generator %s already registered", generator.Name)
+	}
+	r.generators[generator.Name] = generator
+	return nil
+}
+
+// MustRegisterGenerator 注册生成器，失败时 panic，用于 init 阶段
+func (r *Registry) MustRegisterGenerator(generators ...*Generator) {
+	for _, generator := range generators {
+		if err := r.RegisterGenerator(generator); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// GetGenerator 按算法名查找生成器
+func (r *Registry) GetGenerator(name string) (*Generator, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	generator, exist := r.generators[name]
+	if !exist {
+		return nil, fmt.Errorf("%w: generator %s", ErrNotFound, name)
+	}
+	return generator, nil
+}
+
+// Generators 按名称排序返回所有生成器
+func (r *Registry) Generators() []*Generator {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	generators := make([]*Generator, 0, len(r.generators))
+	for _, generator := range r.generators {
+		generators = append(generators, generator)
+	}
+	sort.Slice(generators, func(i, j int) bool { return generators[i].Name < generators[j].Name })
+	return generators
+}
+
+// GenerateResult 生成的输入以及实际使用的参数，用相同的 seed 和参数可以重放
+type GenerateResult struct {
+	Seed    int64             `json:"seed"`
+	Options GenerateOptions   `json:"options"`
+	Inputs  []json.RawMessage `json:"inputs"`
+}
+
+// Generate 生成 in.Count 个输入，每个输入都经过算法的 Decode 校验，可以直接作为请求体
+func (r *Registry) Generate(name string, in GenerateInput) (*GenerateResult, error) {
+	spec, err := r.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	generator, err := r.GetGenerator(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := in.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	opts := GenerateOptions{Size: in.Size, Min: generator.Min, Max: generator.Max, Flavor: in.Flavor}
+	if opts.Size == 0 {
+		opts.Size = DefaultGenerateSize
+	}
+	if in.Min != nil {
+		opts.Min = *in.Min
+	}
+	if in.Max != nil {
+		opts.Max = *in.Max
+	}
+	if opts.Min > opts.Max {
+		return nil, fmt.Errorf("%w: range [%d, %d] is empty", ErrInvalidInput, opts.Min, opts.Max)
+	}
+	if opts.Flavor == "" {
+		opts.Flavor = generator.Flavors[0]
+	}
+	if !slices.Contains(generator.Flavors, opts.Flavor) {
+		return nil, fmt.Errorf("%w: %s supports flavors %v, got %q", ErrInvalidInput, name, generator.Flavors, opts.Flavor)
+	}
+	seed := in.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	count := max(in.Count, 1)
+	rng := rand.New(rand.NewSource(seed))
+	result := &GenerateResult{Seed: seed, Options: opts, Inputs: make([]json.RawMessage, 0, count)}
+	for i := 0; i < count; i++ {
+		data, err := json.Marshal(generator.generate(rng, opts))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := spec.Decode(data); err != nil {
+			// 生成器负责把参数截断到合法范围，走到这里说明生成器有 bug
+			return nil, fmt.Errorf("generator %s produced an invalid input %s: %v", name, data, err)
+		}
+		result.Inputs = append(result.Inputs, data)
+	}
+	return result, nil
+}
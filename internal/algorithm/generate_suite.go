@@ -0,0 +1,330 @@
+package algorithm
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+var (
+	flavorsArray  = []string{FlavorRandom, FlavorAllEqual, FlavorSorted}
+	flavorsString = []string{FlavorRandom, FlavorEmpty, FlavorAllEqual}
+	flavorsGraph  = []string{FlavorRandom, FlavorDAG, FlavorCyclic}
+)
+
+// distinctInts 取值范围内 n 个不同的整数，范围不够大时个数截断为范围大小
+func distinctInts(r *rand.Rand, opts GenerateOptions, n int) []int {
+	n = min(n, opts.Max-opts.Min+1)
+	seen := make(map[int]bool, n)
+	nums := make([]int, 0, n)
+	for len(nums) < n {
+		if value := opts.value(r); !seen[value] {
+			seen[value] = true
+			nums = append(nums, value)
+		}
+	}
+	return nums
+}
+
+// cycleNodes 随机选 k 个不同节点组成环，节点不足时取全部
+func cycleNodes(r *rand.Rand, n, k int) []int {
+	return r.Perm(n)[:min(n, k)]
+}
+
+/*
+genGraph 按 flavor 生成 n 个节点的图，边权取自 opts 的范围
+random: 至多 2n 条随机边，可能有自环和重边
+dag: 有向图的边都从随机排列中靠前的节点指向靠后的节点；无向图是森林
+cyclic: 在随机图上再加一个至多 3 个节点的环，只有 1 个节点时是自环
+*/
+func genGraph(r *rand.Rand, opts GenerateOptions, n int, directed bool) GraphInput {
+	opts = opts.within(-MaxEdgeWeight, MaxEdgeWeight)
+	m := r.Intn(2*n + 1)
+	in := GraphInput{N: n, Directed: directed, Weighted: true, Edges: []Edge{}}
+	switch opts.Flavor {
+	case FlavorDAG:
+		order := r.Perm(n)
+		if directed {
+			for ; m > 0 && n > 1; m-- {
+				i := r.Intn(n - 1)
+				j := i + 1 + r.Intn(n-1-i)
+				in.Edges = append(in.Edges, Edge{From: order[i], To: order[j], Weight: opts.value(r)})
+			}
+			break
+		}
+		for i := 1; i < n; i++ {
+			if r.Intn(3) > 0 {
+				in.Edges = append(in.Edges, Edge{From: order[r.Intn(i)], To: order[i], Weight: opts.value(r)})
+			}
+		}
+	default:
+		in.Edges = randGraph(r, n, m, directed, opts.Min, opts.Max).Edges
+		if opts.Flavor == FlavorCyclic {
+			cycle := cycleNodes(r, n, 3)
+			for i, u := range cycle {
+				in.Edges = append(in.Edges, Edge{From: u, To: cycle[(i+1)%len(cycle)], Weight: opts.value(r)})
+			}
+		}
+	}
+	return in
+}
+
+// genShortestPath 随机选择起点和终点
+func genShortestPath(r *rand.Rand, opts GenerateOptions, n int) ShortestPathInput {
+	return ShortestPathInput{GraphInput: genGraph(r, opts, n, true), Source: r.Intn(n), Target: intPtr(r.Intn(n))}
+}
+
+// genCourses 先修关系 [a, b] 表示 b 在 a 之前，dag 时 b 在随机排列中总是排在 a 前面
+func genCourses(r *rand.Rand, opts GenerateOptions) CourseInput {
+	n := opts.n(1, 10000)
+	in := CourseInput{NumCourses: n, Prerequisites: [][]int{}}
+	graph := genGraph(r, opts, n, true)
+	for _, e := range graph.Edges {
+		in.Prerequisites = append(in.Prerequisites, []int{e.To, e.From})
+	}
+	return in
+}
+
+// genCloneGraph 无向简单图，没有自环和重边
+func genCloneGraph(r *rand.Rand, opts GenerateOptions) CloneGraphInput {
+	n := opts.n(1, 100)
+	adjList := make([][]int, n)
+	for i := range adjList {
+		adjList[i] = []int{}
+	}
+	for m := r.Intn(2*n + 1); m > 0; m-- {
+		u, v := r.Intn(n), r.Intn(n)
+		if u == v || slices.Contains(adjList[u], v+1) {
+			continue
+		}
+		adjList[u] = append(adjList[u], v+1)
+		adjList[v] = append(adjList[v], u+1)
+	}
+	return CloneGraphInput{AdjList: adjList}
+}
+
+// genTwoSum 大约七成的 target 是两个元素之和
+func genTwoSum(r *rand.Rand, opts GenerateOptions) TwoSumInput {
+	nums := opts.ints(r, opts.n(2, 10000))
+	target := opts.value(r) + opts.value(r)
+	if r.Intn(10) < 7 {
+		i := r.Intn(len(nums))
+		j := (i + 1 + r.Intn(len(nums)-1)) % len(nums)
+		target = nums[i] + nums[j]
+	}
+	return TwoSumInput{Nums: nums, Target: &target}
+}
+
+// genRotated 不同元素的升序数组在随机位置旋转，rotated 时旋转点在 0
+func genRotated(r *rand.Rand, opts GenerateOptions) RotatedInput {
+	nums := distinctInts(r, opts, opts.n(1, 10000))
+	slices.Sort(nums)
+	if opts.Flavor != FlavorRotated {
+		pivot := r.Intn(len(nums))
+		nums = append(nums[pivot:], nums[:pivot]...)
+	}
+	return RotatedInput{Nums: nums}
+}
+
+// genCoins 硬币面值至少为 1，金额不超过 10000
+func genCoins(r *rand.Rand, opts GenerateOptions) ([]int, int) {
+	opts = opts.within(1, 10000)
+	coins := opts.ints(r, opts.n(1, 100))
+	return coins, r.Intn(min(opts.Size*opts.Max, 10000) + 1)
+}
+
+// genTwoStrings allEqual 时两个字符串都由同一个字符组成
+func genTwoStrings(r *rand.Rand, opts GenerateOptions) TwoStringInput {
+	alphabet := "abcd"
+	switch opts.Flavor {
+	case FlavorEmpty:
+		return TwoStringInput{}
+	case FlavorAllEqual:
+		alphabet = "a"
+	}
+	return TwoStringInput{
+		Text1: randString(r, opts.n(0, 1000), alphabet),
+		Text2: randString(r, r.Intn(opts.n(0, 1000)+1), alphabet),
+	}
+}
+
+// genDecode zeros 时大量 '0' 夹在 1、2 之间，包括无法解码的 "00" 和 "30"
+func genDecode(r *rand.Rand, opts GenerateOptions) DecodeInput {
+	n := opts.n(1, 1000)
+	var text string
+	switch opts.Flavor {
+	case FlavorZeros:
+		text = randString(r, n, "0000123")
+	case FlavorAllEqual:
+		text = strings.Repeat(randString(r, 1, "0123456789"), n)
+	default:
+		text = randString(r, n, "0123456789")
+	}
+	// 长数字串的解码数超出 int64，生成的输入用 big 模式
+	return DecodeInput{Text: text, CountingOption: CountingOption{Mode: CountBig}}
+}
+
+// genCombinationSum 候选数至少为 1，target 不超过 500
+func genCombinationSum(r *rand.Rand, opts GenerateOptions) CombinationSumInput {
+	opts = opts.within(1, 500)
+	return CombinationSumInput{
+		Candidates: opts.ints(r, opts.n(1, 30)),
+		Target:     1 + r.Intn(min(4*opts.Max, 500)),
+	}
+}
+
+/*
+genSudoku 从一个合法的完整数独出发，打乱数字、带内的行列和带的顺序，仍然是合法的完整数独
+再挖去 size 个格子（至多 64 个），保证至少有一个解
+*/
+func genSudoku(r *rand.Rand, opts GenerateOptions) SudokuInput {
+	digits := r.Perm(9)
+	shuffle := func() []int {
+		var order []int
+		for _, band := range r.Perm(3) {
+			for _, i := range r.Perm(3) {
+				order = append(order, band*3+i)
+			}
+		}
+		return order
+	}
+	rows, cols := shuffle(), shuffle()
+	board := make(SudokuBoard, 9)
+	for i := range board {
+		board[i] = make([]string, 9)
+		for j := range board[i] {
+			row, col := rows[i], cols[j]
+			board[i][j] = string(rune('1' + digits[(row*3+row/3+col)%9]))
+		}
+	}
+	for _, cell := range r.Perm(81)[:opts.n(0, 64)] {
+		board[cell/9][cell%9] = "."
+	}
+	return SudokuInput{Board: board, SearchOptions: SearchOptions{MaxSolutions: 1}}
+}
+
+// genWordSearch 单词大多沿网格中的随机路径取出，保证能找到，其余是随机字母
+func genWordSearch(r *rand.Rand, opts GenerateOptions) WordSearchInput {
+	alphabet := "ABCDE"
+	if opts.Flavor == FlavorAllEqual {
+		alphabet = "A"
+	}
+	side := opts.n(1, MaxWordBoard)
+	board := make(LetterBoard, side)
+	for i := range board {
+		board[i] = strings.Split(randString(r, side, alphabet), "")
+	}
+	length := 1 + r.Intn(min(side*side, 10))
+	if r.Intn(10) >= 7 {
+		return WordSearchInput{Board: board, Word: randString(r, length, alphabet)}
+	}
+	visited := map[[2]int]bool{}
+	cell := [2]int{r.Intn(side), r.Intn(side)}
+	var word strings.Builder
+	for word.Len() < length {
+		visited[cell] = true
+		word.WriteString(board[cell[0]][cell[1]])
+		var next [][2]int
+		for _, d := range [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}} {
+			neighbor := [2]int{cell[0] + d[0], cell[1] + d[1]}
+			if neighbor[0] >= 0 && neighbor[0] < side && neighbor[1] >= 0 && neighbor[1] < side && !visited[neighbor] {
+				next = append(next, neighbor)
+			}
+		}
+		if len(next) == 0 {
+			break
+		}
+		cell = next[r.Intn(len(next))]
+	}
+	return WordSearchInput{Board: board, Word: word.String()}
+}
+
+func init() {
+	Default.MustRegisterGenerator(
+		DefineGenerator("sumUpToTarget", -100, 100, flavorsArray, genTwoSum),
+		DefineGenerator("sumUpToTargetHashMap", -100, 100, flavorsArray, genTwoSum),
+		DefineGenerator("buySold", 0, 100, flavorsArray, func(r *rand.Rand, opts GenerateOptions) PricesInput {
+			return PricesInput{Prices: opts.within(0, MaxGenerateValue).ints(r, opts.n(1, 10000))}
+		}),
+		DefineGenerator("maxSubarray", -50, 50, flavorsArray, func(r *rand.Rand, opts GenerateOptions) NumsInput {
+			return NumsInput{Nums: opts.ints(r, opts.n(1, 10000))}
+		}),
+		DefineGenerator("findMinimumInRotatedArray", -10000, 10000, []string{FlavorRandom, FlavorRotated}, genRotated),
+		DefineGenerator("containerWithMostWater", 0, 100, flavorsArray, func(r *rand.Rand, opts GenerateOptions) HeightsInput {
+			return HeightsInput{Heights: opts.within(0, MaxGenerateValue).ints(r, opts.n(2, 10000))}
+		}),
+		DefineGenerator("numberOfOneBits", 0, 1<<32-1, []string{FlavorRandom}, func(r *rand.Rand, opts GenerateOptions) PopCountInput {
+			n := int64(opts.within(-MaxGenerateValue, MaxGenerateValue).value(r))
+			return PopCountInput{N: &n}
+		}),
+		DefineGenerator("climbStairs", 0, 0, []string{FlavorRandom}, func(r *rand.Rand, opts GenerateOptions) ClimbStairsInput {
+			return ClimbStairsInput{N: opts.n(1, MaxIntStairs)}
+		}),
+		DefineGenerator("coinCharge", 1, 20, []string{FlavorRandom, FlavorAllEqual}, func(r *rand.Rand, opts GenerateOptions) CoinChangeInput {
+			coins, amount := genCoins(r, opts)
+			return CoinChangeInput{Coins: coins, Amount: amount}
+		}),
+		DefineGenerator("coinChargeWays", 1, 20, []string{FlavorRandom, FlavorAllEqual}, func(r *rand.Rand, opts GenerateOptions) CoinWaysInput {
+			coins, amount := genCoins(r, opts)
+			// 组合数很容易超出 int64，生成的输入用 big 模式
+			return CoinWaysInput{Coins: coins, Amount: amount, CountingOption: CountingOption{Mode: CountBig}}
+		}),
+		DefineGenerator("longestIncreasingSubsequence", -100, 100, flavorsArray, func(r *rand.Rand, opts GenerateOptions) SequenceInput {
+			return SequenceInput{NumsInput: NumsInput{Nums: opts.ints(r, opts.n(1, 10000))}}
+		}),
+		DefineGenerator("twoStringLongestCommonSubsequence", 0, 0, flavorsString, genTwoStrings),
+		DefineGenerator("backTrack", 2, 20, flavorsArray, genCombinationSum),
+		DefineGenerator("rubHouse", 0, 100, flavorsArray, func(r *rand.Rand, opts GenerateOptions) HousesInput {
+			return HousesInput{Houses: opts.within(0, MaxGenerateValue).ints(r, opts.n(1, 10000))}
+		}),
+		DefineGenerator("decodeLetter", 0, 0, []string{FlavorRandom, FlavorZeros, FlavorAllEqual}, genDecode),
+		DefineGenerator("cloneGraph", 0, 0, []string{FlavorRandom}, genCloneGraph),
+		DefineGenerator("courseTopology", 0, 0, flavorsGraph, genCourses),
+
+		DefineGenerator("combinationSum2", 1, 10, flavorsArray, genCombinationSum),
+		DefineGenerator("combinationSum3", 0, 0, []string{FlavorRandom}, func(r *rand.Rand, opts GenerateOptions) CombinationSum3Input {
+			return CombinationSum3Input{K: 1 + r.Intn(9), N: 1 + r.Intn(45)}
+		}),
+		DefineGenerator("permuteUnique", 1, 3, flavorsArray, func(r *rand.Rand, opts GenerateOptions) PermutationInput {
+			return PermutationInput{Nums: opts.ints(r, opts.n(1, 8))}
+		}),
+		DefineGenerator("subsets", 1, 5, []string{FlavorRandom, FlavorEmpty, FlavorAllEqual, FlavorSorted}, func(r *rand.Rand, opts GenerateOptions) SubsetsInput {
+			return SubsetsInput{Nums: opts.ints(r, opts.n(0, 15))}
+		}),
+		DefineGenerator("nQueens", 0, 0, []string{FlavorRandom}, func(r *rand.Rand, opts GenerateOptions) NQueensInput {
+			return NQueensInput{N: opts.n(1, 10)}
+		}),
+		DefineGenerator("solveSudoku", 0, 0, []string{FlavorRandom}, genSudoku),
+		DefineGenerator("wordSearch", 0, 0, []string{FlavorRandom, FlavorAllEqual}, genWordSearch),
+
+		DefineGenerator("dijkstra", 1, 100, flavorsGraph, func(r *rand.Rand, opts GenerateOptions) ShortestPathInput {
+			return genShortestPath(r, opts.within(0, MaxEdgeWeight), opts.n(1, 10000))
+		}),
+		DefineGenerator("bellmanFord", -5, 20, flavorsGraph, func(r *rand.Rand, opts GenerateOptions) ShortestPathInput {
+			return genShortestPath(r, opts, opts.n(1, 10000))
+		}),
+		DefineGenerator("floydWarshall", 1, 100, flavorsGraph, func(r *rand.Rand, opts GenerateOptions) FloydInput {
+			return FloydInput{GraphInput: genGraph(r, opts, opts.n(1, MaxFloydNodes), true)}
+		}),
+		DefineGenerator("kruskal", 1, 100, flavorsGraph, func(r *rand.Rand, opts GenerateOptions) UndirectedInput {
+			return UndirectedInput{GraphInput: genGraph(r, opts, opts.n(1, 10000), false)}
+		}),
+		DefineGenerator("prim", 1, 100, flavorsGraph, func(r *rand.Rand, opts GenerateOptions) UndirectedInput {
+			return UndirectedInput{GraphInput: genGraph(r, opts, opts.n(1, 10000), false)}
+		}),
+		DefineGenerator("unionFind", 1, 1, flavorsGraph, func(r *rand.Rand, opts GenerateOptions) UnionFindInput {
+			n := opts.n(1, 10000)
+			in := UnionFindInput{GraphInput: genGraph(r, opts, n, false), Queries: make([][2]int, n)}
+			for i := range in.Queries {
+				in.Queries[i] = [2]int{r.Intn(n), r.Intn(n)}
+			}
+			return in
+		}),
+		DefineGenerator("stronglyConnectedComponents", 1, 1, flavorsGraph, func(r *rand.Rand, opts GenerateOptions) GraphInput {
+			return genGraph(r, opts, opts.n(1, 10000), true)
+		}),
+		DefineGenerator("findCycle", 1, 1, flavorsGraph, func(r *rand.Rand, opts GenerateOptions) GraphInput {
+			return genGraph(r, opts, opts.n(1, 10000), true)
+		}),
+	)
+}
@@ -0,0 +1,272 @@
+package algorithm
+
+import (
+	"maps"
+	"slices"
+)
+
+// Node 无向图节点
+type Node struct {
+	Val       int
+	Neighbors []*Node
+}
+
+// BuildGraph 按 leetcode 邻接表构建图，adjList[i] 是节点 i+1 的邻居，返回节点 1
+// 调用方需要保证邻居编号在 [1, len(adjList)] 范围内
+func BuildGraph(adjList [][]int) *Node {
+	if len(adjList) == 0 {
+		return nil
+	}
+	nodes := make([]*Node, len(adjList))
+	for i := range adjList {
+		nodes[i] = &Node{Val: i + 1}
+	}
+	for i, neighbors := range adjList {
+		for _, neighbor := range neighbors {
+			nodes[i].Neighbors = append(nodes[i].Neighbors, nodes[neighbor-1])
+		}
+	}
+	return nodes[0]
+}
+
+/*
+CloneGraphDFS leetcode 133 cloneGraph
+dfs : 深度遍历 主要用递归
+*/
+func CloneGraphDFS(node *Node) *Node {
+	if node == nil {
+		return nil
+	}
+	return cloneGraphDFS(node, make(map[*Node]*Node))
+}
+
+func cloneGraphDFS(node *Node, visited map[*Node]*Node) *Node {
+	//查看是否访问过
+	if value, exist := visited[node]; exist {
+		//返回新的值clone
+		return value
+	}
+	clone := &Node{
+		Val:       node.Val,
+		Neighbors: make([]*Node, 0, len(node.Neighbors)),
+	}
+	// key 为原始值 node ，value 为新clone
+	visited[node] = clone
+	for _, value := range node.Neighbors {
+		clone.Neighbors = append(clone.Neighbors, cloneGraphDFS(value, visited))
+	}
+	return clone
+}
+
+// CloneGraphBFS bfs 遍历相邻节点  队列 入队 出队
+func CloneGraphBFS(node *Node) *Node {
+	if node == nil {
+		return nil
+	}
+	visited := make(map[*Node]*Node)
+	//1首先初始化一个值
+	visited[node] = &Node{
+		Val:       node.Val,
+		Neighbors: []*Node{},
+	}
+	//2然后压入队列
+	queue := []*Node{node}
+	for len(queue) > 0 {
+		//3：出队列
+		currentQueue := queue[0]
+		queue = queue[1:]
+		//4：遍历出队列元素 子节点
+		for _, value := range currentQueue.Neighbors {
+			//5：子节点不存在 依次压入队列
+			if _, exist := visited[value]; !exist {
+				queue = append(queue, value)
+				//5：添加访问过的子节点
+				visited[value] = &Node{Val: value.Val, Neighbors: []*Node{}}
+			}
+			//赋值当前节点的 临节点：指向邻居的克隆，而不是新建一个孤立节点
+			visited[currentQueue].Neighbors = append(visited[currentQueue].Neighbors, visited[value])
+		}
+	}
+	return visited[node]
+}
+
+// TopologyResult 课程拓扑排序结果
+type TopologyResult struct {
+	NumCourses int   `json:"coursesNum"`
+	Order      []int `json:"order"`
+	CanFinish  bool  `json:"canFinish"`
+}
+
+/*
+CourseSchedule leetcode 207 courseTopology  拓扑排序
+本学期有 m 门课程要修，但是课程之前 有限制
+先修课程按数组 prerequisites 给出，
+其中 prerequisites[i] = [ai, bi] ，表示如果要学习课程 ai 则必须先学习课程 bi。b->a
+问 能否学习完 m 门课程
+解题思路：
+1：构建图：构建prerequisites 课程间的关联关系
+2：计算入度：计算每门课程的前置数量
+3：bfs 遍历 ：从入度为0 的课程开始
+4：判断是否有环，无环  能全部学完
+调用方需要保证课程编号在 [0, numCourses) 范围内
+*/
+func CourseSchedule(numCourses int, prerequisites [][2]int) TopologyResult {
+	return courseSchedule(numCourses, prerequisites, nil)
+}
+
+func courseSchedule(numCourses int, prerequisites [][2]int, t *Trace) TopologyResult {
+	//1构建图
+	graphy := make(map[int][]int)
+	//2:构建入度
+	inDegree := make(map[int]int)
+	for _, value := range prerequisites {
+		// value[1] → value[0]：必须先修value[1]才能修value[0]
+		graphy[value[1]] = append(graphy[value[1]], value[0])
+		inDegree[value[0]]++
+	}
+	//3: bfs 遍历 ：从入度为0 的课程开始
+	//初始化队列  把入度为0 的压入队列
+	queue := make([]int, 0)
+	for i := 0; i < numCourses; i++ {
+		if inDegree[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+	if t.Enabled() {
+		t.Record("init", map[string]interface{}{"inDegree": maps.Clone(inDegree), "queue": slices.Clone(queue)})
+	}
+	visited := make([]int, 0, numCourses)
+	for len(queue) > 0 {
+		//出队列
+		current := queue[0]
+		queue = queue[1:]
+		visited = append(visited, current)
+		//相邻节点 入度-1后如果 为0 压入
+		var changed map[int]int
+		if t.Enabled() {
+			changed = make(map[int]int, len(graphy[current]))
+		}
+		for _, value := range graphy[current] {
+			inDegree[value]--
+			if changed != nil {
+				changed[value] = inDegree[value]
+			}
+			if inDegree[value] == 0 {
+				queue = append(queue, value)
+			}
+		}
+		// 只记录本轮变化的入度，完整入度见 init 帧
+		if changed != nil {
+			t.Record("dequeue", map[string]interface{}{
+				"course":   current,
+				"inDegree": changed,
+				"queue":    slices.Clone(queue),
+				"order":    slices.Clone(visited),
+			})
+		}
+	}
+	return TopologyResult{
+		NumCourses: numCourses,
+		Order:      visited,
+		CanFinish:  len(visited) == numCourses,
+	}
+}
+
+// courseGraph 先修关系 [a, b] 对应有向边 b -> a
+func courseGraph(numCourses int, prerequisites [][2]int) *Graph {
+	g := NewGraph(numCourses, true)
+	for _, pair := range prerequisites {
+		g.AddEdge(pair[1], pair[0], 1)
+	}
+	return g
+}
+
+/*
+CourseCycles leetcode 210 无法完成的课程中的环
+每个包含环的强连通分量（节点数大于 1 或有自环）给出其中一个环，环按 b -> a 的先修方向排列、首尾相同
+例如 [1, 2, 1] 表示 1 是 2 的先修课，2 又是 1 的先修课
+*/
+func CourseCycles(numCourses int, prerequisites [][2]int) [][]int {
+	g := courseGraph(numCourses, prerequisites)
+	component := make([]int, numCourses)
+	var cycles [][]int
+	for id, nodes := range StronglyConnectedComponents(g) {
+		for _, node := range nodes {
+			component[node] = id
+		}
+		if len(nodes) == 1 && !slices.ContainsFunc(g.Adj[nodes[0]], func(a Arc) bool { return a.To == nodes[0] }) {
+			continue
+		}
+		// Tarjan 按逆拓扑序输出分量，分量的出边只指向已经编号的分量
+		cycles = append(cycles, cycleThrough(g, nodes[0], func(node int) bool { return component[node] == id }))
+	}
+	slices.SortFunc(cycles, func(a, b []int) int { return slices.Compare(a, b) })
+	return cycles
+}
+
+// cycleThrough 只经过 inside 中的节点，BFS 找一条从 start 出发回到 start 的最短环
+func cycleThrough(g *Graph, start int, inside func(int) bool) []int {
+	parent := map[int]int{start: start}
+	queue := []int{start}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, arc := range g.Adj[u] {
+			if !inside(arc.To) {
+				continue
+			}
+			if arc.To == start {
+				cycle := []int{start}
+				for v := u; v != start; v = parent[v] {
+					cycle = append(cycle, v)
+				}
+				slices.Reverse(cycle[1:])
+				return append(cycle, start)
+			}
+			if _, seen := parent[arc.To]; !seen {
+				parent[arc.To] = u
+				queue = append(queue, arc.To)
+			}
+		}
+	}
+	return nil
+}
+
+/*
+CourseSemesters 分学期的学习计划，同一学期的课程互不依赖
+每学期从当前可修的课程中按编号从小到大选，maxPerSemester 大于 0 时每学期最多选这么多门，选不下的顺延
+有上限时这是贪心解，不保证学期数最少（最优解见 leetcode 1494）；被环阻塞的课程不出现在计划中
+*/
+func CourseSemesters(numCourses int, prerequisites [][2]int, maxPerSemester int) [][]int {
+	g := courseGraph(numCourses, prerequisites)
+	inDegree := make([]int, numCourses)
+	for _, e := range g.Edges {
+		inDegree[e.To]++
+	}
+	var available []int
+	for course, degree := range inDegree {
+		if degree == 0 {
+			available = append(available, course)
+		}
+	}
+	semesters := make([][]int, 0)
+	for len(available) > 0 {
+		slices.Sort(available)
+		take := len(available)
+		if maxPerSemester > 0 {
+			take = min(take, maxPerSemester)
+		}
+		semester := slices.Clone(available[:take])
+		available = available[take:]
+		for _, course := range semester {
+			for _, arc := range g.Adj[course] {
+				inDegree[arc.To]--
+				if inDegree[arc.To] == 0 {
+					available = append(available, arc.To)
+				}
+			}
+		}
+		semesters = append(semesters, semester)
+	}
+	return semesters
+}
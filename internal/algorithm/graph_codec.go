@@ -0,0 +1,183 @@
+package algorithm
+
+import (
+	"errors"
+	"fmt"
+)
+
+// NodesFromGraph 把 GraphInput 的内部表示转换为 Node 图，节点 i 的 Val 为 i+1，返回全部节点
+// 无向边在两端的 Neighbors 中各出现一次，边权被忽略
+func NodesFromGraph(g *Graph) []*Node {
+	nodes := make([]*Node, g.N)
+	for i := range nodes {
+		nodes[i] = &Node{Val: i + 1}
+	}
+	for u, arcs := range g.Adj {
+		for _, arc := range arcs {
+			nodes[u].Neighbors = append(nodes[u].Neighbors, nodes[arc.To])
+		}
+	}
+	return nodes
+}
+
+// reachable BFS 返回从 node 出发能到达的节点，按访问顺序
+func reachable(node *Node) []*Node {
+	if node == nil {
+		return nil
+	}
+	seen := map[*Node]bool{node: true}
+	order := []*Node{node}
+	for i := 0; i < len(order); i++ {
+		for _, neighbor := range order[i].Neighbors {
+			if !seen[neighbor] {
+				seen[neighbor] = true
+				order = append(order, neighbor)
+			}
+		}
+	}
+	return order
+}
+
+// EncodeAdjList 按 leetcode 格式序列化从 node 可达的部分，adjList[i] 是 Val 为 i+1 的节点的邻居
+// n 是节点总数，不可达的节点邻居为空；Val 必须在 [1, n] 内且互不相同
+func EncodeAdjList(node *Node, n int) ([][]int, error) {
+	adjList := make([][]int, n)
+	for _, u := range reachable(node) {
+		if u.Val < 1 || u.Val > n {
+			return nil, fmt.Errorf("node value %d out of range [1,%d]", u.Val, n)
+		}
+		if adjList[u.Val-1] != nil {
+			return nil, fmt.Errorf("duplicate node value %d", u.Val)
+		}
+		adjList[u.Val-1] = make([]int, 0, len(u.Neighbors))
+		for _, v := range u.Neighbors {
+			adjList[u.Val-1] = append(adjList[u.Val-1], v.Val)
+		}
+	}
+	for i := range adjList {
+		if adjList[i] == nil {
+			adjList[i] = []int{}
+		}
+	}
+	return adjList, nil
+}
+
+// EncodeEdges 按边列表格式序列化从 node 可达的部分，Val 为 v 的节点编号为 v-1
+// 无向图中每对相互指向的邻居只输出一条边（u <= v 的一侧）
+func EncodeEdges(node *Node, n int, directed bool) (GraphInput, error) {
+	adjList, err := EncodeAdjList(node, n)
+	if err != nil {
+		return GraphInput{}, err
+	}
+	out := GraphInput{N: n, Directed: directed, Edges: []Edge{}}
+	for u, neighbors := range adjList {
+		for _, v := range neighbors {
+			if directed || u+1 <= v {
+				out.Edges = append(out.Edges, Edge{From: u, To: v - 1, Weight: 1})
+			}
+		}
+	}
+	return out, nil
+}
+
+// CloneCheck 克隆结果与原图的比较
+type CloneCheck struct {
+	Isomorphic  bool   `json:"isomorphic"`
+	Independent bool   `json:"independent"`
+	Error       string `json:"error,omitempty"`
+}
+
+/*
+CompareClone 同时 BFS 原图和克隆，检查：
+isomorphic：存在一一对应，对应节点 Val 相同，第 i 个邻居也互相对应
+independent：克隆中的任何节点都不是原图中的节点（深拷贝，不共享指针）
+*/
+func CompareClone(original, clone *Node) CloneCheck {
+	check := CloneCheck{Independent: true}
+	if original == nil || clone == nil {
+		check.Isomorphic = original == clone
+		return check
+	}
+	originals := make(map[*Node]bool)
+	for _, u := range reachable(original) {
+		originals[u] = true
+	}
+	forward := map[*Node]*Node{original: clone}
+	backward := map[*Node]*Node{clone: original}
+	queue := []*Node{original}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		c := forward[u]
+		if originals[c] {
+			check.Independent = false
+		}
+		if c.Val != u.Val || len(c.Neighbors) != len(u.Neighbors) {
+			check.Error = fmt.Sprintf("node %d: clone has value %d and %d neighbors, want %d", u.Val, c.Val, len(c.Neighbors), len(u.Neighbors))
+			return check
+		}
+		for i, v := range u.Neighbors {
+			cv := c.Neighbors[i]
+			mapped, seen := forward[v]
+			if !seen {
+				if _, taken := backward[cv]; taken {
+					check.Error = fmt.Sprintf("node %d: neighbor %d maps to a clone node already used", u.Val, i)
+					return check
+				}
+				forward[v], backward[cv] = cv, v
+				queue = append(queue, v)
+				continue
+			}
+			if mapped != cv {
+				check.Error = fmt.Sprintf("node %d: neighbor %d (value %d) is not linked to its clone", u.Val, i, v.Val)
+				return check
+			}
+		}
+	}
+	check.Isomorphic = true
+	if !check.Independent {
+		check.Error = "clone shares nodes with the original"
+	}
+	return check
+}
+
+// CloneGraphInput cloneGraph 输入，adjList（leetcode 格式）与 graph（边列表格式）二选一，从节点 1 开始克隆
+type CloneGraphInput struct {
+	AdjList [][]int     `json:"adjList,omitempty" binding:"max=1000" doc:"leetcode 格式，adjList[i] 是节点 i+1 的邻居"`
+	Graph   *GraphInput `json:"graph,omitempty" doc:"边列表格式，节点 i 的值为 i+1"`
+}
+
+// Validate 两种格式恰好给出一种
+func (in *CloneGraphInput) Validate() error {
+	switch {
+	case len(in.AdjList) > 0 && in.Graph != nil:
+		return errors.New("adjList and graph are mutually exclusive")
+	case in.Graph != nil:
+		if in.Graph.N > 1000 {
+			return errors.New("graph.n must be at most 1000")
+		}
+		return in.Graph.Validate()
+	case len(in.AdjList) > 0:
+		return (&AdjListInput{AdjList: in.AdjList}).Validate()
+	default:
+		return errors.New("one of adjList or graph is required")
+	}
+}
+
+// Decode 构建 Node 图，返回节点 1
+func (in *CloneGraphInput) Decode() *Node {
+	if in.Graph != nil {
+		return NodesFromGraph(in.Graph.Graph())[0]
+	}
+	return BuildGraph(in.AdjList)
+}
+
+// Encode 按输入相同的格式序列化 node
+func (in *CloneGraphInput) Encode(node *Node) (CloneGraphInput, error) {
+	if in.Graph != nil {
+		graph, err := EncodeEdges(node, in.Graph.N, in.Graph.Directed)
+		return CloneGraphInput{Graph: &graph}, err
+	}
+	adjList, err := EncodeAdjList(node, len(in.AdjList))
+	return CloneGraphInput{AdjList: adjList}, err
+}
@@ -0,0 +1,221 @@
+package algorithm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Dist 最短路距离，Infinity 表示不可达，序列化为 null；NegativeInfinity 表示经过负环可以无限变小，序列化为 "-Infinity"
+type Dist int
+
+const (
+	// Infinity 不可达
+	Infinity Dist = math.MaxInt
+	// NegativeInfinity 经过负环，最短路没有定义
+	NegativeInfinity Dist = math.MinInt
+	// negativeLimit 小于它的距离一定经过了负环，两个不小于它的距离相加不会溢出
+	negativeLimit Dist = math.MinInt / 4
+)
+
+func (d Dist) MarshalJSON() ([]byte, error) {
+	switch d {
+	case Infinity:
+		return []byte("null"), nil
+	case NegativeInfinity:
+		return []byte(`"-Infinity"`), nil
+	}
+	return json.Marshal(int(d))
+}
+
+func (d *Dist) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "null":
+		*d = Infinity
+		return nil
+	case `"-Infinity"`:
+		*d = NegativeInfinity
+		return nil
+	}
+	var value int
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*d = Dist(value)
+	return nil
+}
+
+// add 不可达时保持不可达，经过负环时保持 NegativeInfinity
+func (d Dist) add(w int) Dist {
+	if d == Infinity || d == NegativeInfinity {
+		return d
+	}
+	return d + Dist(w)
+}
+
+// plus 两段距离相加，任一段不可达时不可达，任一段经过负环或和小于 negativeLimit 时为 NegativeInfinity，不会溢出
+func (d Dist) plus(e Dist) Dist {
+	switch {
+	case d == Infinity || e == Infinity:
+		return Infinity
+	case d == NegativeInfinity || e == NegativeInfinity:
+		return NegativeInfinity
+	case d+e < negativeLimit:
+		return NegativeInfinity
+	}
+	return d + e
+}
+
+// Edge 一条边，JSON 格式为 [from, to] 或 [from, to, weight]
+type Edge struct {
+	From   int `json:"from"`
+	To     int `json:"to"`
+	Weight int `json:"weight"`
+}
+
+func (e Edge) MarshalJSON() ([]byte, error) {
+	return json.Marshal([3]int{e.From, e.To, e.Weight})
+}
+
+func (e *Edge) UnmarshalJSON(data []byte) error {
+	var values []int
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("edge must be [from, to] or [from, to, weight]: %w", err)
+	}
+	switch len(values) {
+	case 2:
+		*e = Edge{From: values[0], To: values[1], Weight: 1}
+	case 3:
+		*e = Edge{From: values[0], To: values[1], Weight: values[2]}
+	default:
+		return fmt.Errorf("edge must be [from, to] or [from, to, weight], got %d values", len(values))
+	}
+	return nil
+}
+
+// JSONSchema 边在 JSON 中是 2 或 3 个整数的数组
+func (Edge) JSONSchema() *Schema {
+	two, three := 2, 3
+	return &Schema{Type: "array", Items: &Schema{Type: "integer"}, MinItems: &two, MaxItems: &three,
+		Description: "[from, to] 或 [from, to, weight]"}
+}
+
+// Arc 邻接表中的一项，JSON 格式为 to 或 [to, weight]
+type Arc struct {
+	To     int `json:"to"`
+	Weight int `json:"weight"`
+}
+
+func (a Arc) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]int{a.To, a.Weight})
+}
+
+func (a *Arc) UnmarshalJSON(data []byte) error {
+	var to int
+	if err := json.Unmarshal(data, &to); err == nil {
+		*a = Arc{To: to, Weight: 1}
+		return nil
+	}
+	var values []int
+	if err := json.Unmarshal(data, &values); err != nil || len(values) != 2 {
+		return errors.New("adjacency entry must be to or [to, weight]")
+	}
+	*a = Arc{To: values[0], Weight: values[1]}
+	return nil
+}
+
+// JSONSchema 邻接表项是整数或 [to, weight]
+func (Arc) JSONSchema() *Schema {
+	return &Schema{Description: "to 或 [to, weight]"}
+}
+
+// MaxEdgeWeight 边权绝对值上限，保证路径和不会溢出
+const MaxEdgeWeight = 1000000
+
+// GraphInput 所有图算法共用的输入格式，节点编号为 0..n-1
+// edges 与 adjList 二选一；无向图的邻接表中每条边出现两次，只取 u<=v 的一半
+// weighted 为 false 时所有边权视为 1
+type GraphInput struct {
+	N        int     `json:"n" binding:"required,min=1,max=10000" doc:"节点数，编号 0..n-1"`
+	Directed bool    `json:"directed"`
+	Weighted bool    `json:"weighted"`
+	Edges    []Edge  `json:"edges,omitempty" binding:"max=100000"`
+	AdjList  [][]Arc `json:"adjList,omitempty" binding:"max=10000" doc:"adjList[u] 是 u 的邻居"`
+}
+
+// Validate 检查节点编号和边权范围
+func (in *GraphInput) Validate() error {
+	if len(in.Edges) > 0 && len(in.AdjList) > 0 {
+		return errors.New("edges and adjList are mutually exclusive")
+	}
+	if len(in.AdjList) > in.N {
+		return fmt.Errorf("adjList has %d entries but n is %d", len(in.AdjList), in.N)
+	}
+	check := func(where string, u, v, w int) error {
+		if u < 0 || u >= in.N || v < 0 || v >= in.N {
+			return fmt.Errorf("%s: node out of range [0,%d)", where, in.N)
+		}
+		if in.Weighted && (w > MaxEdgeWeight || w < -MaxEdgeWeight) {
+			return fmt.Errorf("%s: weight %d exceeds ±%d", where, w, MaxEdgeWeight)
+		}
+		return nil
+	}
+	for i, e := range in.Edges {
+		if err := check(fmt.Sprintf("edges[%d]", i), e.From, e.To, e.Weight); err != nil {
+			return err
+		}
+	}
+	for u, arcs := range in.AdjList {
+		for i, a := range arcs {
+			if err := check(fmt.Sprintf("adjList[%d][%d]", u, i), u, a.To, a.Weight); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Graph 转换为内部表示
+func (in *GraphInput) Graph() *Graph {
+	weight := func(w int) int {
+		if in.Weighted {
+			return w
+		}
+		return 1
+	}
+	g := NewGraph(in.N, in.Directed)
+	for _, e := range in.Edges {
+		g.AddEdge(e.From, e.To, weight(e.Weight))
+	}
+	for u, arcs := range in.AdjList {
+		for _, a := range arcs {
+			if in.Directed || u <= a.To {
+				g.AddEdge(u, a.To, weight(a.Weight))
+			}
+		}
+	}
+	return g
+}
+
+// Graph 邻接表表示的图，无向边在 Adj 中存两个方向，在 Edges 中只存一次
+type Graph struct {
+	N        int
+	Directed bool
+	Adj      [][]Arc
+	Edges    []Edge
+}
+
+// NewGraph 创建 n 个节点的空图
+func NewGraph(n int, directed bool) *Graph {
+	return &Graph{N: n, Directed: directed, Adj: make([][]Arc, n)}
+}
+
+// AddEdge 添加一条边，无向图同时添加反向弧
+func (g *Graph) AddEdge(from, to, weight int) {
+	g.Edges = append(g.Edges, Edge{From: from, To: to, Weight: weight})
+	g.Adj[from] = append(g.Adj[from], Arc{To: to, Weight: weight})
+	if !g.Directed && from != to {
+		g.Adj[to] = append(g.Adj[to], Arc{To: from, Weight: weight})
+	}
+}
@@ -0,0 +1,306 @@
+package algorithm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// MaxFloydNodes Floyd-Warshall 是 O(V^3)，节点数单独限制
+const MaxFloydNodes = 200
+
+// ShortestPathInput 单源最短路输入，target 不为空时额外返回到 target 的路径
+type ShortestPathInput struct {
+	GraphInput
+	Source int  `json:"source" binding:"min=0"`
+	Target *int `json:"target" binding:"omitempty,min=0"`
+}
+
+// Validate 检查图以及 source、target 的范围
+func (in *ShortestPathInput) Validate() error {
+	if err := in.GraphInput.Validate(); err != nil {
+		return err
+	}
+	if in.Source >= in.N {
+		return fmt.Errorf("source %d out of range [0,%d)", in.Source, in.N)
+	}
+	if in.Target != nil && *in.Target >= in.N {
+		return fmt.Errorf("target %d out of range [0,%d)", *in.Target, in.N)
+	}
+	return nil
+}
+
+// FloydInput 全源最短路输入
+type FloydInput struct {
+	GraphInput
+}
+
+// Validate 节点数不超过 MaxFloydNodes
+func (in *FloydInput) Validate() error {
+	if in.N > MaxFloydNodes {
+		return fmt.Errorf("n must be at most %d for floydWarshall", MaxFloydNodes)
+	}
+	return in.GraphInput.Validate()
+}
+
+// UndirectedInput 最小生成树只对无向图有意义
+type UndirectedInput struct {
+	GraphInput
+}
+
+// Validate 拒绝有向图
+func (in *UndirectedInput) Validate() error {
+	if in.Directed {
+		return errors.New("graph must be undirected")
+	}
+	return in.GraphInput.Validate()
+}
+
+// UnionFindInput 按边合并后回答连通性查询，边的方向被忽略
+type UnionFindInput struct {
+	GraphInput
+	Queries [][2]int `json:"queries" binding:"max=10000" doc:"[u, v] 查询 u 与 v 是否连通"`
+}
+
+// Validate 查询中的节点在范围内
+func (in *UnionFindInput) Validate() error {
+	if err := in.GraphInput.Validate(); err != nil {
+		return err
+	}
+	for i, q := range in.Queries {
+		if q[0] < 0 || q[0] >= in.N || q[1] < 0 || q[1] >= in.N {
+			return fmt.Errorf("queries[%d]: node out of range [0,%d)", i, in.N)
+		}
+	}
+	return nil
+}
+
+// ShortestPathResult 单源最短路结果，dist 中不可达为 null
+type ShortestPathResult struct {
+	ShortestPaths
+	Path          []int `json:"path,omitempty"`
+	Distance      *Dist `json:"distance,omitempty"`
+	NegativeCycle []int `json:"negativeCycle,omitempty"`
+}
+
+// AllPairsResult floydWarshall 结果
+type AllPairsResult struct {
+	Dist          [][]Dist `json:"dist"`
+	NegativeCycle bool     `json:"negativeCycle"`
+}
+
+// UnionFindResult unionFind 结果
+type UnionFindResult struct {
+	Count     int     `json:"count"`
+	Groups    [][]int `json:"groups"`
+	Connected []bool  `json:"connected"`
+}
+
+// ComponentsResult stronglyConnectedComponents 结果
+type ComponentsResult struct {
+	Count      int     `json:"count"`
+	Components [][]int `json:"components"`
+}
+
+// CycleResult findCycle 结果，cycle 首尾节点相同
+type CycleResult struct {
+	HasCycle bool  `json:"hasCycle"`
+	Cycle    []int `json:"cycle"`
+}
+
+func shortestPathResult(paths ShortestPaths, target *int) ShortestPathResult {
+	result := ShortestPathResult{ShortestPaths: paths}
+	if target != nil {
+		distance := paths.Dist[*target]
+		result.Distance = &distance
+		result.Path = paths.PathTo(*target)
+	}
+	return result
+}
+
+// validCycle 环首尾相同且相邻节点之间都有边
+func validCycle(g *Graph, cycle []int) bool {
+	if len(cycle) < 2 || cycle[0] != cycle[len(cycle)-1] {
+		return false
+	}
+	for i := 0; i+1 < len(cycle); i++ {
+		if !slices.ContainsFunc(g.Adj[cycle[i]], func(a Arc) bool { return a.To == cycle[i+1] }) {
+			return false
+		}
+	}
+	return true
+}
+
+// randGraph 生成 n 个节点、m 条边、边权在 [lo, hi] 的随机图
+func randGraph(r *rand.Rand, n, m int, directed bool, lo, hi int) GraphInput {
+	in := GraphInput{N: n, Directed: directed, Weighted: true, Edges: make([]Edge, m)}
+	for i := range in.Edges {
+		in.Edges[i] = Edge{From: r.Intn(n), To: r.Intn(n), Weight: lo + r.Intn(hi-lo+1)}
+	}
+	return in
+}
+
+// shrinkGraph 依次尝试删掉一条边
+func shrinkGraph(in GraphInput) []GraphInput {
+	result := make([]GraphInput, 0, len(in.Edges))
+	for i := range in.Edges {
+		smaller := in
+		smaller.Edges = slices.Delete(slices.Clone(in.Edges), i, i+1)
+		result = append(result, smaller)
+	}
+	return result
+}
+
+var exampleGraph = GraphInput{N: 5, Weighted: true, Edges: []Edge{
+	{0, 1, 4}, {0, 2, 1}, {2, 1, 2}, {1, 3, 1}, {2, 3, 5}, {3, 4, 3},
+}}
+
+func init() {
+	Default.MustRegister(
+		Define(Spec{
+			Name: "dijkstra", Title: "Network Delay Time (Dijkstra)", LeetCode: 743,
+			Category: CategoryGraph, Time: "O((V + E) log V)", Space: "O(V + E)", Traceable: true,
+		}, ShortestPathInput{GraphInput: exampleGraph, Target: intPtr(4)},
+			func(ctx context.Context, in *ShortestPathInput) (ShortestPathResult, error) {
+				paths, err := dijkstra(in.Graph(), in.Source, TraceFrom(ctx))
+				if err != nil {
+					return ShortestPathResult{}, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+				}
+				return shortestPathResult(paths, in.Target), nil
+			}),
+		Define(Spec{
+			Name: "bellmanFord", Title: "Bellman-Ford Shortest Paths", LeetCode: 787,
+			Category: CategoryGraph, Time: "O(V * E)", Space: "O(V)",
+		}, ShortestPathInput{GraphInput: GraphInput{N: 4, Directed: true, Weighted: true,
+			Edges: []Edge{{0, 1, 4}, {0, 2, 5}, {1, 3, 3}, {2, 1, -3}}}, Target: intPtr(3)},
+			func(ctx context.Context, in *ShortestPathInput) (ShortestPathResult, error) {
+				paths, cycle := bellmanFord(in.Graph(), in.Source, MeterFrom(ctx))
+				result := shortestPathResult(paths, in.Target)
+				result.NegativeCycle = cycle
+				return result, nil
+			}),
+		Define(Spec{
+			Name: "floydWarshall", Title: "Floyd-Warshall All Pairs Shortest Paths", LeetCode: 1334,
+			Category: CategoryGraph, Time: "O(V^3)", Space: "O(V^2)",
+		}, FloydInput{GraphInput: exampleGraph},
+			func(ctx context.Context, in *FloydInput) (AllPairsResult, error) {
+				dist := floydWarshall(in.Graph(), MeterFrom(ctx))
+				result := AllPairsResult{Dist: dist}
+				for i := range dist {
+					result.NegativeCycle = result.NegativeCycle || dist[i][i] < 0
+				}
+				return result, nil
+			}),
+		Define(Spec{
+			Name: "kruskal", Title: "Minimum Spanning Tree (Kruskal)", LeetCode: 1584,
+			Category: CategoryGraph, Time: "O(E log E)", Space: "O(V + E)",
+		}, UndirectedInput{GraphInput: exampleGraph},
+			func(ctx context.Context, in *UndirectedInput) (MST, error) {
+				return Kruskal(in.Graph()), nil
+			}),
+		Define(Spec{
+			Name: "prim", Title: "Minimum Spanning Tree (Prim)", LeetCode: 1584,
+			Category: CategoryGraph, Time: "O(E log E)", Space: "O(V + E)",
+		}, UndirectedInput{GraphInput: exampleGraph},
+			func(ctx context.Context, in *UndirectedInput) (MST, error) {
+				return Prim(in.Graph()), nil
+			}),
+		Define(Spec{
+			Name: "unionFind", Title: "Number of Provinces (Union-Find)", LeetCode: 547,
+			Category: CategoryGraph, Time: "O(E α(V))", Space: "O(V)",
+		}, UnionFindInput{GraphInput: GraphInput{N: 5, Edges: []Edge{{0, 1, 1}, {1, 2, 1}, {3, 4, 1}}},
+			Queries: [][2]int{{0, 2}, {0, 3}}},
+			func(ctx context.Context, in *UnionFindInput) (UnionFindResult, error) {
+				uf := NewUnionFind(in.N)
+				for _, e := range in.Graph().Edges {
+					uf.Union(e.From, e.To)
+				}
+				result := UnionFindResult{Count: uf.Count(), Groups: uf.Groups(), Connected: make([]bool, len(in.Queries))}
+				for i, q := range in.Queries {
+					result.Connected[i] = uf.Connected(q[0], q[1])
+				}
+				return result, nil
+			}),
+		Define(Spec{
+			Name: "stronglyConnectedComponents", Title: "Strongly Connected Components (Tarjan)",
+			Category: CategoryGraph, Time: "O(V + E)", Space: "O(V)",
+		}, GraphInput{N: 5, Directed: true, Edges: []Edge{{0, 1, 1}, {1, 2, 1}, {2, 0, 1}, {2, 3, 1}, {3, 4, 1}}},
+			func(ctx context.Context, in *GraphInput) (ComponentsResult, error) {
+				components := StronglyConnectedComponents(in.Graph())
+				return ComponentsResult{Count: len(components), Components: components}, nil
+			}),
+		Define(Spec{
+			Name: "findCycle", Title: "Find Cycle",
+			Category: CategoryGraph, Time: "O(V + E)", Space: "O(V)",
+		}, GraphInput{N: 4, Directed: true, Edges: []Edge{{0, 1, 1}, {1, 2, 1}, {2, 3, 1}, {3, 1, 1}}},
+			func(ctx context.Context, in *GraphInput) (CycleResult, error) {
+				cycle := FindCycle(in.Graph())
+				return CycleResult{HasCycle: cycle != nil, Cycle: cycle}, nil
+			}),
+	)
+
+	Default.MustRegisterPair(
+		DefinePair("dijkstra", "dijkstra vs bellmanFord（非负权）",
+			func(r *rand.Rand, size int) GraphInput {
+				return randGraph(r, size, size*2, r.Intn(2) == 0, 0, 20)
+			},
+			func(in GraphInput) []Dist {
+				paths, _ := BellmanFord(in.Graph(), 0)
+				return paths.Dist
+			},
+			func(in GraphInput) []Dist {
+				paths, _ := Dijkstra(in.Graph(), 0)
+				return paths.Dist
+			},
+			nil, shrinkGraph),
+		DefinePair("minimumSpanningTree", "kruskal vs prim，比较总权重和连通分量数",
+			func(r *rand.Rand, size int) GraphInput {
+				return randGraph(r, size, size*2, false, -20, 20)
+			},
+			func(in GraphInput) [2]int {
+				mst := Kruskal(in.Graph())
+				return [2]int{mst.Weight, mst.Components}
+			},
+			func(in GraphInput) [2]int {
+				mst := Prim(in.Graph())
+				return [2]int{mst.Weight, mst.Components}
+			},
+			nil, shrinkGraph),
+		DefinePair("findCycle", "有向图：强连通分量判环 vs DFS 找环（并校验环上的边）",
+			func(r *rand.Rand, size int) GraphInput {
+				return randGraph(r, size, size, true, 1, 1)
+			},
+			func(in GraphInput) bool {
+				g := in.Graph()
+				for _, component := range StronglyConnectedComponents(g) {
+					if len(component) > 1 {
+						return true
+					}
+				}
+				return slices.ContainsFunc(g.Edges, func(e Edge) bool { return e.From == e.To })
+			},
+			func(in GraphInput) bool {
+				g := in.Graph()
+				cycle := FindCycle(g)
+				return cycle != nil && validCycle(g, cycle)
+			},
+			nil, shrinkGraph),
+	)
+
+	Default.MustRegisterBench(
+		DefineBench("dijkstra", "随机有向图，E = 4V", geometricSizes(1000, 128000), 1000000,
+			func(r *rand.Rand, size int) *Graph {
+				in := randGraph(r, size, size*4, true, 1, 100)
+				return in.Graph()
+			},
+			func(g *Graph) { Dijkstra(g, 0) }),
+		DefineBench("kruskal", "随机无向图，E = 4V", geometricSizes(1000, 128000), 1000000,
+			func(r *rand.Rand, size int) *Graph {
+				in := randGraph(r, size, size*4, false, 1, 100)
+				return in.Graph()
+			},
+			func(g *Graph) { Kruskal(g) }),
+	)
+}
@@ -0,0 +1,267 @@
+package algorithm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// JobQueued 等待空闲的 worker
+	JobQueued = "queued"
+	// JobRunning 正在执行
+	JobRunning = "running"
+	// JobSucceeded 执行成功
+	JobSucceeded = "succeeded"
+	// JobFailed 执行失败，包括超出预算
+	JobFailed = "failed"
+)
+
+// ErrQueueFull 等待中的任务已满，调用方可以据此返回 503
+var ErrQueueFull = errors.New("job queue is full")
+
+// ErrQueueClosed 任务池已经关闭，不再接受任务，关闭时还在等待的任务以此失败
+var ErrQueueClosed = errors.New("job queue is closed")
+
+// ErrJobNotFound 任务不存在或已经被清理
+var ErrJobNotFound = errors.New("job not found")
+
+// JobFunc 任务要执行的函数，ctx 中带有任务的预算（见 MeterFrom）
+// 失败时返回的 result 仍会保存，例如超出预算时的消耗
+type JobFunc func(ctx context.Context) (result interface{}, err error)
+
+// Job 一个异步任务，状态通过 Status 读取
+type Job struct {
+	id        string
+	algorithm string
+	budget    Budget
+	run       JobFunc
+	done      chan struct{}
+
+	mu         sync.Mutex
+	state      string
+	createdAt  time.Time
+	startedAt  time.Time
+	finishedAt time.Time
+	meter      *Meter
+	usage      *BudgetUsage
+	result     interface{}
+	err        error
+}
+
+// JobStatus 任务状态快照，progress 是执行中（或结束时）的预算消耗
+type JobStatus struct {
+	ID         string       `json:"id"`
+	Algorithm  string       `json:"algorithm"`
+	State      string       `json:"state"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	StartedAt  *time.Time   `json:"startedAt,omitempty"`
+	FinishedAt *time.Time   `json:"finishedAt,omitempty"`
+	Progress   *BudgetUsage `json:"progress,omitempty"`
+	Result     interface{}  `json:"result,omitempty"`
+	Error      string       `json:"error,omitempty"`
+}
+
+// ID 任务 id
+func (j *Job) ID() string {
+	return j.id
+}
+
+// Done 任务结束时关闭
+func (j *Job) Done() <-chan struct{} {
+	return j.done
+}
+
+// Err 任务的错误，没有结束或成功时为 nil
+func (j *Job) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.err
+}
+
+// Status 当前状态的快照
+func (j *Job) Status() JobStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	status := JobStatus{
+		ID:        j.id,
+		Algorithm: j.algorithm,
+		State:     j.state,
+		CreatedAt: j.createdAt,
+		Progress:  j.usage,
+		Result:    j.result,
+	}
+	if j.state == JobRunning {
+		status.Progress = j.meter.Usage()
+	}
+	if !j.startedAt.IsZero() {
+		status.StartedAt = &j.startedAt
+	}
+	if !j.finishedAt.IsZero() {
+		status.FinishedAt = &j.finishedAt
+	}
+	if j.err != nil {
+		status.Error = j.err.Error()
+	}
+	return status
+}
+
+// execute 在 worker 中执行任务，ctx 取消（任务池关闭）时任务随之取消，算法 panic 时任务失败而不是让 worker 退出
+func (j *Job) execute(ctx context.Context) {
+	ctx, meter, cancel := WithBudget(ctx, j.budget)
+	defer cancel()
+	j.mu.Lock()
+	j.state, j.startedAt, j.meter = JobRunning, time.Now(), meter
+	j.mu.Unlock()
+
+	result, err := func() (result interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("job %s panicked: %v", j.id, r)
+			}
+		}()
+		return j.run(ctx)
+	}()
+
+	j.finish(meter.Usage(), result, err)
+}
+
+// finish 记录结果并结束任务
+func (j *Job) finish(usage *BudgetUsage, result interface{}, err error) {
+	j.mu.Lock()
+	j.state, j.finishedAt, j.usage, j.result, j.err = JobSucceeded, time.Now(), usage, result, err
+	if err != nil {
+		j.state = JobFailed
+	}
+	j.mu.Unlock()
+	close(j.done)
+}
+
+// JobQueue 固定数量 worker 的任务池，等待中的任务数有上限，结束的任务保留最近 retain 个
+// 不再使用时需要调用 Close 停止 worker
+type JobQueue struct {
+	pending chan *Job
+	retain  int
+	ctx     context.Context
+	cancel  context.CancelFunc
+	workers sync.WaitGroup
+
+	mu       sync.Mutex
+	closed   bool
+	jobs     map[string]*Job
+	finished []string
+}
+
+// NewJobQueue 启动 workers 个 worker，最多 capacity 个任务等待执行
+func NewJobQueue(workers, capacity, retain int) *JobQueue {
+	q := &JobQueue{
+		pending: make(chan *Job, capacity),
+		retain:  retain,
+		jobs:    make(map[string]*Job),
+	}
+	q.ctx, q.cancel = context.WithCancel(context.Background())
+	q.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go q.work()
+	}
+	return q
+}
+
+func (q *JobQueue) work() {
+	defer q.workers.Done()
+	for {
+		select {
+		case <-q.ctx.Done():
+			return
+		case job := <-q.pending:
+			// 关闭后 select 仍可能选中等待中的任务
+			if q.ctx.Err() != nil {
+				job.finish(nil, nil, ErrQueueClosed)
+			} else {
+				job.execute(q.ctx)
+			}
+			q.retire(job)
+		}
+	}
+}
+
+// retire 记录结束的任务，超过 retain 个时清理最早结束的
+func (q *JobQueue) retire(job *Job) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.finished = append(q.finished, job.id)
+	for len(q.finished) > q.retain {
+		delete(q.jobs, q.finished[0])
+		q.finished = q.finished[1:]
+	}
+}
+
+// Close 停止接受任务，取消正在执行的任务并等待 worker 退出，还在等待的任务以 ErrQueueClosed 失败
+// 重复调用没有影响
+func (q *JobQueue) Close() {
+	q.mu.Lock()
+	closed := q.closed
+	q.closed = true
+	q.mu.Unlock()
+	if closed {
+		return
+	}
+	q.cancel()
+	q.workers.Wait()
+	for {
+		select {
+		case job := <-q.pending:
+			job.finish(nil, nil, ErrQueueClosed)
+			q.retire(job)
+		default:
+			return
+		}
+	}
+}
+
+// newJobID 随机 id，不能被猜到，避免读取别人的结果
+func newJobID() string {
+	var b [12]byte
+	_, _ = rand.Read(b[:])
+	return "job_" + hex.EncodeToString(b[:])
+}
+
+// Submit 提交任务，budget 在任务开始执行时生效，队列已满时返回 ErrQueueFull，已经关闭时返回 ErrQueueClosed
+func (q *JobQueue) Submit(algorithm string, budget Budget, run JobFunc) (*Job, error) {
+	job := &Job{
+		id:        newJobID(),
+		algorithm: algorithm,
+		budget:    budget,
+		run:       run,
+		done:      make(chan struct{}),
+		state:     JobQueued,
+		createdAt: time.Now(),
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return nil, ErrQueueClosed
+	}
+	select {
+	case q.pending <- job:
+	default:
+		return nil, fmt.Errorf("%w: %d jobs waiting", ErrQueueFull, cap(q.pending))
+	}
+	q.jobs[job.id] = job
+	return job, nil
+}
+
+// Get 按 id 查找任务，不存在或已经被清理时返回 ErrJobNotFound
+func (q *JobQueue) Get(id string) (*Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job, exist := q.jobs[id]
+	if !exist {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+	return job, nil
+}
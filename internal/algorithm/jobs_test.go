@@ -0,0 +1,139 @@
+package algorithm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// blockingJob 开始执行时通知 started，然后等到 release 关闭或 ctx 取消
+func blockingJob(started chan<- struct{}, release <-chan struct{}) JobFunc {
+	return func(ctx context.Context) (interface{}, error) {
+		close(started)
+		select {
+		case <-release:
+			return "released", nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// waitJob 等待任务结束，超时时测试失败
+func waitJob(t *testing.T, job *Job) JobStatus {
+	t.Helper()
+	select {
+	case <-job.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("job %s did not finish", job.ID())
+	}
+	return job.Status()
+}
+
+// TestJobQueueFull worker 和等待队列都占满后提交返回 ErrQueueFull，空出位置后可以继续提交
+func TestJobQueueFull(t *testing.T) {
+	q := NewJobQueue(1, 1, 10)
+	defer q.Close()
+	started, release := make(chan struct{}), make(chan struct{})
+	running, err := q.Submit("block", DefaultBudget, blockingJob(started, release))
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	queued, err := q.Submit("queued", DefaultBudget, func(ctx context.Context) (interface{}, error) { return 1, nil })
+	if err != nil {
+		t.Fatalf("second job should wait in the queue: %v", err)
+	}
+	if status := queued.Status(); status.State != JobQueued {
+		t.Errorf("second job state = %s, want %s", status.State, JobQueued)
+	}
+	if _, err := q.Submit("rejected", DefaultBudget, func(ctx context.Context) (interface{}, error) { return nil, nil }); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("third job: err = %v, want ErrQueueFull", err)
+	}
+
+	close(release)
+	if status := waitJob(t, running); status.State != JobSucceeded || status.Result != "released" || status.Progress == nil {
+		t.Errorf("running job = %+v", status)
+	}
+	if status := waitJob(t, queued); status.State != JobSucceeded || status.Result != 1 {
+		t.Errorf("queued job = %+v", status)
+	}
+}
+
+// TestJobQueueRetain 只保留最近 retain 个结束的任务，更早的返回 ErrJobNotFound
+func TestJobQueueRetain(t *testing.T) {
+	q := NewJobQueue(1, 10, 2)
+	var jobs []*Job
+	for i := 0; i < 4; i++ {
+		job, err := q.Submit("n", DefaultBudget, func(ctx context.Context) (interface{}, error) { return i, nil })
+		if err != nil {
+			t.Fatal(err)
+		}
+		jobs = append(jobs, job)
+	}
+	for _, job := range jobs {
+		waitJob(t, job)
+	}
+	// Close 等待 worker 把结束的任务记录完
+	q.Close()
+	for i, job := range jobs {
+		_, err := q.Get(job.ID())
+		if evicted := i < 2; evicted != errors.Is(err, ErrJobNotFound) {
+			t.Errorf("job %d: Get err = %v, evicted %v", i, err, evicted)
+		}
+	}
+	if _, err := q.Get("job_unknown"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("unknown job: err = %v", err)
+	}
+}
+
+// TestJobQueueClose 关闭时取消正在执行的任务，等待中的任务以 ErrQueueClosed 失败，之后不再接受任务
+func TestJobQueueClose(t *testing.T) {
+	q := NewJobQueue(1, 2, 10)
+	started := make(chan struct{})
+	running, err := q.Submit("block", DefaultBudget, blockingJob(started, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	queued, err := q.Submit("queued", DefaultBudget, func(ctx context.Context) (interface{}, error) { return 1, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q.Close()
+	if status := waitJob(t, running); status.State != JobFailed || !errors.Is(running.Err(), context.Canceled) {
+		t.Errorf("running job = %+v, want canceled", status)
+	}
+	if status := waitJob(t, queued); status.State != JobFailed || !errors.Is(queued.Err(), ErrQueueClosed) {
+		t.Errorf("queued job = %+v, want ErrQueueClosed", status)
+	}
+	if _, err := q.Submit("late", DefaultBudget, func(ctx context.Context) (interface{}, error) { return nil, nil }); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("submit after Close: err = %v, want ErrQueueClosed", err)
+	}
+	q.Close()
+}
+
+// TestJobBudget 任务在自己的预算内执行，超时后 ctx 被取消；panic 只让任务失败
+func TestJobBudget(t *testing.T) {
+	q := NewJobQueue(1, 2, 10)
+	defer q.Close()
+	timeout, err := q.Submit("timeout", Budget{Timeout: 10 * time.Millisecond}, func(ctx context.Context) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := waitJob(t, timeout); status.State != JobFailed || !errors.Is(timeout.Err(), context.DeadlineExceeded) {
+		t.Errorf("timeout job = %+v", status)
+	}
+	panicked, err := q.Submit("panic", DefaultBudget, func(ctx context.Context) (interface{}, error) { panic("boom") })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := waitJob(t, panicked); status.State != JobFailed || status.Error == "" {
+		t.Errorf("panicking job = %+v", status)
+	}
+}
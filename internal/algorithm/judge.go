@@ -0,0 +1,474 @@
+package algorithm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+const (
+	// VerdictAccepted 答案正确
+	VerdictAccepted = "accepted"
+	// VerdictWrongAnswer 答案与参考解不一致，或不满足题目的校验器
+	VerdictWrongAnswer = "wrong_answer"
+	// VerdictInvalidAnswer 答案不能解析为题目的输出格式
+	VerdictInvalidAnswer = "invalid_answer"
+
+	// MaxJudgeDiffs 评测结果中最多列出的差异数
+	MaxJudgeDiffs = 50
+)
+
+// Difference 期望输出与答案在某个 JSON 路径上的差异，缺少的一侧为空
+type Difference struct {
+	Path     string          `json:"path"`
+	Expected json.RawMessage `json:"expected,omitempty"`
+	Got      json.RawMessage `json:"got,omitempty"`
+}
+
+// Verdict 一次评测的结果，expected 是参考解的输出
+type Verdict struct {
+	Problem  string          `json:"problem"`
+	Status   string          `json:"status"`
+	Message  string          `json:"message,omitempty"`
+	Expected json.RawMessage `json:"expected,omitempty"`
+	Diff     []Difference    `json:"diff,omitempty"`
+}
+
+// Accepted 答案是否通过
+func (v *Verdict) Accepted() bool {
+	return v.Status == VerdictAccepted
+}
+
+// Checker 有多个合法答案的题目的校验器，返回 nil 表示答案可以接受
+// 返回包装 ErrInvalidInput 的错误表示这个输入无法评测（例如参考解被截断）
+type Checker struct {
+	Name  string
+	check func(input, expected, got interface{}) error
+}
+
+// DefineChecker 用类型化的输入和输出创建校验器，In、Out 必须与同名算法 Define 时的类型一致
+func DefineChecker[In any, Out any](name string, check func(in *In, expected, got Out) error) *Checker {
+	return &Checker{
+		Name: name,
+		check: func(input, expected, got interface{}) error {
+			in, ok1 := input.(*In)
+			want, ok2 := expected.(Out)
+			answer, ok3 := got.(*Out)
+			if !ok1 || !ok2 || !ok3 {
+				return fmt.Errorf("checker %s: types do not match the algorithm", name)
+			}
+			return check(in, want, *answer)
+		},
+	}
+}
+
+// RegisterChecker 注册校验器，名称是对应的算法名
+func (r *Registry) RegisterChecker(checker *Checker) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.checkers == nil {
+		r.checkers = make(map[string]*Checker)
+	}
+	if _, exist := r.checkers[checker.Name]; exist {
+		return fmt.Errorf("algorithm: checker %s already registered", checker.Name)
+	}
+	r.checkers[checker.Name] = checker
+	return nil
+}
+
+// MustRegisterChecker 注册校验器，失败时 panic，用于 init 阶段
+func (r *Registry) MustRegisterChecker(checkers ...*Checker) {
+	for _, checker := range checkers {
+		if err := r.RegisterChecker(checker); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Checkers 有专用校验器的算法名，按名称排序
+func (r *Registry) Checkers() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.checkers))
+	for name := range r.checkers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+/*
+Judge 用参考解评测答案
+input 按算法的输入格式解析，answer 按输出格式解析（不允许多余字段）；
+有校验器的题目由校验器判定，其他题目要求与参考解的 JSON 完全一致
+输入不合法、参考解出错或超出预算时返回错误，答案的问题都体现在 Verdict 中
+*/
+func (r *Registry) Judge(ctx context.Context, problem string, input, answer []byte) (*Verdict, error) {
+	spec, err := r.Get(problem)
+	if err != nil {
+		return nil, err
+	}
+	in, err := spec.Decode(input)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := spec.Run(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	expectedJSON, err := json.Marshal(expected)
+	if err != nil {
+		return nil, err
+	}
+	verdict := &Verdict{Problem: problem, Expected: expectedJSON}
+
+	got := spec.newOutput()
+	decoder := json.NewDecoder(bytes.NewReader(answer))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(got); err != nil {
+		verdict.Status = VerdictInvalidAnswer
+		verdict.Message = fmt.Sprintf("answer does not match the output format: %v", err)
+		return verdict, nil
+	}
+	gotJSON, err := json.Marshal(got)
+	if err != nil {
+		return nil, err
+	}
+	verdict.Diff = JSONDiff(expectedJSON, gotJSON)
+
+	r.mu.RLock()
+	checker := r.checkers[problem]
+	r.mu.RUnlock()
+	switch {
+	case checker != nil:
+		if err := checker.check(in, expected, got); err != nil {
+			if errors.Is(err, ErrInvalidInput) {
+				return nil, err
+			}
+			verdict.Status, verdict.Message = VerdictWrongAnswer, err.Error()
+			return verdict, nil
+		}
+		// 校验器接受的答案可以与参考解不同，差异没有意义
+		verdict.Diff = nil
+	case len(verdict.Diff) > 0:
+		verdict.Status, verdict.Message = VerdictWrongAnswer, "output differs from the expected output"
+		return verdict, nil
+	}
+	verdict.Status = VerdictAccepted
+	return verdict, nil
+}
+
+// JSONDiff 逐个路径比较两个 JSON 值，路径形如 $.order[2]，最多返回 MaxJudgeDiffs 个差异
+// 数字按字面比较，不能解析的一侧整体视为不同
+func JSONDiff(expected, got []byte) []Difference {
+	var a, b interface{}
+	errA, errB := decodeNumber(expected, &a), decodeNumber(got, &b)
+	if errA != nil || errB != nil {
+		if bytes.Equal(expected, got) {
+			return nil
+		}
+		return []Difference{{Path: "$", Expected: expected, Got: got}}
+	}
+	var diffs []Difference
+	diffJSON("$", a, b, &diffs)
+	return diffs
+}
+
+func decodeNumber(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func diffJSON(path string, a, b interface{}, diffs *[]Difference) {
+	if len(*diffs) >= MaxJudgeDiffs {
+		return
+	}
+	switch x := a.(type) {
+	case map[string]interface{}:
+		if y, ok := b.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(x)+len(y))
+			for key := range x {
+				keys = append(keys, key)
+			}
+			for key := range y {
+				if _, dup := x[key]; !dup {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				diffMissing(path+"."+key, x, y, key, diffs)
+			}
+			return
+		}
+	case []interface{}:
+		if y, ok := b.([]interface{}); ok {
+			for i := 0; i < max(len(x), len(y)); i++ {
+				child := path + "[" + strconv.Itoa(i) + "]"
+				switch {
+				case i >= len(y):
+					*diffs = append(*diffs, Difference{Path: child, Expected: rawJSON(x[i])})
+				case i >= len(x):
+					*diffs = append(*diffs, Difference{Path: child, Got: rawJSON(y[i])})
+				default:
+					diffJSON(child, x[i], y[i], diffs)
+				}
+				if len(*diffs) >= MaxJudgeDiffs {
+					return
+				}
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(a, b) {
+		*diffs = append(*diffs, Difference{Path: path, Expected: rawJSON(a), Got: rawJSON(b)})
+	}
+}
+
+func diffMissing(path string, x, y map[string]interface{}, key string, diffs *[]Difference) {
+	a, inA := x[key]
+	b, inB := y[key]
+	switch {
+	case !inB:
+		*diffs = append(*diffs, Difference{Path: path, Expected: rawJSON(a)})
+	case !inA:
+		*diffs = append(*diffs, Difference{Path: path, Got: rawJSON(b)})
+	default:
+		diffJSON(path, a, b, diffs)
+	}
+}
+
+func rawJSON(v interface{}) json.RawMessage {
+	data, _ := json.Marshal(v)
+	return data
+}
+
+// jsonKey 用 JSON 编码作为比较集合元素的键
+func jsonKey(v interface{}) string {
+	return string(rawJSON(v))
+}
+
+// checkTwoSum 任意一对和为 target 的下标都可以接受
+func checkTwoSum(in *TwoSumInput, expected, got TwoSumResult[int]) error {
+	if got.Found != expected.Found {
+		return fmt.Errorf("found = %v, want %v", got.Found, expected.Found)
+	}
+	if !got.Found {
+		if len(got.Indices) > 0 || len(got.Values) > 0 {
+			return errors.New("indices and values must be empty when nothing is found")
+		}
+		return nil
+	}
+	if len(got.Indices) != 2 {
+		return fmt.Errorf("want 2 indices, got %d", len(got.Indices))
+	}
+	i, j := got.Indices[0], got.Indices[1]
+	if i == j || i < 0 || j < 0 || i >= len(in.Nums) || j >= len(in.Nums) {
+		return fmt.Errorf("indices %v must be two different positions in nums", got.Indices)
+	}
+	if in.Nums[i]+in.Nums[j] != *in.Target {
+		return fmt.Errorf("nums[%d] + nums[%d] = %d, want %d", i, j, in.Nums[i]+in.Nums[j], *in.Target)
+	}
+	if !slices.Equal(got.Values, []int{in.Nums[i], in.Nums[j]}) {
+		return fmt.Errorf("values %v do not match nums at indices %v", got.Values, got.Indices)
+	}
+	return nil
+}
+
+/*
+checkCoursePlan 学习顺序、环和学期计划都可以有多种合法答案
+order 必须是可完成课程的一个拓扑序，blocked 与参考解相同，
+每个环都是先修图中的环，学期计划合法且学期数不多于参考解
+*/
+func checkCoursePlan(in *CourseInput, expected, got CoursePlanResult) error {
+	if got.NumCourses != expected.NumCourses || got.CanFinish != expected.CanFinish {
+		return fmt.Errorf("coursesNum/canFinish = %d/%v, want %d/%v", got.NumCourses, got.CanFinish, expected.NumCourses, expected.CanFinish)
+	}
+	if !slices.Equal(sortedCopy(got.Blocked), expected.Blocked) {
+		return fmt.Errorf("blocked = %v, want %v in any order", got.Blocked, expected.Blocked)
+	}
+	if !slices.Equal(sortedCopy(got.Order), sortedCopy(expected.Order)) {
+		return errors.New("order must contain exactly the courses that can be finished")
+	}
+	position := make(map[int]int, len(got.Order))
+	for i, course := range got.Order {
+		position[course] = i
+	}
+	for _, pair := range in.Prerequisites {
+		course, ok := position[pair[0]]
+		if ok && position[pair[1]] >= course {
+			return fmt.Errorf("order takes course %d before its prerequisite %d", pair[0], pair[1])
+		}
+	}
+	if len(got.Cycles) != len(expected.Cycles) {
+		return fmt.Errorf("want %d cycles, got %d", len(expected.Cycles), len(got.Cycles))
+	}
+	g := courseGraph(in.NumCourses, in.Pairs())
+	for _, cycle := range got.Cycles {
+		if !validCycle(g, cycle) {
+			return fmt.Errorf("%v is not a cycle of prerequisites", cycle)
+		}
+	}
+	planned := 0
+	for _, semester := range expected.Semesters {
+		planned += len(semester)
+	}
+	if validSemesters(*in, got.Semesters) != planned {
+		return errors.New("semesters must schedule every finishable course after its prerequisites")
+	}
+	if len(got.Semesters) > len(expected.Semesters) {
+		return fmt.Errorf("plan takes %d semesters, the reference needs only %d", len(got.Semesters), len(expected.Semesters))
+	}
+	return nil
+}
+
+// checkSearchStats 回溯结果中 count 必须等于解的个数；参考解超时的输入无法评测
+func checkSearchStats[R any](expected, got SearchResult[R]) error {
+	if expected.TimedOut {
+		return fmt.Errorf("%w: reference search timed out, raise timeoutMs to judge this input", ErrInvalidInput)
+	}
+	if got.Count != len(got.Solutions) {
+		return fmt.Errorf("count = %d but %d solutions given", got.Count, len(got.Solutions))
+	}
+	return nil
+}
+
+// sameSolutions 解集与参考解相同，顺序任意；key 给出解的规范形式，例如组合内部排序
+// 参考解被 maxSolutions 截断时无法知道完整解集，无法评测
+func sameSolutions[In any, R any](key func(R) string) func(in *In, expected, got SearchResult[R]) error {
+	return func(in *In, expected, got SearchResult[R]) error {
+		if err := checkSearchStats(expected, got); err != nil {
+			return err
+		}
+		if expected.Truncated {
+			return fmt.Errorf("%w: reference search was truncated, raise maxSolutions to judge this input", ErrInvalidInput)
+		}
+		keys := func(solutions []R) []string {
+			result := make([]string, len(solutions))
+			for i, solution := range solutions {
+				result[i] = key(solution)
+			}
+			slices.Sort(result)
+			return result
+		}
+		want, have := keys(expected.Solutions), keys(got.Solutions)
+		for i := 0; i < max(len(want), len(have)); i++ {
+			switch {
+			case i >= len(have) || i < len(want) && want[i] < have[i]:
+				return fmt.Errorf("missing solution %s", want[i])
+			case i >= len(want) || want[i] != have[i]:
+				return fmt.Errorf("unexpected or duplicate solution %s", have[i])
+			}
+		}
+		return nil
+	}
+}
+
+// validSolutions 每个解都合法且互不相同，个数与参考解相同；用于解很多、只要求找出若干个的题目
+func validSolutions[In any, R any](valid func(in *In, solution R) error) func(in *In, expected, got SearchResult[R]) error {
+	return func(in *In, expected, got SearchResult[R]) error {
+		if err := checkSearchStats(expected, got); err != nil {
+			return err
+		}
+		if got.Count != expected.Count {
+			return fmt.Errorf("want %d solutions, got %d", expected.Count, got.Count)
+		}
+		seen := make(map[string]bool, len(got.Solutions))
+		for i, solution := range got.Solutions {
+			key := jsonKey(solution)
+			if seen[key] {
+				return fmt.Errorf("solutions[%d] is a duplicate", i)
+			}
+			seen[key] = true
+			if err := valid(in, solution); err != nil {
+				return fmt.Errorf("solutions[%d]: %v", i, err)
+			}
+		}
+		return nil
+	}
+}
+
+// sortedKey 组合类的解不计内部顺序
+func sortedKey(solution []int) string {
+	return jsonKey(sortedCopy(solution))
+}
+
+func sortedCopy(nums []int) []int {
+	sorted := slices.Clone(nums)
+	slices.Sort(sorted)
+	return sorted
+}
+
+// validSudoku 解填满了所有格子、保留了题目给出的数字且没有冲突
+func validSudoku(in *SudokuInput, solution SudokuBoard) error {
+	if err := solution.Validate(); err != nil {
+		return err
+	}
+	for r := range solution {
+		for c, cell := range solution[r] {
+			if cell == "." {
+				return fmt.Errorf("cell [%d][%d] is empty", r, c)
+			}
+			if given := in.Board[r][c]; given != "." && given != cell {
+				return fmt.Errorf("cell [%d][%d] = %s, the puzzle gives %s", r, c, cell, given)
+			}
+		}
+	}
+	return nil
+}
+
+// validWordPath 路径上的格子互不相同、依次相邻，且拼出 word
+func validWordPath(in *WordSearchInput, path [][2]int) error {
+	letters := []rune(in.Word)
+	if len(path) != len(letters) {
+		return fmt.Errorf("path has %d cells, word has %d letters", len(path), len(letters))
+	}
+	visited := make(map[[2]int]bool, len(path))
+	for i, cell := range path {
+		r, c := cell[0], cell[1]
+		if r < 0 || r >= len(in.Board) || c < 0 || c >= len(in.Board[r]) {
+			return fmt.Errorf("cell %v is outside the board", cell)
+		}
+		if visited[cell] {
+			return fmt.Errorf("cell %v is used twice", cell)
+		}
+		visited[cell] = true
+		if []rune(in.Board[r][c])[0] != letters[i] {
+			return fmt.Errorf("cell %v is %s, want %c", cell, in.Board[r][c], letters[i])
+		}
+		if i > 0 && abs(r-path[i-1][0])+abs(c-path[i-1][1]) != 1 {
+			return fmt.Errorf("cell %v is not adjacent to %v", cell, path[i-1])
+		}
+	}
+	return nil
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func init() {
+	Default.MustRegisterChecker(
+		DefineChecker("sumUpToTarget", checkTwoSum),
+		DefineChecker("sumUpToTargetHashMap", checkTwoSum),
+		DefineChecker("courseTopology", checkCoursePlan),
+		DefineChecker("backTrack", sameSolutions[CombinationSumInput](sortedKey)),
+		DefineChecker("combinationSum2", sameSolutions[CombinationSumInput](sortedKey)),
+		DefineChecker("combinationSum3", sameSolutions[CombinationSum3Input](sortedKey)),
+		DefineChecker("subsets", sameSolutions[SubsetsInput](sortedKey)),
+		DefineChecker("permuteUnique", sameSolutions[PermutationInput](func(p []int) string { return jsonKey(p) })),
+		DefineChecker("nQueens", sameSolutions[NQueensInput](func(board []string) string { return jsonKey(board) })),
+		DefineChecker("solveSudoku", validSolutions(validSudoku)),
+		DefineChecker("wordSearch", validSolutions(validWordPath)),
+	)
+}
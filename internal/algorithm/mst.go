@@ -0,0 +1,160 @@
+package algorithm
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// UnionFind 并查集，路径压缩 + 按秩合并，均摊 O(α(n))
+type UnionFind struct {
+	parent []int
+	rank   []int
+	count  int
+}
+
+// NewUnionFind 创建 n 个独立集合
+func NewUnionFind(n int) *UnionFind {
+	u := &UnionFind{parent: make([]int, n), rank: make([]int, n), count: n}
+	for i := range u.parent {
+		u.parent[i] = i
+	}
+	return u
+}
+
+// Find 返回 x 所在集合的根，同时把路径上的节点直接挂到根下
+func (u *UnionFind) Find(x int) int {
+	root := x
+	for u.parent[root] != root {
+		root = u.parent[root]
+	}
+	for u.parent[x] != root {
+		u.parent[x], x = root, u.parent[x]
+	}
+	return root
+}
+
+// Union 合并 x 和 y 所在集合，已经在同一集合时返回 false
+func (u *UnionFind) Union(x, y int) bool {
+	rx, ry := u.Find(x), u.Find(y)
+	if rx == ry {
+		return false
+	}
+	if u.rank[rx] < u.rank[ry] {
+		rx, ry = ry, rx
+	}
+	u.parent[ry] = rx
+	if u.rank[rx] == u.rank[ry] {
+		u.rank[rx]++
+	}
+	u.count--
+	return true
+}
+
+// Connected x 和 y 是否在同一集合
+func (u *UnionFind) Connected(x, y int) bool {
+	return u.Find(x) == u.Find(y)
+}
+
+// Count 集合个数
+func (u *UnionFind) Count() int {
+	return u.count
+}
+
+// Groups 按最小元素排序返回所有集合，集合内升序
+func (u *UnionFind) Groups() [][]int {
+	index := make(map[int]int)
+	var groups [][]int
+	for x := range u.parent {
+		root := u.Find(x)
+		i, ok := index[root]
+		if !ok {
+			i = len(groups)
+			index[root] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], x)
+	}
+	return groups
+}
+
+// MST 最小生成树（图不连通时是最小生成森林）
+type MST struct {
+	Weight     int    `json:"weight"`
+	Edges      []Edge `json:"edges"`
+	Components int    `json:"components"`
+}
+
+/*
+Kruskal 最小生成树
+边按权重升序，用并查集跳过会成环的边
+O(E log E)
+*/
+func Kruskal(g *Graph) MST {
+	edges := slices.Clone(g.Edges)
+	slices.SortStableFunc(edges, func(a, b Edge) int { return a.Weight - b.Weight })
+	uf := NewUnionFind(g.N)
+	result := MST{Edges: make([]Edge, 0, g.N-1)}
+	for _, e := range edges {
+		if uf.Union(e.From, e.To) {
+			result.Weight += e.Weight
+			result.Edges = append(result.Edges, e)
+		}
+	}
+	result.Components = uf.Count()
+	return result
+}
+
+type arcItem struct {
+	from int
+	Arc
+}
+
+// arcHeap 按边权排序的最小堆
+type arcHeap []arcItem
+
+func (h arcHeap) Len() int            { return len(h) }
+func (h arcHeap) Less(i, j int) bool  { return h[i].Weight < h[j].Weight }
+func (h arcHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *arcHeap) Push(x interface{}) { *h = append(*h, x.(arcItem)) }
+func (h *arcHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+/*
+Prim 最小生成树
+从一个未访问节点出发，每次取连接树内外权重最小的边；图不连通时对每个连通分量重新出发
+O(E log E)
+*/
+func Prim(g *Graph) MST {
+	visited := make([]bool, g.N)
+	result := MST{Edges: make([]Edge, 0, g.N-1)}
+	for start := range visited {
+		if visited[start] {
+			continue
+		}
+		result.Components++
+		visited[start] = true
+		h := &arcHeap{}
+		for _, arc := range g.Adj[start] {
+			heap.Push(h, arcItem{from: start, Arc: arc})
+		}
+		for h.Len() > 0 {
+			item := heap.Pop(h).(arcItem)
+			if visited[item.To] {
+				continue
+			}
+			visited[item.To] = true
+			result.Weight += item.Weight
+			result.Edges = append(result.Edges, Edge{From: item.from, To: item.To, Weight: item.Weight})
+			for _, arc := range g.Adj[item.To] {
+				if !visited[arc.To] {
+					heap.Push(h, arcItem{from: item.To, Arc: arc})
+				}
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,184 @@
+package algorithm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Category 题目分类
+type Category string
+
+const (
+	CategoryDP              Category = "dp"
+	CategoryTwoPointer      Category = "two-pointer"
+	CategorySlidingWindow   Category = "sliding-window"
+	CategoryBinarySearch    Category = "binary-search"
+	CategoryHashTable       Category = "hash-table"
+	CategoryBitManipulation Category = "bit-manipulation"
+	CategoryGraph           Category = "graph"
+	CategoryBacktracking    Category = "backtracking"
+	CategoryInterval        Category = "interval"
+	CategoryString          Category = "string"
+	CategoryTree            Category = "tree"
+	CategoryTrie            Category = "trie"
+	CategoryHeap            Category = "heap"
+	CategoryMonotonicStack  Category = "monotonic-stack"
+	CategoryDesign          Category = "design"
+)
+
+// ErrInvalidInput 输入不合法，调用方可以据此返回 400
+var ErrInvalidInput = errors.New("invalid input")
+
+// ErrNotFound 算法未注册
+var ErrNotFound = errors.New("algorithm not found")
+
+// Validator 需要额外校验（binding 标签以外）的输入
+type Validator interface {
+	Validate() error
+}
+
+// validate 与 gin 一致使用 binding 标签，同一个输入结构在 HTTP 和命令行下校验规则相同
+var validate = func() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("binding")
+	return v
+}()
+
+// Spec 一个已注册算法的元数据和执行入口，Traceable 表示执行时会向 context 中的 Trace 记录过程
+type Spec struct {
+	Name      string          `json:"name"`
+	Title     string          `json:"title"`
+	LeetCode  int             `json:"leetcode,omitempty"`
+	Category  Category        `json:"category"`
+	Time      string          `json:"time"`
+	Space     string          `json:"space"`
+	Traceable bool            `json:"traceable"`
+	Schema    *Schema         `json:"schema"`
+	Example   json.RawMessage `json:"example"`
+
+	newInput  func() interface{}
+	newOutput func() interface{}
+	run       func(ctx context.Context, input interface{}) (interface{}, error)
+}
+
+// Define 用类型化的输入和执行函数创建 Spec，输入的 JSON schema 由 In 的结构标签生成
+func Define[In any, Out any](spec Spec, example In, run func(ctx context.Context, in *In) (Out, error)) *Spec {
+	data, err := json.Marshal(example)
+	if err != nil {
+		panic(fmt.Sprintf("algorithm %s: marshal example: %v", spec.Name, err))
+	}
+	spec.Example = data
+	spec.Schema = SchemaOf(reflect.TypeOf(example))
+	spec.newInput = func() interface{} { return new(In) }
+	spec.newOutput = func() interface{} { return new(Out) }
+	spec.run = func(ctx context.Context, input interface{}) (interface{}, error) {
+		return run(ctx, input.(*In))
+	}
+	return &spec
+}
+
+// Decode 解析并校验 JSON 输入
+func (s *Spec) Decode(data []byte) (interface{}, error) {
+	input := s.newInput()
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: empty body", ErrInvalidInput)
+	}
+	if err := json.Unmarshal(data, input); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := validate.Struct(input); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if v, ok := input.(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+	}
+	return input, nil
+}
+
+// Run 执行已解析的输入，context 中有 Meter 时超出预算返回 BudgetExceededError
+func (s *Spec) Run(ctx context.Context, input interface{}) (result interface{}, err error) {
+	defer recoverBudget(&err)
+	return s.run(ctx, input)
+}
+
+// Execute 解析 JSON 输入并执行
+func (s *Spec) Execute(ctx context.Context, data []byte) (interface{}, error) {
+	input, err := s.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return s.Run(ctx, input)
+}
+
+// Registry 算法注册表
+type Registry struct {
+	mu         sync.RWMutex
+	specs      map[string]*Spec
+	pairs      map[string]*Pair
+	benches    map[string]*Bench
+	checkers   map[string]*Checker
+	generators map[string]*Generator
+}
+
+// NewRegistry 创建空注册表
+func NewRegistry() *Registry {
+	return &Registry{specs: make(map[string]*Spec)}
+}
+
+// Register 注册算法，名称重复时报错
+func (r *Registry) Register(spec *Spec) error {
+	if spec.Name == "" || spec.run == nil {
+		return errors.New("algorithm: spec must have a name and be created by Define")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exist := r.specs[spec.Name]; exist {
+		return fmt.Errorf("algorithm: %s already registered", spec.Name)
+	}
+	r.specs[spec.Name] = spec
+	return nil
+}
+
+// MustRegister 注册算法，失败时 panic，用于 init 阶段
+func (r *Registry) MustRegister(specs ...*Spec) {
+	for _, spec := range specs {
+		if err := r.Register(spec); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Get 按名称查找算法
+func (r *Registry) Get(name string) (*Spec, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	spec, exist := r.specs[name]
+	if !exist {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return spec, nil
+}
+
+// List 按名称排序返回所有算法
+func (r *Registry) List() []*Spec {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	specs := make([]*Spec, 0, len(r.specs))
+	for _, spec := range r.specs {
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(i, j int) bool { return specs[i].Name < specs[j].Name })
+	return specs
+}
+
+// Default 内置算法注册表
+var Default = NewRegistry()
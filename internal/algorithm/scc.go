@@ -0,0 +1,154 @@
+package algorithm
+
+import "slices"
+
+/*
+StronglyConnectedComponents Tarjan 强连通分量
+DFS 记录每个节点的发现序 index 和能回溯到的最小发现序 low，low == index 的节点是分量的根
+分量按发现顺序的逆拓扑序返回，分量内升序，O(V + E)
+*/
+func StronglyConnectedComponents(g *Graph) [][]int {
+	index := make([]int, g.N)
+	low := make([]int, g.N)
+	onStack := make([]bool, g.N)
+	for i := range index {
+		index[i] = -1
+	}
+	var (
+		stack      []int
+		components [][]int
+		counter    int
+		connect    func(u int)
+	)
+	connect = func(u int) {
+		index[u], low[u] = counter, counter
+		counter++
+		stack = append(stack, u)
+		onStack[u] = true
+		for _, arc := range g.Adj[u] {
+			if index[arc.To] == -1 {
+				connect(arc.To)
+				low[u] = min(low[u], low[arc.To])
+			} else if onStack[arc.To] {
+				low[u] = min(low[u], index[arc.To])
+			}
+		}
+		if low[u] != index[u] {
+			return
+		}
+		var component []int
+		for {
+			v := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[v] = false
+			component = append(component, v)
+			if v == u {
+				break
+			}
+		}
+		slices.Sort(component)
+		components = append(components, component)
+	}
+	for u := 0; u < g.N; u++ {
+		if index[u] == -1 {
+			connect(u)
+		}
+	}
+	return components
+}
+
+/*
+FindCycle 返回图中的一个环，首尾节点相同，例如 [0, 1, 2, 0]；无环时返回 nil
+有向图：DFS 遇到仍在栈上（灰色）的节点说明有回边，沿父节点回溯得到环
+无向图：并查集加边，第一条两端已连通的边与生成森林中的路径构成环
+*/
+func FindCycle(g *Graph) []int {
+	if g.Directed {
+		return findDirectedCycle(g)
+	}
+	return findUndirectedCycle(g)
+}
+
+func findDirectedCycle(g *Graph) []int {
+	const (
+		white = iota
+		gray
+		black
+	)
+	color := make([]int, g.N)
+	parent := make([]int, g.N)
+	var (
+		cycle []int
+		visit func(u int) bool
+	)
+	visit = func(u int) bool {
+		color[u] = gray
+		for _, arc := range g.Adj[u] {
+			switch color[arc.To] {
+			case white:
+				parent[arc.To] = u
+				if visit(arc.To) {
+					return true
+				}
+			case gray:
+				// u -> arc.To 是回边，arc.To 沿树边走到 u 再回到 arc.To
+				cycle = []int{arc.To}
+				for v := u; v != arc.To; v = parent[v] {
+					cycle = append(cycle, v)
+				}
+				cycle = append(cycle, arc.To)
+				slices.Reverse(cycle)
+				return true
+			}
+		}
+		color[u] = black
+		return false
+	}
+	for u := 0; u < g.N; u++ {
+		if color[u] == white && visit(u) {
+			return cycle
+		}
+	}
+	return nil
+}
+
+func findUndirectedCycle(g *Graph) []int {
+	uf := NewUnionFind(g.N)
+	forest := NewGraph(g.N, false)
+	for _, e := range g.Edges {
+		if uf.Union(e.From, e.To) {
+			forest.AddEdge(e.From, e.To, e.Weight)
+			continue
+		}
+		// 森林中 To 到 From 的路径加上这条边构成环
+		path := treePath(forest, e.To, e.From)
+		return append(path, e.To)
+	}
+	return nil
+}
+
+// treePath BFS 求森林中 from 到 to 的唯一路径
+func treePath(forest *Graph, from, to int) []int {
+	parent := make([]int, forest.N)
+	for i := range parent {
+		parent[i] = -1
+	}
+	parent[from] = from
+	queue := []int{from}
+	for len(queue) > 0 && parent[to] == -1 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, arc := range forest.Adj[u] {
+			if parent[arc.To] == -1 {
+				parent[arc.To] = u
+				queue = append(queue, arc.To)
+			}
+		}
+	}
+	path := []int{to}
+	for v := to; v != from; v = parent[v] {
+		path = append(path, parent[v])
+	}
+	slices.Reverse(path)
+	return path
+}
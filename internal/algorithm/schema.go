@@ -0,0 +1,142 @@
+package algorithm
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Schema 输入结构的 JSON schema 子集，足够前端渲染表单
+type Schema struct {
+	Type        string             `json:"type,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"`
+	Maximum     *float64           `json:"maximum,omitempty"`
+	MinItems    *int               `json:"minItems,omitempty"`
+	MaxItems    *int               `json:"maxItems,omitempty"`
+	MinLength   *int               `json:"minLength,omitempty"`
+	MaxLength   *int               `json:"maxLength,omitempty"`
+	Pattern     string             `json:"pattern,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+}
+
+// Schemaer 自定义 JSON 格式的类型自己描述 schema
+type Schemaer interface {
+	JSONSchema() *Schema
+}
+
+var schemaerType = reflect.TypeOf((*Schemaer)(nil)).Elem()
+
+// SchemaOf 根据 json、binding、doc 结构标签生成 schema
+// binding 中 dive 之前的 min/max 作用于数组本身，之后的作用于元素；匿名嵌入的结构体字段展开到外层
+func SchemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Implements(schemaerType) {
+		return reflect.Zero(t).Interface().(Schemaer).JSONSchema()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if field.Anonymous && name == "" {
+				embedded := SchemaOf(field.Type)
+				for key, property := range embedded.Properties {
+					schema.Properties[key] = property
+				}
+				schema.Required = append(schema.Required, embedded.Required...)
+				continue
+			}
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			property := SchemaOf(field.Type)
+			property.Description = field.Tag.Get("doc")
+			if applyBinding(property, field.Tag.Get("binding")) {
+				schema.Required = append(schema.Required, name)
+			}
+			schema.Properties[name] = property
+		}
+		return schema
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: SchemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	default:
+		return &Schema{}
+	}
+}
+
+// applyBinding 把 binding 标签翻译为 schema 约束，返回字段是否必填
+func applyBinding(schema *Schema, tag string) bool {
+	var required bool
+	current := schema
+	for _, rule := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "required":
+			required = required || current == schema
+		case "dive":
+			if current.Items == nil {
+				return required
+			}
+			current = current.Items
+		case "min", "max", "gte", "lte":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			setBound(current, key == "min" || key == "gte", n)
+		case "oneof":
+			current.Enum = strings.Fields(value)
+		case "numeric":
+			current.Pattern = "^[0-9]+$"
+		}
+	}
+	return required
+}
+
+func setBound(schema *Schema, lower bool, n float64) {
+	size := int(n)
+	switch schema.Type {
+	case "array":
+		if lower {
+			schema.MinItems = &size
+		} else {
+			schema.MaxItems = &size
+		}
+	case "string":
+		if lower {
+			schema.MinLength = &size
+		} else {
+			schema.MaxLength = &size
+		}
+	default:
+		if lower {
+			schema.Minimum = &n
+		} else {
+			schema.Maximum = &n
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package algorithm
+
+import (
+	"container/heap"
+	"errors"
+	"slices"
+)
+
+// ErrNegativeWeight Dijkstra 不支持负权边
+var ErrNegativeWeight = errors.New("graph has negative edge weight")
+
+// ShortestPaths 单源最短路结果，Prev[v] 是最短路上 v 的前驱，没有时为 -1
+type ShortestPaths struct {
+	Source int    `json:"source"`
+	Dist   []Dist `json:"dist"`
+	Prev   []int  `json:"prev"`
+}
+
+func newShortestPaths(n, source int) ShortestPaths {
+	p := ShortestPaths{Source: source, Dist: make([]Dist, n), Prev: make([]int, n)}
+	for i := range p.Dist {
+		p.Dist[i] = Infinity
+		p.Prev[i] = -1
+	}
+	p.Dist[source] = 0
+	return p
+}
+
+// PathTo 还原 source 到 target 的路径，不可达或经过负环时返回 nil
+// 最多沿前驱走 len(Prev) 步，前驱成环时同样返回 nil
+func (p ShortestPaths) PathTo(target int) []int {
+	if p.Dist[target] == Infinity || p.Dist[target] == NegativeInfinity {
+		return nil
+	}
+	var path []int
+	for v := target; v != -1; v = p.Prev[v] {
+		if len(path) == len(p.Prev) {
+			return nil
+		}
+		path = append(path, v)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+type distItem struct {
+	node int
+	dist Dist
+}
+
+// distHeap 按距离排序的最小堆
+type distHeap []distItem
+
+func (h distHeap) Len() int            { return len(h) }
+func (h distHeap) Less(i, j int) bool  { return h[i].dist < h[j].dist }
+func (h distHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *distHeap) Push(x interface{}) { *h = append(*h, x.(distItem)) }
+func (h *distHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+/*
+Dijkstra leetcode 743 单源最短路
+每次从堆中取出距离最小且未确定的节点，用它松弛所有出边
+边权必须非负，O((V + E) log V)
+*/
+func Dijkstra(g *Graph, source int) (ShortestPaths, error) {
+	return dijkstra(g, source, nil)
+}
+
+func dijkstra(g *Graph, source int, t *Trace) (ShortestPaths, error) {
+	for _, e := range g.Edges {
+		if e.Weight < 0 {
+			return ShortestPaths{}, ErrNegativeWeight
+		}
+	}
+	paths := newShortestPaths(g.N, source)
+	done := make([]bool, g.N)
+	h := &distHeap{{node: source}}
+	for h.Len() > 0 {
+		item := heap.Pop(h).(distItem)
+		if done[item.node] {
+			continue
+		}
+		done[item.node] = true
+		var relaxed map[int]Dist
+		if t.Enabled() {
+			relaxed = make(map[int]Dist)
+		}
+		for _, arc := range g.Adj[item.node] {
+			if d := item.dist.add(arc.Weight); d < paths.Dist[arc.To] {
+				paths.Dist[arc.To] = d
+				paths.Prev[arc.To] = item.node
+				heap.Push(h, distItem{node: arc.To, dist: d})
+				if relaxed != nil {
+					relaxed[arc.To] = d
+				}
+			}
+		}
+		if relaxed != nil {
+			t.Record("settle", map[string]interface{}{"node": item.node, "dist": item.dist, "relaxed": relaxed})
+		}
+	}
+	return paths, nil
+}
+
+/*
+BellmanFord 单源最短路，支持负权边
+对所有边松弛 V-1 轮；第 V 轮仍能松弛说明存在从 source 可达的负环，返回负环上的节点
+有负环时再松弛 V 轮，仍能松弛的节点以及从它们可达的节点最短路没有定义，距离为 NegativeInfinity、没有前驱
+O(V * E)
+*/
+func BellmanFord(g *Graph, source int) (ShortestPaths, []int) {
+	return bellmanFord(g, source, nil)
+}
+
+func bellmanFord(g *Graph, source int, m *Meter) (ShortestPaths, []int) {
+	paths := newShortestPaths(g.N, source)
+	var updated int
+	for round := 0; round < g.N; round++ {
+		m.Step(int64(len(g.Edges)))
+		updated = -1
+		for u, arcs := range g.Adj {
+			if paths.Dist[u] == Infinity {
+				continue
+			}
+			for _, arc := range arcs {
+				if d := paths.Dist[u].add(arc.Weight); d < paths.Dist[arc.To] {
+					paths.Dist[arc.To] = d
+					paths.Prev[arc.To] = u
+					updated = arc.To
+				}
+			}
+		}
+		if updated == -1 {
+			return paths, nil
+		}
+	}
+	// 第 V 轮仍有松弛：沿前驱走 V 步一定落在负环上
+	v := updated
+	for i := 0; i < g.N; i++ {
+		v = paths.Prev[v]
+	}
+	cycle := []int{v}
+	for u := paths.Prev[v]; u != v; u = paths.Prev[u] {
+		cycle = append(cycle, u)
+	}
+	cycle = append(cycle, v)
+	slices.Reverse(cycle)
+	// NegativeInfinity 加任何边权仍是 NegativeInfinity，V 轮之内传播到所有受负环影响的节点
+	for round := 0; round < g.N; round++ {
+		m.Step(int64(len(g.Edges)))
+		for u, arcs := range g.Adj {
+			if paths.Dist[u] == Infinity {
+				continue
+			}
+			for _, arc := range arcs {
+				if d := paths.Dist[u].add(arc.Weight); d < paths.Dist[arc.To] || d == NegativeInfinity {
+					paths.Dist[arc.To] = NegativeInfinity
+				}
+			}
+		}
+	}
+	for v, d := range paths.Dist {
+		if d == NegativeInfinity {
+			paths.Prev[v] = -1
+		}
+	}
+	return paths, cycle
+}
+
+/*
+FloydWarshall 全源最短路
+dist[i][j] = min(dist[i][j], dist[i][k] + dist[k][j])，依次允许经过节点 k
+O(V^3)，dist[i][i] < 0 说明 i 在负环上；负环上的距离每轮都会翻倍变小，相加时截断到 NegativeInfinity 避免溢出，
+最后 i 能经过负环到达 j 时 dist[i][j] 都是 NegativeInfinity
+*/
+func FloydWarshall(g *Graph) [][]Dist {
+	return floydWarshall(g, nil)
+}
+
+func floydWarshall(g *Graph, m *Meter) [][]Dist {
+	m.Alloc(intBytes(g.N * g.N))
+	dist := make([][]Dist, g.N)
+	for i := range dist {
+		dist[i] = make([]Dist, g.N)
+		for j := range dist[i] {
+			dist[i][j] = Infinity
+		}
+		dist[i][i] = 0
+	}
+	for u, arcs := range g.Adj {
+		for _, arc := range arcs {
+			dist[u][arc.To] = min(dist[u][arc.To], Dist(arc.Weight))
+		}
+	}
+	for k := 0; k < g.N; k++ {
+		m.Step(int64(g.N * g.N))
+		for i := 0; i < g.N; i++ {
+			if dist[i][k] == Infinity {
+				continue
+			}
+			for j := 0; j < g.N; j++ {
+				if d := dist[i][k].plus(dist[k][j]); d < dist[i][j] {
+					dist[i][j] = d
+				}
+			}
+		}
+	}
+	for k := 0; k < g.N; k++ {
+		if dist[k][k] >= 0 {
+			continue
+		}
+		m.Step(int64(g.N * g.N))
+		for i := 0; i < g.N; i++ {
+			if dist[i][k] == Infinity {
+				continue
+			}
+			for j := 0; j < g.N; j++ {
+				if dist[k][j] != Infinity {
+					dist[i][j] = NegativeInfinity
+				}
+			}
+		}
+	}
+	return dist
+}
@@ -0,0 +1,479 @@
+package algorithm
+
+import (
+	"cmp"
+	"math/bits"
+	"slices"
+)
+
+/*
+本文件的字符串算法都按 rune 处理，下标是 rune 下标，中文等多字节字符算一个字符
+泛型版本（PrefixFunction、ZFunction、SuffixArray、LCPArray）可以直接用于 []rune、[]byte 或其他序列
+*/
+
+// everyPosition 空模式串在 0..n 每个位置都出现，与 strings.Index("", "") == 0 一致
+func everyPosition(n int) []int {
+	positions := make([]int, n+1)
+	for i := range positions {
+		positions[i] = i
+	}
+	return positions
+}
+
+// SearchBruteForce 逐个位置比较 O(n * m)，返回 pattern 在 text 中所有出现位置（可以重叠）
+func SearchBruteForce(text, pattern string) []int {
+	s, p := []rune(text), []rune(pattern)
+	matches := []int{}
+	for i := 0; i+len(p) <= len(s); i++ {
+		if slices.Equal(s[i:i+len(p)], p) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// PrefixFunction KMP 的前缀函数：pi[i] 是 s[:i+1] 最长的相等真前缀与真后缀的长度
+func PrefixFunction[T comparable](s []T) []int {
+	pi := make([]int, len(s))
+	for i := 1; i < len(s); i++ {
+		j := pi[i-1]
+		for j > 0 && s[i] != s[j] {
+			j = pi[j-1]
+		}
+		if s[i] == s[j] {
+			j++
+		}
+		pi[i] = j
+	}
+	return pi
+}
+
+/*
+KMPSearch leetcode 28 找出字符串中第一个匹配项的下标，这里返回所有出现位置（可以重叠）
+失配时 pattern 按前缀函数回退，text 的指针从不回退，O(n + m)
+*/
+func KMPSearch(text, pattern string) []int {
+	p := []rune(pattern)
+	return kmpSearch([]rune(text), p, PrefixFunction(p), nil, nil)
+}
+
+func kmpSearch[T comparable](text, pattern []T, pi []int, t *Trace, m *Meter) []int {
+	if len(pattern) == 0 {
+		return everyPosition(len(text))
+	}
+	m.Step(int64(2 * len(text)))
+	matches := []int{}
+	var j int
+	for i, c := range text {
+		for j > 0 && c != pattern[j] {
+			if t.Enabled() {
+				t.Record("fallback", map[string]interface{}{"i": i, "from": j, "to": pi[j-1]})
+			}
+			j = pi[j-1]
+		}
+		if c == pattern[j] {
+			j++
+		}
+		if j == len(pattern) {
+			matches = append(matches, i-j+1)
+			if t.Enabled() {
+				t.Record("match", map[string]interface{}{"index": i - j + 1})
+			}
+			j = pi[j-1]
+		}
+	}
+	return matches
+}
+
+/*
+ZFunction z[i] 是 s 与 s[i:] 的最长公共前缀长度，z[0] 定义为 len(s)
+维护最右的匹配段 [l, r)：i 在段内时 z[i] 至少是 min(r-i, z[i-l])，再向右暴力扩展，O(n)
+*/
+func ZFunction[T comparable](s []T) []int {
+	z := make([]int, len(s))
+	if len(s) == 0 {
+		return z
+	}
+	z[0] = len(s)
+	for i, l, r := 1, 0, 0; i < len(s); i++ {
+		if i < r {
+			z[i] = min(r-i, z[i-l])
+		}
+		for i+z[i] < len(s) && s[z[i]] == s[i+z[i]] {
+			z[i]++
+		}
+		if i+z[i] > r {
+			l, r = i, i+z[i]
+		}
+	}
+	return z
+}
+
+/*
+ZSearch 在 pattern + 分隔符 + text 上计算 Z 函数，z 值等于 len(pattern) 的位置就是一次匹配
+分隔符取 -1，不会出现在合法的 rune 序列中，O(n + m)
+*/
+func ZSearch(text, pattern string) []int {
+	return zSearch([]rune(text), []rune(pattern), nil)
+}
+
+func zSearch(text, pattern []rune, m *Meter) []int {
+	if len(pattern) == 0 {
+		return everyPosition(len(text))
+	}
+	m.Step(int64(2 * (len(text) + len(pattern))))
+	m.Alloc(intBytes(len(text) + len(pattern)))
+	z := ZFunction(slices.Concat(pattern, []rune{-1}, text))
+	matches := []int{}
+	for i := range text {
+		if z[len(pattern)+1+i] == len(pattern) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+const (
+	// rabinKarpMod 梅森素数 2^61 - 1，乘法用 128 位中间结果取模
+	rabinKarpMod = 1<<61 - 1
+	// rabinKarpBase 大于 rune 的最大值 0x10FFFF
+	rabinKarpBase = 1<<21 + 23
+)
+
+// mulMod61 a * b mod 2^61-1，2^64 ≡ 2^3，2^61 ≡ 1
+func mulMod61(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	sum := hi<<3 | lo>>61 + lo&rabinKarpMod
+	for sum >= rabinKarpMod {
+		sum -= rabinKarpMod
+	}
+	return sum
+}
+
+func addMod61(a, b uint64) uint64 {
+	sum := a + b
+	if sum >= rabinKarpMod {
+		sum -= rabinKarpMod
+	}
+	return sum
+}
+
+/*
+RabinKarp 滚动哈希：窗口右移时减去移出字符的贡献、乘 base、加上新字符，O(1) 更新
+哈希相同时再逐个比较排除冲突，返回所有出现位置以及哈希冲突的次数；期望 O(n + m)
+*/
+func RabinKarp(text, pattern string) (matches []int, collisions int) {
+	return rabinKarp([]rune(text), []rune(pattern), nil)
+}
+
+func rabinKarp(text, pattern []rune, m *Meter) ([]int, int) {
+	n, k := len(text), len(pattern)
+	if k == 0 {
+		return everyPosition(n), 0
+	}
+	matches := []int{}
+	if k > n {
+		return matches, 0
+	}
+	m.Step(int64(n + k))
+	// power = base^(k-1)，用于去掉窗口最左边的字符
+	var target, window, power uint64 = 0, 0, 1
+	for i := 0; i < k; i++ {
+		target = addMod61(mulMod61(target, rabinKarpBase), uint64(pattern[i]))
+		window = addMod61(mulMod61(window, rabinKarpBase), uint64(text[i]))
+		if i > 0 {
+			power = mulMod61(power, rabinKarpBase)
+		}
+	}
+	var collisions int
+	for i := 0; ; i++ {
+		if window == target {
+			m.Step(int64(k))
+			if slices.Equal(text[i:i+k], pattern) {
+				matches = append(matches, i)
+			} else {
+				collisions++
+			}
+		}
+		if i+k == n {
+			return matches, collisions
+		}
+		window = addMod61(window, rabinKarpMod-mulMod61(uint64(text[i]), power))
+		window = addMod61(mulMod61(window, rabinKarpBase), uint64(text[i+k]))
+	}
+}
+
+/*
+LongestPalindrome leetcode 5 最长回文子串，Manacher O(n)
+在字符之间（包括两端）插入间隔，奇偶回文统一为以某个位置为中心的回文，radius[i] 是最长回文半径
+已知最右回文的中心 center、右端 right，i < right 时 radius[i] 至少是 min(radius[2*center-i], right-i)
+变换后半径 r 的回文对应原串中长度为 r 的回文，返回 rune 下标的窗口，s 为空时返回 {0, -1}
+*/
+func LongestPalindrome(s string) Window {
+	return longestPalindrome([]rune(s), nil, nil)
+}
+
+func longestPalindrome(s []rune, t *Trace, m *Meter) Window {
+	n := 2*len(s) + 1
+	m.Step(int64(2 * n))
+	m.Alloc(intBytes(n))
+	// 偶数位置是间隔，奇数位置 2i+1 是 s[i]
+	at := func(i int) rune {
+		if i%2 == 0 {
+			return -1
+		}
+		return s[i/2]
+	}
+	radius := make([]int, n)
+	best := Window{Left: 0, Right: -1}
+	for i, center, right := 0, 0, 0; i < n; i++ {
+		if i < right {
+			radius[i] = min(radius[2*center-i], right-i)
+		}
+		for i-radius[i] > 0 && i+radius[i] < n-1 && at(i-radius[i]-1) == at(i+radius[i]+1) {
+			radius[i]++
+		}
+		if i+radius[i] > right {
+			center, right = i, i+radius[i]
+			if t.Enabled() {
+				t.Record("extend", map[string]interface{}{"center": center, "radius": radius[i]})
+			}
+		}
+		if radius[i] > best.Len() {
+			left := (i - radius[i]) / 2
+			best = Window{Left: left, Right: left + radius[i] - 1}
+		}
+	}
+	return best
+}
+
+// isPalindrome 首尾对称
+func isPalindrome[T comparable](s []T) bool {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		if s[i] != s[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// LongestPalindromeBruteForce 以每个字符和每个间隔为中心向两边扩展 O(n^2)，返回长度
+func LongestPalindromeBruteForce(s string) int {
+	runes := []rune(s)
+	var best int
+	for center := 0; center < 2*len(runes)-1; center++ {
+		left, right := center/2, (center+1)/2
+		for left >= 0 && right < len(runes) && runes[left] == runes[right] {
+			left, right = left-1, right+1
+		}
+		best = max(best, right-left-1)
+	}
+	return best
+}
+
+/*
+SuffixArray 后缀数组：所有后缀按字典序排序后的起始下标，倍增 O(n log^2 n)
+rank 是只看前 k 个元素时的名次，按 (rank[i], rank[i+k]) 排序得到只看前 2k 个元素时的名次，名次两两不同时结束
+*/
+func SuffixArray[T cmp.Ordered](s []T) []int {
+	return suffixArray(s, nil)
+}
+
+func suffixArray[T cmp.Ordered](s []T, m *Meter) []int {
+	n := len(s)
+	m.Alloc(intBytes(3 * n))
+	sa, rank, next := make([]int, n), make([]int, n), make([]int, n)
+	for i := range sa {
+		sa[i] = i
+	}
+	m.Step(int64(n))
+	slices.SortFunc(sa, func(a, b int) int { return cmp.Compare(s[a], s[b]) })
+	for i := 1; i < n; i++ {
+		rank[sa[i]] = rank[sa[i-1]]
+		if s[sa[i]] != s[sa[i-1]] {
+			rank[sa[i]]++
+		}
+	}
+	for k := 1; n > 0 && rank[sa[n-1]] < n-1; k *= 2 {
+		// 后缀 i 的第二关键字 rank[i+k]，越界时取 -1 排在最前
+		second := func(i int) int {
+			if i+k < n {
+				return rank[i+k]
+			}
+			return -1
+		}
+		compare := func(a, b int) int {
+			return cmp.Or(cmp.Compare(rank[a], rank[b]), cmp.Compare(second(a), second(b)))
+		}
+		m.Step(int64(n))
+		slices.SortFunc(sa, compare)
+		next[sa[0]] = 0
+		for i := 1; i < n; i++ {
+			next[sa[i]] = next[sa[i-1]]
+			if compare(sa[i-1], sa[i]) < 0 {
+				next[sa[i]]++
+			}
+		}
+		rank, next = next, rank
+	}
+	return sa
+}
+
+/*
+LCPArray Kasai 算法 O(n)：lcp[i] 是后缀 sa[i-1] 与 sa[i] 的最长公共前缀长度，lcp[0] = 0
+按起始位置从左到右处理，后缀 i 的 lcp 为 h 时，后缀 i+1 的 lcp 至少是 h-1
+*/
+func LCPArray[T comparable](s []T, sa []int) []int {
+	n := len(s)
+	rank := make([]int, n)
+	for i, start := range sa {
+		rank[start] = i
+	}
+	lcp := make([]int, n)
+	var h int
+	for i := range s {
+		if rank[i] == 0 {
+			h = 0
+			continue
+		}
+		j := sa[rank[i]-1]
+		for i+h < n && j+h < n && s[i+h] == s[j+h] {
+			h++
+		}
+		lcp[rank[i]] = h
+		if h > 0 {
+			h--
+		}
+	}
+	return lcp
+}
+
+// SuffixArrayBruteForce 直接比较后缀排序 O(n^2 log n)
+func SuffixArrayBruteForce[T cmp.Ordered](s []T) []int {
+	sa := make([]int, len(s))
+	for i := range sa {
+		sa[i] = i
+	}
+	slices.SortFunc(sa, func(a, b int) int { return slices.Compare(s[a:], s[b:]) })
+	return sa
+}
+
+// commonPrefix 两个序列的最长公共前缀长度
+func commonPrefix[T comparable](a, b []T) int {
+	var n int
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// LongestRepeated 最长的出现至少两次（可以重叠）的子串，即 lcp 的最大值，没有时返回 {0, -1}
+func LongestRepeated(sa, lcp []int) Window {
+	best := Window{Left: 0, Right: -1}
+	for i, h := range lcp {
+		if h > best.Len() {
+			best = Window{Left: sa[i], Right: sa[i] + h - 1}
+		}
+	}
+	return best
+}
+
+// DistinctSubstrings 不同的非空子串个数：所有后缀的前缀共 n(n+1)/2 个，相邻后缀的公共前缀重复计算
+func DistinctSubstrings(lcp []int) int {
+	n := len(lcp)
+	count := n * (n + 1) / 2
+	for _, h := range lcp {
+		count -= h
+	}
+	return count
+}
+
+// 编辑操作
+const (
+	EditMatch   = "match"
+	EditReplace = "replace"
+	EditInsert  = "insert"
+	EditDelete  = "delete"
+)
+
+// EditOp 一步编辑，i、j 是操作对应的 word1、word2 中的 rune 下标，插入时 i 是插入位置，删除时 j 是对应位置
+type EditOp struct {
+	Op   string `json:"op"`
+	I    int    `json:"i"`
+	J    int    `json:"j"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+/*
+EditDistance leetcode 72 编辑距离，按 rune 计算
+dp[i][j] 是 word1 前 i 个字符变成 word2 前 j 个字符的最少操作数
+末尾相同时 dp[i][j] = dp[i-1][j-1]，否则是替换 dp[i-1][j-1]、删除 dp[i-1][j]、插入 dp[i][j-1] 中最小的加一
+从 dp[m][n] 回溯得到一组最少的操作，包括 match
+*/
+func EditDistance(word1, word2 string) (int, []EditOp) {
+	return editDistance([]rune(word1), []rune(word2), nil)
+}
+
+func editDistance(a, b []rune, meter *Meter) (int, []EditOp) {
+	m, n := len(a), len(b)
+	meter.Alloc(intBytes((m + 1) * (n + 1)))
+	dp := make([][]int, m+1)
+	for i := range dp {
+		dp[i] = make([]int, n+1)
+		dp[i][0] = i
+	}
+	for j := range dp[0] {
+		dp[0][j] = j
+	}
+	for i := 1; i <= m; i++ {
+		meter.Step(int64(n))
+		for j := 1; j <= n; j++ {
+			if a[i-1] == b[j-1] {
+				dp[i][j] = dp[i-1][j-1]
+			} else {
+				dp[i][j] = min(dp[i-1][j-1], dp[i-1][j], dp[i][j-1]) + 1
+			}
+		}
+	}
+	ops := make([]EditOp, 0, max(m, n))
+	for i, j := m, n; i > 0 || j > 0; {
+		switch {
+		case i > 0 && j > 0 && a[i-1] == b[j-1] && dp[i][j] == dp[i-1][j-1]:
+			i, j = i-1, j-1
+			ops = append(ops, EditOp{Op: EditMatch, I: i, J: j, From: string(a[i]), To: string(b[j])})
+		case i > 0 && j > 0 && dp[i][j] == dp[i-1][j-1]+1:
+			i, j = i-1, j-1
+			ops = append(ops, EditOp{Op: EditReplace, I: i, J: j, From: string(a[i]), To: string(b[j])})
+		case i > 0 && dp[i][j] == dp[i-1][j]+1:
+			i--
+			ops = append(ops, EditOp{Op: EditDelete, I: i, J: j, From: string(a[i])})
+		default:
+			j--
+			ops = append(ops, EditOp{Op: EditInsert, I: i, J: j, To: string(b[j])})
+		}
+	}
+	slices.Reverse(ops)
+	return dp[m][n], ops
+}
+
+// EditDistanceRows 只保留两行的 dp，返回距离
+func EditDistanceRows(word1, word2 string) int {
+	a, b := []rune(word1), []rune(word2)
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				cur[j] = prev[j-1]
+			} else {
+				cur[j] = min(prev[j-1], prev[j], cur[j-1]) + 1
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,363 @@
+package algorithm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// SearchInput 字符串匹配输入，长度按字符（rune）计算
+type SearchInput struct {
+	Text    string `json:"text" binding:"max=100000"`
+	Pattern string `json:"pattern" binding:"required,max=10000"`
+}
+
+// LongTextInput 较长的单个字符串输入
+type LongTextInput struct {
+	Text string `json:"text" binding:"max=100000"`
+}
+
+// EditDistanceInput editDistance 输入
+type EditDistanceInput struct {
+	Word1 string `json:"word1" binding:"max=1000"`
+	Word2 string `json:"word2" binding:"max=1000"`
+}
+
+// MatchResult 字符串匹配结果，first 是第一次出现的位置（没有时为 -1），matches 是所有出现位置，都是 rune 下标
+type MatchResult struct {
+	First   int   `json:"first"`
+	Matches []int `json:"matches"`
+}
+
+func matchResult(matches []int) MatchResult {
+	first := -1
+	if len(matches) > 0 {
+		first = matches[0]
+	}
+	return MatchResult{First: first, Matches: matches}
+}
+
+// KMPResult kmpSearch 结果，prefix 是 pattern 的前缀函数
+type KMPResult struct {
+	MatchResult
+	Prefix []int `json:"prefix"`
+}
+
+// ZResult zSearch 结果，z 是 pattern 的 Z 函数
+type ZResult struct {
+	MatchResult
+	Z []int `json:"z"`
+}
+
+// RabinKarpResult rabinKarp 结果，collisions 是哈希相同但内容不同的次数
+type RabinKarpResult struct {
+	MatchResult
+	Collisions int `json:"collisions"`
+}
+
+// SuffixArrayResult suffixArray 结果
+// longestRepeated 是出现至少两次的最长子串，distinct 是不同的非空子串个数
+type SuffixArrayResult struct {
+	SuffixArray     []int           `json:"suffixArray"`
+	LCP             []int           `json:"lcp"`
+	LongestRepeated SubstringResult `json:"longestRepeated"`
+	Distinct        int             `json:"distinct"`
+}
+
+// EditAlignment 按编辑操作对齐的两个字符串，插入、删除的位置用 '-' 补齐
+type EditAlignment struct {
+	Word1 string `json:"word1"`
+	Word2 string `json:"word2"`
+}
+
+// EditDistanceResult editDistance 结果
+type EditDistanceResult struct {
+	Distance  int           `json:"distance"`
+	Ops       []EditOp      `json:"ops"`
+	Alignment EditAlignment `json:"alignment"`
+}
+
+func alignEdits(ops []EditOp) EditAlignment {
+	var word1, word2 strings.Builder
+	for _, op := range ops {
+		switch op.Op {
+		case EditInsert:
+			word1.WriteByte('-')
+			word2.WriteString(op.To)
+		case EditDelete:
+			word1.WriteString(op.From)
+			word2.WriteByte('-')
+		default:
+			word1.WriteString(op.From)
+			word2.WriteString(op.To)
+		}
+	}
+	return EditAlignment{Word1: word1.String(), Word2: word2.String()}
+}
+
+func checkMatches(in *SearchInput, expected, got MatchResult) error {
+	if !slices.Equal(got.Matches, expected.Matches) {
+		return fmt.Errorf("matches = %v, want %v", got.Matches, expected.Matches)
+	}
+	if got.First != expected.First {
+		return fmt.Errorf("first = %d, want %d", got.First, expected.First)
+	}
+	return nil
+}
+
+func checkLongestPalindrome(in *LongTextInput, expected, got SubstringResult) error {
+	return checkSubstring(in.Text, expected, got, func(substring []rune) error {
+		if !isPalindrome(substring) {
+			return fmt.Errorf("%q is not a palindrome", string(substring))
+		}
+		return nil
+	})
+}
+
+/*
+applyEdits 依次执行操作，检查下标与位置一致、match 两边相同、replace 两边不同，
+最后得到 word2，非 match 操作的个数就是代价
+*/
+func applyEdits(word1, word2 string, ops []EditOp) (int, error) {
+	a, b := []rune(word1), []rune(word2)
+	var i, j, cost int
+	for k, op := range ops {
+		if op.I != i || op.J != j {
+			return 0, fmt.Errorf("ops[%d] is at (%d, %d), want (%d, %d)", k, op.I, op.J, i, j)
+		}
+		consume1 := op.Op != EditInsert
+		consume2 := op.Op != EditDelete
+		if consume1 && (i >= len(a) || op.From != string(a[i])) || consume2 && (j >= len(b) || op.To != string(b[j])) {
+			return 0, fmt.Errorf("ops[%d] %s does not match the words", k, op.Op)
+		}
+		switch op.Op {
+		case EditMatch, EditReplace:
+			if (op.From == op.To) != (op.Op == EditMatch) {
+				return 0, fmt.Errorf("ops[%d] %s %q -> %q", k, op.Op, op.From, op.To)
+			}
+		case EditInsert, EditDelete:
+		default:
+			return 0, fmt.Errorf("ops[%d] has unknown op %q", k, op.Op)
+		}
+		if op.Op != EditMatch {
+			cost++
+		}
+		if consume1 {
+			i++
+		}
+		if consume2 {
+			j++
+		}
+	}
+	if i != len(a) || j != len(b) {
+		return 0, errors.New("ops do not cover both words")
+	}
+	return cost, nil
+}
+
+func checkEditDistance(in *EditDistanceInput, expected, got EditDistanceResult) error {
+	if got.Distance != expected.Distance {
+		return fmt.Errorf("distance = %d, want %d", got.Distance, expected.Distance)
+	}
+	cost, err := applyEdits(in.Word1, in.Word2, got.Ops)
+	if err != nil {
+		return err
+	}
+	if cost != got.Distance {
+		return fmt.Errorf("ops cost %d, not %d", cost, got.Distance)
+	}
+	return nil
+}
+
+// alphabetFor 按 flavor 选字母表：unicode 时包含中文，allEqual 时只有一个字符
+func alphabetFor(flavor, alphabet string) string {
+	switch flavor {
+	case FlavorUnicode:
+		return "中文字符串ab"
+	case FlavorAllEqual:
+		return alphabet[:1]
+	}
+	return alphabet
+}
+
+// flavorsText 字符串题目通用的 flavor
+var flavorsText = []string{FlavorRandom, FlavorEmpty, FlavorAllEqual, FlavorUnicode}
+
+func genSearch(r *rand.Rand, opts GenerateOptions) SearchInput {
+	alphabet := alphabetFor(opts.Flavor, "abc")
+	text := randString(r, opts.n(0, 100000), alphabet)
+	return SearchInput{Text: text, Pattern: randString(r, 1+r.Intn(3), alphabet)}
+}
+
+// randSearch 小字母表上的短模式串，保证有较多匹配与部分匹配
+func randSearch(r *rand.Rand, size int) SearchInput {
+	alphabet := []string{"ab", "ab中"}[r.Intn(2)]
+	return SearchInput{Text: randString(r, size, alphabet), Pattern: randString(r, 1+r.Intn(4), alphabet)}
+}
+
+func init() {
+	Default.MustRegister(
+		Define(Spec{
+			Name: "kmpSearch", Title: "Find the Index of the First Occurrence in a String (KMP)", LeetCode: 28,
+			Category: CategoryString, Time: "O(n + m)", Space: "O(m)", Traceable: true,
+		}, SearchInput{Text: "ababcabcabababd", Pattern: "ababd"},
+			func(ctx context.Context, in *SearchInput) (KMPResult, error) {
+				pattern := []rune(in.Pattern)
+				prefix := PrefixFunction(pattern)
+				matches := kmpSearch([]rune(in.Text), pattern, prefix, TraceFrom(ctx), MeterFrom(ctx))
+				return KMPResult{MatchResult: matchResult(matches), Prefix: prefix}, nil
+			}),
+		Define(Spec{
+			Name: "zSearch", Title: "String Matching with Z-function",
+			Category: CategoryString, Time: "O(n + m)", Space: "O(n + m)",
+		}, SearchInput{Text: "中文字符串里的字符", Pattern: "字符"},
+			func(ctx context.Context, in *SearchInput) (ZResult, error) {
+				pattern := []rune(in.Pattern)
+				matches := zSearch([]rune(in.Text), pattern, MeterFrom(ctx))
+				return ZResult{MatchResult: matchResult(matches), Z: ZFunction(pattern)}, nil
+			}),
+		Define(Spec{
+			Name: "rabinKarp", Title: "String Matching with Rabin-Karp",
+			Category: CategoryString, Time: "O(n + m) expected", Space: "O(1)",
+		}, SearchInput{Text: "abracadabra", Pattern: "abra"},
+			func(ctx context.Context, in *SearchInput) (RabinKarpResult, error) {
+				matches, collisions := rabinKarp([]rune(in.Text), []rune(in.Pattern), MeterFrom(ctx))
+				return RabinKarpResult{MatchResult: matchResult(matches), Collisions: collisions}, nil
+			}),
+		Define(Spec{
+			Name: "longestPalindrome", Title: "Longest Palindromic Substring (Manacher)", LeetCode: 5,
+			Category: CategoryString, Time: "O(n)", Space: "O(n)", Traceable: true,
+		}, LongTextInput{Text: "babad"},
+			func(ctx context.Context, in *LongTextInput) (SubstringResult, error) {
+				return substringResult(in.Text, longestPalindrome([]rune(in.Text), TraceFrom(ctx), MeterFrom(ctx))), nil
+			}),
+		Define(Spec{
+			Name: "suffixArray", Title: "Suffix Array with LCP",
+			Category: CategoryString, Time: "O(n log^2 n)", Space: "O(n)",
+		}, LongTextInput{Text: "banana"},
+			func(ctx context.Context, in *LongTextInput) (SuffixArrayResult, error) {
+				runes := []rune(in.Text)
+				sa := suffixArray(runes, MeterFrom(ctx))
+				lcp := LCPArray(runes, sa)
+				return SuffixArrayResult{
+					SuffixArray:     sa,
+					LCP:             lcp,
+					LongestRepeated: substringResult(in.Text, LongestRepeated(sa, lcp)),
+					Distinct:        DistinctSubstrings(lcp),
+				}, nil
+			}),
+		Define(Spec{
+			Name: "editDistance", Title: "Edit Distance", LeetCode: 72,
+			Category: CategoryString, Time: "O(m * n)", Space: "O(m * n)",
+		}, EditDistanceInput{Word1: "horse", Word2: "ros"},
+			func(ctx context.Context, in *EditDistanceInput) (EditDistanceResult, error) {
+				distance, ops := editDistance([]rune(in.Word1), []rune(in.Word2), MeterFrom(ctx))
+				return EditDistanceResult{Distance: distance, Ops: ops, Alignment: alignEdits(ops)}, nil
+			}),
+	)
+
+	Default.MustRegisterPair(
+		DefinePair("kmpSearch", "kmpSearch vs 逐个位置比较",
+			randSearch,
+			func(in SearchInput) []int { return SearchBruteForce(in.Text, in.Pattern) },
+			func(in SearchInput) []int { return KMPSearch(in.Text, in.Pattern) },
+			nil, nil),
+		DefinePair("zSearch", "zSearch vs 逐个位置比较",
+			randSearch,
+			func(in SearchInput) []int { return SearchBruteForce(in.Text, in.Pattern) },
+			func(in SearchInput) []int { return ZSearch(in.Text, in.Pattern) },
+			nil, nil),
+		DefinePair("rabinKarp", "rabinKarp vs 逐个位置比较",
+			randSearch,
+			func(in SearchInput) []int { return SearchBruteForce(in.Text, in.Pattern) },
+			func(in SearchInput) []int {
+				matches, _ := RabinKarp(in.Text, in.Pattern)
+				return matches
+			},
+			nil, nil),
+		DefinePair("longestPalindrome", "longestPalindrome Manacher vs 中心扩展（并检查回文）",
+			func(r *rand.Rand, size int) string {
+				return randString(r, size, []string{"ab", "abc", "回文ab"}[r.Intn(3)])
+			},
+			LongestPalindromeBruteForce,
+			func(text string) int {
+				got := substringResult(text, LongestPalindrome(text))
+				if checkLongestPalindrome(&LongTextInput{Text: text}, got, got) != nil {
+					return -1
+				}
+				return got.Length
+			},
+			nil, nil),
+		DefinePair("suffixArray", "suffixArray 倍增 vs 直接排序后缀",
+			func(r *rand.Rand, size int) []rune { return []rune(randString(r, size, "ab中")) },
+			SuffixArrayBruteForce[rune], SuffixArray[rune], nil, nil),
+		DefinePair("suffixArrayLCP", "suffixArray Kasai vs 逐对比较相邻后缀",
+			func(r *rand.Rand, size int) []rune { return []rune(randString(r, size, "ab中")) },
+			func(s []rune) []int {
+				sa := SuffixArrayBruteForce(s)
+				lcp := make([]int, len(s))
+				for i := 1; i < len(sa); i++ {
+					lcp[i] = commonPrefix(s[sa[i-1]:], s[sa[i]:])
+				}
+				return lcp
+			},
+			func(s []rune) []int { return LCPArray(s, SuffixArray(s)) },
+			nil, nil),
+		DefinePair("editDistance", "editDistance 回溯操作 vs 两行 dp",
+			func(r *rand.Rand, size int) EditDistanceInput {
+				alphabet := []string{"ab", "abc", "编辑ab"}[r.Intn(3)]
+				return EditDistanceInput{Word1: randString(r, r.Intn(size+1), alphabet), Word2: randString(r, r.Intn(size+1), alphabet)}
+			},
+			func(in EditDistanceInput) int { return EditDistanceRows(in.Word1, in.Word2) },
+			func(in EditDistanceInput) int {
+				distance, ops := EditDistance(in.Word1, in.Word2)
+				if cost, err := applyEdits(in.Word1, in.Word2, ops); err != nil || cost != distance {
+					return -1
+				}
+				return distance
+			},
+			nil, nil),
+	)
+
+	Default.MustRegisterChecker(
+		DefineChecker("kmpSearch", func(in *SearchInput, expected, got KMPResult) error {
+			return checkMatches(in, expected.MatchResult, got.MatchResult)
+		}),
+		DefineChecker("zSearch", func(in *SearchInput, expected, got ZResult) error {
+			return checkMatches(in, expected.MatchResult, got.MatchResult)
+		}),
+		DefineChecker("rabinKarp", func(in *SearchInput, expected, got RabinKarpResult) error {
+			return checkMatches(in, expected.MatchResult, got.MatchResult)
+		}),
+		DefineChecker("longestPalindrome", checkLongestPalindrome),
+		DefineChecker("editDistance", checkEditDistance),
+	)
+
+	Default.MustRegisterGenerator(
+		DefineGenerator("kmpSearch", 0, 0, []string{FlavorRandom, FlavorAllEqual, FlavorUnicode}, genSearch),
+		DefineGenerator("zSearch", 0, 0, []string{FlavorRandom, FlavorAllEqual, FlavorUnicode}, genSearch),
+		DefineGenerator("rabinKarp", 0, 0, []string{FlavorRandom, FlavorAllEqual, FlavorUnicode}, genSearch),
+		DefineGenerator("longestPalindrome", 0, 0, flavorsText, func(r *rand.Rand, opts GenerateOptions) LongTextInput {
+			if opts.Flavor == FlavorEmpty {
+				return LongTextInput{}
+			}
+			return LongTextInput{Text: randString(r, opts.n(0, 100000), alphabetFor(opts.Flavor, "abc"))}
+		}),
+		DefineGenerator("suffixArray", 0, 0, flavorsText, func(r *rand.Rand, opts GenerateOptions) LongTextInput {
+			if opts.Flavor == FlavorEmpty {
+				return LongTextInput{}
+			}
+			return LongTextInput{Text: randString(r, opts.n(0, 100000), alphabetFor(opts.Flavor, "abcd"))}
+		}),
+		DefineGenerator("editDistance", 0, 0, flavorsText, func(r *rand.Rand, opts GenerateOptions) EditDistanceInput {
+			if opts.Flavor == FlavorEmpty {
+				return EditDistanceInput{Word2: randString(r, opts.n(0, 1000), "abc")}
+			}
+			alphabet := alphabetFor(opts.Flavor, "abcdef")
+			return EditDistanceInput{Word1: randString(r, opts.n(0, 1000), alphabet), Word2: randString(r, r.Intn(opts.n(0, 1000)+1), alphabet)}
+		}),
+	)
+}
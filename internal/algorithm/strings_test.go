@@ -0,0 +1,132 @@
+package algorithm
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestStringSearch 三种匹配算法和暴力匹配的已知答案，包含重叠匹配、空文本、单个字符和多字节字符
+func TestStringSearch(t *testing.T) {
+	tests := []struct {
+		text, pattern string
+		matches       []int
+	}{
+		{"sadbutsad", "sad", []int{0, 6}},
+		{"leetcode", "leeto", []int{}},
+		{"aaaa", "aa", []int{0, 1, 2}},
+		{"", "a", []int{}},
+		{"a", "a", []int{0}},
+		{"a", "ab", []int{}},
+		{"你好你好", "你好", []int{0, 2}},
+	}
+	for _, test := range tests {
+		rabinKarp, _ := RabinKarp(test.text, test.pattern)
+		for name, got := range map[string][]int{
+			"brute force": SearchBruteForce(test.text, test.pattern),
+			"kmp":         KMPSearch(test.text, test.pattern),
+			"z":           ZSearch(test.text, test.pattern),
+			"rabin-karp":  rabinKarp,
+		} {
+			if len(got) != len(test.matches) || len(got) > 0 && !reflect.DeepEqual(got, test.matches) {
+				t.Errorf("%s(%q, %q) = %v, want %v", name, test.text, test.pattern, got, test.matches)
+			}
+		}
+	}
+}
+
+// TestPrefixAndZFunction 前缀函数和 Z 函数的已知值
+func TestPrefixAndZFunction(t *testing.T) {
+	s := []rune("aabxaab")
+	if got := PrefixFunction(s); !reflect.DeepEqual(got, []int{0, 1, 0, 0, 1, 2, 3}) {
+		t.Errorf("PrefixFunction(%q) = %v", string(s), got)
+	}
+	if got := ZFunction(s); !reflect.DeepEqual(got, []int{7, 1, 0, 0, 3, 1, 0}) {
+		t.Errorf("ZFunction(%q) = %v", string(s), got)
+	}
+	if got := ZFunction([]int{-1}); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("ZFunction single = %v", got)
+	}
+}
+
+// TestLongestPalindrome Manacher 的已知答案，奇偶长度、空串和单个字符
+func TestLongestPalindrome(t *testing.T) {
+	tests := []struct {
+		s      string
+		window Window
+	}{
+		{"babad", Window{0, 2}},
+		{"cbbd", Window{1, 2}},
+		{"", Window{0, -1}},
+		{"x", Window{0, 0}},
+		{"上海自来水来自海上", Window{0, 8}},
+	}
+	for _, test := range tests {
+		if got := LongestPalindrome(test.s); got != test.window {
+			t.Errorf("LongestPalindrome(%q) = %v, want %v", test.s, got, test.window)
+		}
+	}
+}
+
+// TestSuffixArray 后缀数组、LCP、最长重复子串和不同子串个数
+func TestSuffixArray(t *testing.T) {
+	tests := []struct {
+		s        string
+		sa, lcp  []int
+		repeated Window
+		distinct int
+	}{
+		{"banana", []int{5, 3, 1, 0, 4, 2}, []int{0, 1, 3, 0, 0, 2}, Window{1, 3}, 15},
+		{"abc", []int{0, 1, 2}, []int{0, 0, 0}, Window{0, -1}, 6},
+		{"aaa", []int{2, 1, 0}, []int{0, 1, 2}, Window{0, 1}, 3},
+		{"z", []int{0}, []int{0}, Window{0, -1}, 1},
+	}
+	for _, test := range tests {
+		s := []rune(test.s)
+		sa := SuffixArray(s)
+		lcp := LCPArray(s, sa)
+		if !reflect.DeepEqual(sa, test.sa) || !reflect.DeepEqual(lcp, test.lcp) {
+			t.Errorf("%q: sa %v lcp %v, want %v %v", test.s, sa, lcp, test.sa, test.lcp)
+			continue
+		}
+		if got := LongestRepeated(sa, lcp); got.Len() != test.repeated.Len() {
+			t.Errorf("%q: longest repeated %v, want length %d", test.s, got, test.repeated.Len())
+		}
+		if got := DistinctSubstrings(lcp); got != test.distinct {
+			t.Errorf("%q: distinct substrings %d, want %d", test.s, got, test.distinct)
+		}
+	}
+	if got := SuffixArray([]rune{}); len(got) != 0 {
+		t.Errorf("SuffixArray(empty) = %v", got)
+	}
+}
+
+// TestEditDistance 编辑距离的已知答案，操作序列的代价等于距离
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		word1, word2 string
+		distance     int
+	}{
+		{"horse", "ros", 3},
+		{"intention", "execution", 5},
+		{"", "", 0},
+		{"", "abc", 3},
+		{"a", "a", 0},
+		{"a", "b", 1},
+		{"北京", "南京", 1},
+	}
+	for _, test := range tests {
+		distance, ops := EditDistance(test.word1, test.word2)
+		if distance != test.distance || EditDistanceRows(test.word1, test.word2) != test.distance {
+			t.Errorf("EditDistance(%q, %q) = %d, want %d", test.word1, test.word2, distance, test.distance)
+		}
+		var cost int
+		for _, op := range ops {
+			if op.Op != EditMatch {
+				cost++
+			}
+		}
+		if cost != distance {
+			t.Errorf("EditDistance(%q, %q): %d non-match ops %v, want %d", test.word1, test.word2, cost, ops, distance)
+		}
+	}
+}
@@ -0,0 +1,373 @@
+package algorithm
+
+import (
+	"cmp"
+	"slices"
+
+	"mango/internal/algorithm/ds"
+)
+
+// Frequency 元素及其出现次数
+type Frequency struct {
+	Value int `json:"value"`
+	Count int `json:"count"`
+}
+
+// frequencies 按值升序统计出现次数
+func frequencies(nums []int) []Frequency {
+	count := make(map[int]int)
+	for _, x := range nums {
+		count[x]++
+	}
+	result := make([]Frequency, 0, len(count))
+	for value, c := range count {
+		result = append(result, Frequency{Value: value, Count: c})
+	}
+	slices.SortFunc(result, func(a, b Frequency) int { return cmp.Compare(a.Value, b.Value) })
+	return result
+}
+
+// byFrequency 频次降序，同频次按值升序
+func byFrequency(a, b Frequency) int {
+	return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Value, b.Value))
+}
+
+/*
+TopKFrequent leetcode 347 前 K 个高频元素
+计数后维护大小为 k 的堆，堆顶是已选中的元素里频次最低的，新元素更好时替换堆顶，O(n log k)
+返回按频次降序（同频次按值升序）的 k 个元素，k 不能超过不同元素的个数
+*/
+func TopKFrequent(nums []int, k int) []Frequency {
+	return topKFrequent(nums, k, nil)
+}
+
+func topKFrequent(nums []int, k int, m *Meter) []Frequency {
+	counts := frequencies(nums)
+	m.Step(int64(len(nums) + len(counts)))
+	// 堆顶是 byFrequency 中排在最后的
+	worse := func(a, b Frequency) bool { return byFrequency(a, b) > 0 }
+	h := ds.NewHeap(worse)
+	for _, f := range counts {
+		if h.Len() < k {
+			h.Push(f)
+		} else {
+			h.PushPop(f)
+		}
+	}
+	top := make([]Frequency, h.Len())
+	for i := len(top) - 1; i >= 0; i-- {
+		top[i] = h.Pop()
+	}
+	return top
+}
+
+// TopKFrequentSort 全部按频次排序后取前 k 个 O(n log n)
+func TopKFrequentSort(nums []int, k int) []Frequency {
+	counts := frequencies(nums)
+	slices.SortFunc(counts, byFrequency)
+	return counts[:k]
+}
+
+/*
+FindKthLargest leetcode 215 数组中的第 K 个最大元素
+大小为 k 的小顶堆，堆顶就是目前为止第 k 大的元素，O(n log k)
+*/
+func FindKthLargest(nums []int, k int) int {
+	h := ds.NewHeapFrom(slices.Clone(nums[:k]), func(a, b int) bool { return a < b })
+	for _, x := range nums[k:] {
+		h.PushPop(x)
+	}
+	return h.Peek()
+}
+
+// FindKthLargestSort 降序排序后取第 k 个
+func FindKthLargestSort(nums []int, k int) int {
+	sorted := slices.Clone(nums)
+	slices.Sort(sorted)
+	return sorted[len(sorted)-k]
+}
+
+/*
+MaxSlidingWindow leetcode 239 滑动窗口最大值，单调队列 O(n)
+队列中的下标递增、值递减，新元素从队尾压入前弹出所有不大于它的元素（它们不可能再成为最大值）
+队首滑出窗口时移除，队首就是窗口最大值；返回每个窗口的最大值及其下标（有多个时取最右的）
+*/
+func MaxSlidingWindow(nums []int, k int) []ds.Indexed[int] {
+	return maxSlidingWindow(nums, k, nil)
+}
+
+func maxSlidingWindow(nums []int, k int, t *Trace) []ds.Indexed[int] {
+	deque := ds.NewMonoDeque(func(back, v int) bool { return back <= v })
+	maxima := make([]ds.Indexed[int], 0, len(nums)-k+1)
+	for i, x := range nums {
+		deque.Push(i, x)
+		if i < k-1 {
+			continue
+		}
+		deque.Evict(i - k + 1)
+		front, _ := deque.Front()
+		maxima = append(maxima, front)
+		if t.Enabled() {
+			t.Record("window", map[string]interface{}{"left": i - k + 1, "right": i, "max": front.Value, "index": front.Index, "deque": deque.Len()})
+		}
+	}
+	return maxima
+}
+
+// MaxSlidingWindowBruteForce 每个窗口扫描一遍 O(n * k)，返回最大值
+func MaxSlidingWindowBruteForce(nums []int, k int) []int {
+	maxima := make([]int, 0, len(nums)-k+1)
+	for i := 0; i+k <= len(nums); i++ {
+		maxima = append(maxima, slices.Max(nums[i:i+k]))
+	}
+	return maxima
+}
+
+/*
+DailyTemperatures leetcode 739 每日温度，单调栈 O(n)
+栈中是还没等到更高温度的日子（温度从底到顶非递增），今天的温度比栈顶高时栈顶就等到了今天
+*/
+func DailyTemperatures(temperatures []int) []int {
+	wait := make([]int, len(temperatures))
+	stack := ds.NewMonoStack(func(top, v int) bool { return top < v })
+	for i, temperature := range temperatures {
+		for _, day := range stack.Push(i, temperature) {
+			wait[day.Index] = i - day.Index
+		}
+	}
+	return wait
+}
+
+// DailyTemperaturesBruteForce 每天向后找第一个更高的温度 O(n^2)
+func DailyTemperaturesBruteForce(temperatures []int) []int {
+	wait := make([]int, len(temperatures))
+	for i, temperature := range temperatures {
+		for j := i + 1; j < len(temperatures); j++ {
+			if temperatures[j] > temperature {
+				wait[i] = j - i
+				break
+			}
+		}
+	}
+	return wait
+}
+
+/*
+LargestRectangle leetcode 84 柱状图中最大的矩形，单调栈 O(n)
+栈中柱子高度从底到顶严格递增，柱子被不高于它的柱子 i 弹出时，以它为高的矩形右边界是 i-1，
+左边界是它在栈中下面那根柱子的下一个位置；末尾压入高度为 0 的哨兵弹出所有柱子
+返回最大面积、高度以及矩形的柱子范围
+*/
+func LargestRectangle(heights []int) (area, height int, window Window) {
+	return largestRectangle(heights, nil)
+}
+
+func largestRectangle(heights []int, t *Trace) (area, height int, window Window) {
+	window = Window{Left: 0, Right: -1}
+	stack := ds.NewMonoStack(func(top, v int) bool { return top >= v })
+	for i := 0; i <= len(heights); i++ {
+		h := 0
+		if i < len(heights) {
+			h = heights[i]
+		}
+		popped := stack.Push(i, h)
+		items := stack.Items()
+		for k, bar := range popped {
+			// 弹出的柱子中，下一根弹出的柱子就在它下面；最后一根下面是剩下的栈顶（新压入的柱子之下）
+			left := 0
+			if k+1 < len(popped) {
+				left = popped[k+1].Index + 1
+			} else if len(items) > 1 {
+				left = items[len(items)-2].Index + 1
+			}
+			candidate := bar.Value * (i - left)
+			if t.Enabled() {
+				t.Record("pop", map[string]interface{}{"index": bar.Index, "height": bar.Value, "left": left, "right": i - 1, "area": candidate})
+			}
+			if candidate > area {
+				area, height, window = candidate, bar.Value, Window{Left: left, Right: i - 1}
+			}
+		}
+	}
+	return area, height, window
+}
+
+// LargestRectangleBruteForce 枚举左边界，向右扩展时维护最小高度 O(n^2)，返回面积
+func LargestRectangleBruteForce(heights []int) int {
+	var best int
+	for i := range heights {
+		lowest := heights[i]
+		for j := i; j < len(heights); j++ {
+			lowest = min(lowest, heights[j])
+			best = max(best, lowest*(j-i+1))
+		}
+	}
+	return best
+}
+
+// 区间和查询使用的数据结构
+const (
+	StructureSegmentTree = "segmentTree"
+	StructureFenwick     = "fenwick"
+)
+
+// rangeSum leetcode 307 区域和检索 - 数组可修改 的两种实现共同的接口
+type rangeSum interface {
+	update(i, value int)
+	sum(left, right int) int
+}
+
+type segmentRangeSum struct{ *ds.SegmentTree[int] }
+
+func (s segmentRangeSum) update(i, value int)     { s.Set(i, value) }
+func (s segmentRangeSum) sum(left, right int) int { return s.Query(left, right+1) }
+
+// fenwickRangeSum 树状数组只支持增加，修改时需要记住当前值
+type fenwickRangeSum struct {
+	*ds.Fenwick[int]
+	values []int
+}
+
+func (f fenwickRangeSum) update(i, value int) {
+	f.Add(i, value-f.values[i])
+	f.values[i] = value
+}
+
+func (f fenwickRangeSum) sum(left, right int) int { return f.RangeSum(left, right+1) }
+
+/*
+RangeSumQueries leetcode 307 区域和检索 - 数组可修改
+operations[i] 为 update 时 args[i] 是 [index, value]，为 sumRange 时是 [left, right]（闭区间）
+structure 选择线段树或树状数组，每次操作 O(log n)；返回每个 sumRange 的结果，update 对应 nil
+*/
+func RangeSumQueries(nums []int, operations []string, args [][2]int, structure string) []*int {
+	var rs rangeSum
+	if structure == StructureFenwick {
+		rs = fenwickRangeSum{Fenwick: ds.NewFenwick(nums), values: slices.Clone(nums)}
+	} else {
+		rs = segmentRangeSum{ds.NewSegmentTree(slices.Clone(nums), func(a, b int) int { return a + b }, 0)}
+	}
+	results := make([]*int, len(operations))
+	for i, op := range operations {
+		if op == "update" {
+			rs.update(args[i][0], args[i][1])
+			continue
+		}
+		sum := rs.sum(args[i][0], args[i][1])
+		results[i] = &sum
+	}
+	return results
+}
+
+// RangeSumBruteForce 直接修改数组、逐个求和
+func RangeSumBruteForce(nums []int, operations []string, args [][2]int) []*int {
+	values := slices.Clone(nums)
+	results := make([]*int, len(operations))
+	for i, op := range operations {
+		if op == "update" {
+			values[args[i][0]] = args[i][1]
+			continue
+		}
+		var sum int
+		for _, x := range values[args[i][0] : args[i][1]+1] {
+			sum += x
+		}
+		results[i] = &sum
+	}
+	return results
+}
+
+// 缓存淘汰策略
+const (
+	CacheLRU = "lru"
+	CacheLFU = "lfu"
+)
+
+// cache LRU 与 LFU 共同的接口
+type cache interface {
+	Get(key int) (int, bool)
+	Put(key, value int) (int, bool)
+}
+
+// CacheTrace 依次执行缓存操作的结果，get 未命中为 -1；evicted 是每次 put 淘汰的 key，其他操作为 nil
+type CacheTrace struct {
+	Results []*int `json:"results"`
+	Evicted []*int `json:"evicted"`
+}
+
+/*
+RunCache leetcode 146 / 460 依次执行 put [key, value] 与 get [key]
+policy 为 lru 时淘汰最久没有使用的 key，为 lfu 时淘汰使用次数最少的（同次数中最久没有使用的）
+*/
+func RunCache(policy string, capacity int, operations []string, args [][]int) CacheTrace {
+	var c cache
+	if policy == CacheLFU {
+		c = ds.NewLFU[int, int](capacity)
+	} else {
+		c = ds.NewLRU[int, int](capacity)
+	}
+	result := CacheTrace{Results: make([]*int, len(operations)), Evicted: make([]*int, len(operations))}
+	for i, op := range operations {
+		if op == "put" {
+			if key, ok := c.Put(args[i][0], args[i][1]); ok {
+				result.Evicted[i] = &key
+			}
+			continue
+		}
+		value, ok := c.Get(args[i][0])
+		if !ok {
+			value = -1
+		}
+		result.Results[i] = &value
+	}
+	return result
+}
+
+// cacheSlot RunCacheBruteForce 中的缓存项，last 是最后一次使用的时间
+type cacheSlot struct {
+	key, value, uses, last int
+}
+
+// RunCacheBruteForce 用切片保存缓存项，淘汰时线性扫描找出 (uses, last) 或 last 最小的
+func RunCacheBruteForce(policy string, capacity int, operations []string, args [][]int) CacheTrace {
+	var slots []cacheSlot
+	result := CacheTrace{Results: make([]*int, len(operations)), Evicted: make([]*int, len(operations))}
+	find := func(key int) int {
+		return slices.IndexFunc(slots, func(s cacheSlot) bool { return s.key == key })
+	}
+	for now, op := range operations {
+		key := args[now][0]
+		i := find(key)
+		if i >= 0 {
+			slots[i].uses++
+			slots[i].last = now
+		}
+		if op == "get" {
+			value := -1
+			if i >= 0 {
+				value = slots[i].value
+			}
+			result.Results[now] = &value
+			continue
+		}
+		if i >= 0 {
+			slots[i].value = args[now][1]
+			continue
+		}
+		if len(slots) == capacity {
+			victim := 0
+			for j, s := range slots {
+				v := slots[victim]
+				if policy == CacheLFU && (s.uses < v.uses || s.uses == v.uses && s.last < v.last) ||
+					policy != CacheLFU && s.last < v.last {
+					victim = j
+				}
+			}
+			evicted := slots[victim].key
+			result.Evicted[now] = &evicted
+			slots = slices.Delete(slots, victim, victim+1)
+		}
+		slots = append(slots, cacheSlot{key: key, value: args[now][1], uses: 1, last: now})
+	}
+	return result
+}
@@ -0,0 +1,451 @@
+package algorithm
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// TopKInput 前 K 个高频元素输入
+type TopKInput struct {
+	Nums []int `json:"nums" binding:"required,min=1,max=100000"`
+	K    int   `json:"k" binding:"required,min=1" doc:"不超过不同元素的个数"`
+}
+
+// Validate k 不超过不同元素的个数
+func (in *TopKInput) Validate() error {
+	if distinct := len(frequencies(in.Nums)); in.K > distinct {
+		return fmt.Errorf("k %d exceeds the number of distinct elements %d", in.K, distinct)
+	}
+	return nil
+}
+
+// NumsKInput 数组与不超过其长度的 k
+type NumsKInput struct {
+	Nums []int `json:"nums" binding:"required,min=1,max=100000"`
+	K    int   `json:"k" binding:"required,min=1" doc:"不超过数组长度"`
+}
+
+// Validate k 不超过数组长度
+func (in *NumsKInput) Validate() error {
+	if in.K > len(in.Nums) {
+		return fmt.Errorf("k %d exceeds the length of nums %d", in.K, len(in.Nums))
+	}
+	return nil
+}
+
+// TemperaturesInput 每日温度输入
+type TemperaturesInput struct {
+	Temperatures []int `json:"temperatures" binding:"required,min=1,max=100000,dive,min=30,max=100"`
+}
+
+// HistogramInput 柱状图输入，与 HeightsInput 不同，允许只有一根柱子
+type HistogramInput struct {
+	Heights []int `json:"heights" binding:"required,min=1,max=100000,dive,min=0,max=10000"`
+}
+
+// MaxRangeSumValue rangeSumQueryMutable 元素绝对值的上限
+const MaxRangeSumValue = 1000000000
+
+// RangeSumInput 区域和检索输入
+type RangeSumInput struct {
+	Nums       []int    `json:"nums" binding:"required,min=1,max=30000,dive,min=-1000000000,max=1000000000"`
+	Operations []string `json:"operations" binding:"required,min=1,max=30000,dive,oneof=update sumRange"`
+	Args       [][2]int `json:"args" binding:"required" doc:"update 为 [index, value]，sumRange 为 [left, right]（闭区间）"`
+	Structure  string   `json:"structure" binding:"omitempty,oneof=segmentTree fenwick" doc:"segmentTree（默认）或 fenwick"`
+}
+
+// Validate 每个操作都有参数，下标在范围内，sumRange 的 left 不大于 right
+func (in *RangeSumInput) Validate() error {
+	if len(in.Operations) != len(in.Args) {
+		return fmt.Errorf("%d operations but %d args", len(in.Operations), len(in.Args))
+	}
+	n := len(in.Nums)
+	for i, op := range in.Operations {
+		a, b := in.Args[i][0], in.Args[i][1]
+		switch {
+		case op == "update" && (a < 0 || a >= n):
+			return fmt.Errorf("args[%d]: index %d out of range [0, %d)", i, a, n)
+		case op == "update" && (b < -MaxRangeSumValue || b > MaxRangeSumValue):
+			return fmt.Errorf("args[%d]: value %d must be within ±%d", i, b, MaxRangeSumValue)
+		case op == "sumRange" && (a < 0 || a > b || b >= n):
+			return fmt.Errorf("args[%d]: range [%d, %d] is not within [0, %d)", i, a, b, n)
+		}
+	}
+	return nil
+}
+
+// CacheInput lruCache、lfuCache 输入
+type CacheInput struct {
+	Capacity   int      `json:"capacity" binding:"required,min=1,max=10000"`
+	Operations []string `json:"operations" binding:"required,min=1,max=100000,dive,oneof=put get"`
+	Args       [][]int  `json:"args" binding:"required" doc:"put 为 [key, value]，get 为 [key]"`
+}
+
+// Validate 每个操作的参数个数正确
+func (in *CacheInput) Validate() error {
+	if len(in.Operations) != len(in.Args) {
+		return fmt.Errorf("%d operations but %d args", len(in.Operations), len(in.Args))
+	}
+	for i, op := range in.Operations {
+		if want := map[string]int{"put": 2, "get": 1}[op]; len(in.Args[i]) != want {
+			return fmt.Errorf("args[%d]: %s takes %d arguments, got %d", i, op, want, len(in.Args[i]))
+		}
+	}
+	return nil
+}
+
+// TopKResult topKFrequent 结果，top 按频次降序
+type TopKResult struct {
+	Elements []int       `json:"elements"`
+	Top      []Frequency `json:"top"`
+}
+
+// SlidingMaxResult slidingWindowMaximum 结果，indices 是每个最大值的下标
+type SlidingMaxResult struct {
+	Maxima  []int `json:"maxima"`
+	Indices []int `json:"indices"`
+}
+
+// TemperaturesResult dailyTemperatures 结果，wait[i] 是第 i 天之后要等几天才有更高的温度，没有时为 0
+type TemperaturesResult struct {
+	Wait []int `json:"wait"`
+}
+
+// RectangleResult largestRectangleInHistogram 结果，window 是矩形覆盖的柱子
+type RectangleResult struct {
+	Area   int    `json:"area"`
+	Height int    `json:"height"`
+	Window Window `json:"window"`
+}
+
+// RangeSumResult rangeSumQueryMutable 结果，update 对应 null
+type RangeSumResult struct {
+	Results []*int `json:"results"`
+}
+
+// checkTopK 元素互不相同、频次正确，且频次的多重集合与 expected 相同（同频次时可以选不同的元素）
+func checkTopK(in *TopKInput, expected, got TopKResult) error {
+	if len(got.Top) != in.K || len(got.Elements) != in.K {
+		return fmt.Errorf("want %d elements, got %d", in.K, len(got.Elements))
+	}
+	count := make(map[int]int)
+	for _, f := range frequencies(in.Nums) {
+		count[f.Value] = f.Count
+	}
+	seen := make(map[int]bool)
+	counts := make([]int, 0, in.K)
+	for i, f := range got.Top {
+		if seen[f.Value] {
+			return fmt.Errorf("%d appears twice", f.Value)
+		}
+		if count[f.Value] != f.Count || got.Elements[i] != f.Value {
+			return fmt.Errorf("top[%d] = %d appears %d times, not %d", i, f.Value, count[f.Value], f.Count)
+		}
+		seen[f.Value] = true
+		counts = append(counts, f.Count)
+	}
+	want := make([]int, 0, in.K)
+	for _, f := range expected.Top {
+		want = append(want, f.Count)
+	}
+	slices.Sort(counts)
+	slices.Sort(want)
+	if !slices.Equal(counts, want) {
+		return fmt.Errorf("frequencies %v, want %v", counts, want)
+	}
+	return nil
+}
+
+// validSlidingMax 每个下标都在对应窗口内且值等于最大值
+func validSlidingMax(nums []int, k int, result SlidingMaxResult) error {
+	if len(result.Indices) != len(result.Maxima) {
+		return fmt.Errorf("%d indices for %d maxima", len(result.Indices), len(result.Maxima))
+	}
+	for i, index := range result.Indices {
+		if index < i || index >= i+k || nums[index] != result.Maxima[i] {
+			return fmt.Errorf("indices[%d] = %d is not the maximum %d of window [%d, %d]", i, index, result.Maxima[i], i, i+k-1)
+		}
+	}
+	return nil
+}
+
+func checkSlidingMax(in *NumsKInput, expected, got SlidingMaxResult) error {
+	if !slices.Equal(got.Maxima, expected.Maxima) {
+		return fmt.Errorf("maxima = %v, want %v", got.Maxima, expected.Maxima)
+	}
+	return validSlidingMax(in.Nums, in.K, got)
+}
+
+// validRectangle window 在范围内，height 是窗口内最矮的柱子，面积等于 height 乘以宽度
+func validRectangle(heights []int, result RectangleResult) error {
+	w := result.Window
+	if w.Right-w.Left+1 == 0 && result.Area == 0 {
+		return nil
+	}
+	if w.Left < 0 || w.Left > w.Right || w.Right >= len(heights) {
+		return fmt.Errorf("window [%d, %d] is out of range", w.Left, w.Right)
+	}
+	if lowest := slices.Min(heights[w.Left : w.Right+1]); lowest != result.Height {
+		return fmt.Errorf("height = %d, but the lowest bar in [%d, %d] is %d", result.Height, w.Left, w.Right, lowest)
+	}
+	if area := result.Height * (w.Right - w.Left + 1); area != result.Area {
+		return fmt.Errorf("window [%d, %d] has area %d, not %d", w.Left, w.Right, area, result.Area)
+	}
+	return nil
+}
+
+func checkRectangle(in *HistogramInput, expected, got RectangleResult) error {
+	if got.Area != expected.Area {
+		return fmt.Errorf("area = %d, want %d", got.Area, expected.Area)
+	}
+	return validRectangle(in.Heights, got)
+}
+
+// slidingMaxResult 拆分 MaxSlidingWindow 的结果
+func slidingMaxResult(nums []int, k int, t *Trace) SlidingMaxResult {
+	maxima := maxSlidingWindow(nums, k, t)
+	result := SlidingMaxResult{Maxima: make([]int, len(maxima)), Indices: make([]int, len(maxima))}
+	for i, m := range maxima {
+		result.Maxima[i], result.Indices[i] = m.Value, m.Index
+	}
+	return result
+}
+
+// randRangeSum n 个元素和 n 个操作，update 与 sumRange 各占一半
+func randRangeSum(r *rand.Rand, opts GenerateOptions, n int) RangeSumInput {
+	in := RangeSumInput{Nums: opts.ints(r, n), Operations: make([]string, n), Args: make([][2]int, n)}
+	for i := range in.Operations {
+		if r.Intn(2) == 0 {
+			in.Operations[i], in.Args[i] = "update", [2]int{r.Intn(n), opts.value(r)}
+			continue
+		}
+		left := r.Intn(n)
+		in.Operations[i], in.Args[i] = "sumRange", [2]int{left, left + r.Intn(n-left)}
+	}
+	return in
+}
+
+// randCacheOps n 个操作，key 和 value 取自 [lo, hi]，put 与 get 各占一半
+func randCacheOps(r *rand.Rand, n, lo, hi int) ([]string, [][]int) {
+	operations, args := make([]string, n), make([][]int, n)
+	for i := range operations {
+		key := lo + r.Intn(hi-lo+1)
+		if r.Intn(2) == 0 {
+			operations[i], args[i] = "put", []int{key, lo + r.Intn(hi-lo+1)}
+		} else {
+			operations[i], args[i] = "get", []int{key}
+		}
+	}
+	return operations, args
+}
+
+func genCache(r *rand.Rand, opts GenerateOptions) CacheInput {
+	n := opts.n(1, 100000)
+	operations, args := randCacheOps(r, n, opts.Min, opts.Max)
+	if opts.Flavor == FlavorAllEqual {
+		for i := range args {
+			args[i][0] = opts.Min
+		}
+	}
+	return CacheInput{Capacity: 1 + r.Intn(min(n, 10000)), Operations: operations, Args: args}
+}
+
+func init() {
+	Default.MustRegister(
+		Define(Spec{
+			Name: "topKFrequent", Title: "Top K Frequent Elements", LeetCode: 347,
+			Category: CategoryHeap, Time: "O(n log k)", Space: "O(n)",
+		}, TopKInput{Nums: []int{1, 1, 1, 2, 2, 3}, K: 2},
+			func(ctx context.Context, in *TopKInput) (TopKResult, error) {
+				top := topKFrequent(in.Nums, in.K, MeterFrom(ctx))
+				elements := make([]int, len(top))
+				for i, f := range top {
+					elements[i] = f.Value
+				}
+				return TopKResult{Elements: elements, Top: top}, nil
+			}),
+		Define(Spec{
+			Name: "kthLargestElement", Title: "Kth Largest Element in an Array", LeetCode: 215,
+			Category: CategoryHeap, Time: "O(n log k)", Space: "O(k)",
+		}, NumsKInput{Nums: []int{3, 2, 1, 5, 6, 4}, K: 2},
+			func(ctx context.Context, in *NumsKInput) (KthResult, error) {
+				return KthResult{Value: FindKthLargest(in.Nums, in.K)}, nil
+			}),
+		Define(Spec{
+			Name: "slidingWindowMaximum", Title: "Sliding Window Maximum", LeetCode: 239,
+			Category: CategoryMonotonicStack, Time: "O(n)", Space: "O(k)", Traceable: true,
+		}, NumsKInput{Nums: []int{1, 3, -1, -3, 5, 3, 6, 7}, K: 3},
+			func(ctx context.Context, in *NumsKInput) (SlidingMaxResult, error) {
+				return slidingMaxResult(in.Nums, in.K, TraceFrom(ctx)), nil
+			}),
+		Define(Spec{
+			Name: "dailyTemperatures", Title: "Daily Temperatures", LeetCode: 739,
+			Category: CategoryMonotonicStack, Time: "O(n)", Space: "O(n)",
+		}, TemperaturesInput{Temperatures: []int{73, 74, 75, 71, 69, 72, 76, 73}},
+			func(ctx context.Context, in *TemperaturesInput) (TemperaturesResult, error) {
+				return TemperaturesResult{Wait: DailyTemperatures(in.Temperatures)}, nil
+			}),
+		Define(Spec{
+			Name: "largestRectangleInHistogram", Title: "Largest Rectangle in Histogram", LeetCode: 84,
+			Category: CategoryMonotonicStack, Time: "O(n)", Space: "O(n)", Traceable: true,
+		}, HistogramInput{Heights: []int{2, 1, 5, 6, 2, 3}},
+			func(ctx context.Context, in *HistogramInput) (RectangleResult, error) {
+				area, height, window := largestRectangle(in.Heights, TraceFrom(ctx))
+				return RectangleResult{Area: area, Height: height, Window: window}, nil
+			}),
+		Define(Spec{
+			Name: "rangeSumQueryMutable", Title: "Range Sum Query - Mutable", LeetCode: 307,
+			Category: CategoryDesign, Time: "O(log n) per operation", Space: "O(n)",
+		}, RangeSumInput{Nums: []int{1, 3, 5}, Operations: []string{"sumRange", "update", "sumRange"}, Args: [][2]int{{0, 2}, {1, 2}, {0, 2}}},
+			func(ctx context.Context, in *RangeSumInput) (RangeSumResult, error) {
+				return RangeSumResult{Results: RangeSumQueries(in.Nums, in.Operations, in.Args, in.Structure)}, nil
+			}),
+		Define(Spec{
+			Name: "lruCache", Title: "LRU Cache", LeetCode: 146,
+			Category: CategoryDesign, Time: "O(1) per operation", Space: "O(capacity)",
+		}, CacheInput{
+			Capacity:   2,
+			Operations: []string{"put", "put", "get", "put", "get", "put", "get", "get", "get"},
+			Args:       [][]int{{1, 1}, {2, 2}, {1}, {3, 3}, {2}, {4, 4}, {1}, {3}, {4}},
+		},
+			func(ctx context.Context, in *CacheInput) (CacheTrace, error) {
+				return RunCache(CacheLRU, in.Capacity, in.Operations, in.Args), nil
+			}),
+		Define(Spec{
+			Name: "lfuCache", Title: "LFU Cache", LeetCode: 460,
+			Category: CategoryDesign, Time: "O(1) per operation", Space: "O(capacity)",
+		}, CacheInput{
+			Capacity:   2,
+			Operations: []string{"put", "put", "get", "put", "get", "get", "put", "get", "get", "get"},
+			Args:       [][]int{{1, 1}, {2, 2}, {1}, {3, 3}, {2}, {3}, {4, 4}, {1}, {3}, {4}},
+		},
+			func(ctx context.Context, in *CacheInput) (CacheTrace, error) {
+				return RunCache(CacheLFU, in.Capacity, in.Operations, in.Args), nil
+			}),
+	)
+
+	Default.MustRegisterPair(
+		DefinePair("topKFrequent", "topKFrequent 大小为 k 的堆 vs 全部排序",
+			func(r *rand.Rand, size int) TopKInput {
+				nums := randInts(r, 1+r.Intn(size), 0, size/2)
+				return TopKInput{Nums: nums, K: 1 + r.Intn(len(frequencies(nums)))}
+			},
+			func(in TopKInput) []Frequency { return TopKFrequentSort(in.Nums, in.K) },
+			func(in TopKInput) []Frequency { return TopKFrequent(in.Nums, in.K) },
+			nil, nil),
+		DefinePair("kthLargestElement", "kthLargestElement 小顶堆 vs 排序",
+			func(r *rand.Rand, size int) NumsKInput {
+				nums := randInts(r, 1+r.Intn(size), -size, size)
+				return NumsKInput{Nums: nums, K: 1 + r.Intn(len(nums))}
+			},
+			func(in NumsKInput) int { return FindKthLargestSort(in.Nums, in.K) },
+			func(in NumsKInput) int { return FindKthLargest(in.Nums, in.K) },
+			nil, nil),
+		DefinePair("slidingWindowMaximum", "slidingWindowMaximum 单调队列 vs 逐个窗口扫描（并检查下标）",
+			func(r *rand.Rand, size int) NumsKInput {
+				nums := randInts(r, 1+r.Intn(size), 0, 10)
+				return NumsKInput{Nums: nums, K: 1 + r.Intn(len(nums))}
+			},
+			func(in NumsKInput) []int { return MaxSlidingWindowBruteForce(in.Nums, in.K) },
+			func(in NumsKInput) []int {
+				result := slidingMaxResult(in.Nums, in.K, nil)
+				if validSlidingMax(in.Nums, in.K, result) != nil {
+					return nil
+				}
+				return result.Maxima
+			},
+			nil, nil),
+		DefinePair("dailyTemperatures", "dailyTemperatures 单调栈 vs 向后扫描",
+			func(r *rand.Rand, size int) []int { return randInts(r, 1+r.Intn(size), 30, 40) },
+			DailyTemperaturesBruteForce,
+			DailyTemperatures,
+			nil, nil),
+		DefinePair("largestRectangleInHistogram", "largestRectangleInHistogram 单调栈 vs 枚举左边界（并检查矩形）",
+			func(r *rand.Rand, size int) []int { return randInts(r, 1+r.Intn(size), 0, 10) },
+			LargestRectangleBruteForce,
+			func(heights []int) int {
+				area, height, window := LargestRectangle(heights)
+				if validRectangle(heights, RectangleResult{Area: area, Height: height, Window: window}) != nil {
+					return -1
+				}
+				return area
+			},
+			nil, nil),
+		DefinePair("rangeSumQueryMutable", "rangeSumQueryMutable 线段树、树状数组 vs 直接求和",
+			func(r *rand.Rand, size int) RangeSumInput {
+				return randRangeSum(r, GenerateOptions{Min: -20, Max: 20, Flavor: FlavorRandom}, 1+r.Intn(size))
+			},
+			func(in RangeSumInput) []*int { return RangeSumBruteForce(in.Nums, in.Operations, in.Args) },
+			func(in RangeSumInput) []*int {
+				segment := RangeSumQueries(in.Nums, in.Operations, in.Args, StructureSegmentTree)
+				fenwick := RangeSumQueries(in.Nums, in.Operations, in.Args, StructureFenwick)
+				for i := range segment {
+					if (segment[i] == nil) != (fenwick[i] == nil) || segment[i] != nil && *segment[i] != *fenwick[i] {
+						return nil
+					}
+				}
+				return segment
+			},
+			nil, nil),
+		DefinePair("lruCache", "lruCache 哈希表 + 双向链表 vs 线性扫描",
+			func(r *rand.Rand, size int) CacheInput {
+				operations, args := randCacheOps(r, 1+r.Intn(3*size), 0, size)
+				return CacheInput{Capacity: 1 + r.Intn(size/2+1), Operations: operations, Args: args}
+			},
+			func(in CacheInput) CacheTrace {
+				return RunCacheBruteForce(CacheLRU, in.Capacity, in.Operations, in.Args)
+			},
+			func(in CacheInput) CacheTrace { return RunCache(CacheLRU, in.Capacity, in.Operations, in.Args) },
+			nil, nil),
+		DefinePair("lfuCache", "lfuCache 按频次分组的链表 vs 线性扫描",
+			func(r *rand.Rand, size int) CacheInput {
+				operations, args := randCacheOps(r, 1+r.Intn(3*size), 0, size)
+				return CacheInput{Capacity: 1 + r.Intn(size/2+1), Operations: operations, Args: args}
+			},
+			func(in CacheInput) CacheTrace {
+				return RunCacheBruteForce(CacheLFU, in.Capacity, in.Operations, in.Args)
+			},
+			func(in CacheInput) CacheTrace { return RunCache(CacheLFU, in.Capacity, in.Operations, in.Args) },
+			nil, nil),
+	)
+
+	Default.MustRegisterChecker(
+		DefineChecker("topKFrequent", checkTopK),
+		DefineChecker("slidingWindowMaximum", checkSlidingMax),
+		DefineChecker("largestRectangleInHistogram", checkRectangle),
+	)
+
+	Default.MustRegisterGenerator(
+		DefineGenerator("topKFrequent", 0, 20, []string{FlavorRandom, FlavorAllEqual}, func(r *rand.Rand, opts GenerateOptions) TopKInput {
+			nums := opts.ints(r, opts.n(1, 100000))
+			return TopKInput{Nums: nums, K: 1 + r.Intn(len(frequencies(nums)))}
+		}),
+		DefineGenerator("kthLargestElement", -100, 100, []string{FlavorRandom, FlavorSorted, FlavorAllEqual}, func(r *rand.Rand, opts GenerateOptions) NumsKInput {
+			nums := opts.ints(r, opts.n(1, 100000))
+			return NumsKInput{Nums: nums, K: 1 + r.Intn(len(nums))}
+		}),
+		DefineGenerator("slidingWindowMaximum", -100, 100, []string{FlavorRandom, FlavorSorted, FlavorAllEqual}, func(r *rand.Rand, opts GenerateOptions) NumsKInput {
+			nums := opts.ints(r, opts.n(1, 100000))
+			if opts.Flavor == FlavorSorted {
+				// 降序时每个新元素都不会弹出队尾，队列最长
+				slices.Reverse(nums)
+			}
+			return NumsKInput{Nums: nums, K: 1 + r.Intn(len(nums))}
+		}),
+		DefineGenerator("dailyTemperatures", 30, 100, []string{FlavorRandom, FlavorSorted, FlavorAllEqual}, func(r *rand.Rand, opts GenerateOptions) TemperaturesInput {
+			return TemperaturesInput{Temperatures: opts.within(30, 100).ints(r, opts.n(1, 100000))}
+		}),
+		DefineGenerator("largestRectangleInHistogram", 0, 20, []string{FlavorRandom, FlavorSorted, FlavorAllEqual}, func(r *rand.Rand, opts GenerateOptions) HistogramInput {
+			return HistogramInput{Heights: opts.within(0, 10000).ints(r, opts.n(1, 100000))}
+		}),
+		DefineGenerator("rangeSumQueryMutable", -100, 100, []string{FlavorRandom, FlavorAllEqual}, func(r *rand.Rand, opts GenerateOptions) RangeSumInput {
+			in := randRangeSum(r, opts.within(-MaxRangeSumValue, MaxRangeSumValue), opts.n(1, 30000))
+			in.Structure = []string{StructureSegmentTree, StructureFenwick}[r.Intn(2)]
+			return in
+		}),
+		DefineGenerator("lruCache", 0, 20, []string{FlavorRandom, FlavorAllEqual}, genCache),
+		DefineGenerator("lfuCache", 0, 20, []string{FlavorRandom, FlavorAllEqual}, genCache),
+	)
+}
@@ -0,0 +1,153 @@
+package algorithm
+
+import (
+	"reflect"
+	"testing"
+
+	"mango/internal/algorithm/ds"
+)
+
+// TestHeapProblems 前 k 个高频元素和第 k 大元素，包含单个元素和全为负数
+func TestHeapProblems(t *testing.T) {
+	frequent := []struct {
+		nums []int
+		k    int
+		want []Frequency
+	}{
+		{[]int{1, 1, 1, 2, 2, 3}, 2, []Frequency{{1, 3}, {2, 2}}},
+		{[]int{1}, 1, []Frequency{{1, 1}}},
+		{[]int{-1, -1, -2, -3, -3}, 2, []Frequency{{-3, 2}, {-1, 2}}},
+		{[]int{4, 5, 6}, 3, []Frequency{{4, 1}, {5, 1}, {6, 1}}},
+	}
+	for _, test := range frequent {
+		if got := TopKFrequent(test.nums, test.k); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("TopKFrequent(%v, %d) = %v, want %v", test.nums, test.k, got, test.want)
+		}
+		if got := TopKFrequentSort(test.nums, test.k); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("TopKFrequentSort(%v, %d) = %v, want %v", test.nums, test.k, got, test.want)
+		}
+	}
+	kth := []struct {
+		nums    []int
+		k, want int
+	}{
+		{[]int{3, 2, 1, 5, 6, 4}, 2, 5},
+		{[]int{3, 2, 3, 1, 2, 4, 5, 5, 6}, 4, 4},
+		{[]int{-7}, 1, -7},
+		{[]int{-1, -5, -3}, 3, -5},
+	}
+	for _, test := range kth {
+		if got := FindKthLargest(test.nums, test.k); got != test.want {
+			t.Errorf("FindKthLargest(%v, %d) = %d, want %d", test.nums, test.k, got, test.want)
+		}
+	}
+}
+
+// TestMonotonicProblems 滑动窗口最大值、每日温度和柱状图中最大的矩形
+func TestMonotonicProblems(t *testing.T) {
+	windows := []struct {
+		nums   []int
+		k      int
+		maxima []ds.Indexed[int]
+	}{
+		{[]int{1, 3, -1, -3, 5, 3, 6, 7}, 3, []ds.Indexed[int]{{Index: 1, Value: 3}, {Index: 1, Value: 3}, {Index: 4, Value: 5}, {Index: 4, Value: 5}, {Index: 6, Value: 6}, {Index: 7, Value: 7}}},
+		{[]int{-2}, 1, []ds.Indexed[int]{{Index: 0, Value: -2}}},
+		{[]int{-5, -5, -9}, 2, []ds.Indexed[int]{{Index: 1, Value: -5}, {Index: 1, Value: -5}}},
+	}
+	for _, test := range windows {
+		if got := MaxSlidingWindow(test.nums, test.k); !reflect.DeepEqual(got, test.maxima) {
+			t.Errorf("MaxSlidingWindow(%v, %d) = %v, want %v", test.nums, test.k, got, test.maxima)
+		}
+	}
+
+	temperatures := []struct {
+		in, wait []int
+	}{
+		{[]int{73, 74, 75, 71, 69, 72, 76, 73}, []int{1, 1, 4, 2, 1, 1, 0, 0}},
+		{[]int{30}, []int{0}},
+		{[]int{-10, -20, -5}, []int{2, 1, 0}},
+		{[]int{}, []int{}},
+	}
+	for _, test := range temperatures {
+		if got := DailyTemperatures(test.in); !reflect.DeepEqual(got, test.wait) {
+			t.Errorf("DailyTemperatures(%v) = %v, want %v", test.in, got, test.wait)
+		}
+	}
+
+	rectangles := []struct {
+		heights      []int
+		area, height int
+		window       Window
+	}{
+		{[]int{2, 1, 5, 6, 2, 3}, 10, 5, Window{2, 3}},
+		{[]int{2, 4}, 4, 4, Window{1, 1}},
+		{[]int{3}, 3, 3, Window{0, 0}},
+		{[]int{0, 0}, 0, 0, Window{0, -1}},
+		{[]int{}, 0, 0, Window{0, -1}},
+	}
+	for _, test := range rectangles {
+		area, height, window := LargestRectangle(test.heights)
+		if area != test.area || height != test.height || window != test.window {
+			t.Errorf("LargestRectangle(%v) = %d %d %v, want %d %d %v", test.heights, area, height, window, test.area, test.height, test.window)
+		}
+	}
+}
+
+// TestRangeSumQueries 线段树和树状数组的区域和检索结果一致，包含负数和单个元素
+func TestRangeSumQueries(t *testing.T) {
+	tests := []struct {
+		nums       []int
+		operations []string
+		args       [][2]int
+		want       []*int
+	}{
+		{[]int{1, 3, 5}, []string{"sumRange", "update", "sumRange"}, [][2]int{{0, 2}, {1, 2}, {0, 2}}, []*int{intPtr(9), nil, intPtr(8)}},
+		{[]int{-4}, []string{"sumRange", "update", "sumRange"}, [][2]int{{0, 0}, {0, -9}, {0, 0}}, []*int{intPtr(-4), nil, intPtr(-9)}},
+		{[]int{-1, -2, -3, -4}, []string{"sumRange", "sumRange"}, [][2]int{{1, 2}, {0, 3}}, []*int{intPtr(-5), intPtr(-10)}},
+	}
+	for _, test := range tests {
+		for _, structure := range []string{StructureSegmentTree, StructureFenwick} {
+			if got := RangeSumQueries(test.nums, test.operations, test.args, structure); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("%s %v: got %v, want %v", structure, test.nums, derefAll(got), derefAll(test.want))
+			}
+		}
+	}
+}
+
+// TestRunCache leetcode 146 与 460 的示例
+func TestRunCache(t *testing.T) {
+	operations := []string{"put", "put", "get", "put", "get", "put", "get", "get", "get"}
+	args := [][]int{{1, 1}, {2, 2}, {1}, {3, 3}, {2}, {4, 4}, {1}, {3}, {4}}
+	tests := []struct {
+		policy  string
+		results []*int
+		evicted []*int
+	}{
+		{CacheLRU, []*int{nil, nil, intPtr(1), nil, intPtr(-1), nil, intPtr(-1), intPtr(3), intPtr(4)},
+			[]*int{nil, nil, nil, intPtr(2), nil, intPtr(1), nil, nil, nil}},
+		// 1 使用了两次，put 4 时淘汰只用过一次的 3
+		{CacheLFU, []*int{nil, nil, intPtr(1), nil, intPtr(-1), nil, intPtr(1), intPtr(-1), intPtr(4)},
+			[]*int{nil, nil, nil, intPtr(2), nil, intPtr(3), nil, nil, nil}},
+	}
+	for _, test := range tests {
+		got := RunCache(test.policy, 2, operations, args)
+		if !reflect.DeepEqual(got.Results, test.results) || !reflect.DeepEqual(got.Evicted, test.evicted) {
+			t.Errorf("%s: results %v evicted %v, want %v %v", test.policy, derefAll(got.Results), derefAll(got.Evicted), derefAll(test.results), derefAll(test.evicted))
+		}
+	}
+	single := RunCache(CacheLRU, 1, []string{"put", "put", "get"}, [][]int{{1, 1}, {2, 2}, {1}})
+	if *single.Evicted[1] != 1 || *single.Results[2] != -1 {
+		t.Errorf("capacity 1: %v %v", derefAll(single.Results), derefAll(single.Evicted))
+	}
+}
+
+// derefAll 便于输出，nil 记为 nil
+func derefAll(values []*int) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		if v != nil {
+			result[i] = *v
+		}
+	}
+	return result
+}
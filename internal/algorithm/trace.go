@@ -0,0 +1,66 @@
+package algorithm
+
+import "context"
+
+const (
+	// DefaultTraceLimit 默认最多记录的事件数
+	DefaultTraceLimit = 1000
+	// MaxTraceLimit 调用方可以设置的最大事件数
+	MaxTraceLimit = 10000
+)
+
+// TraceEvent 执行过程中的一帧状态，前端可以逐帧回放
+type TraceEvent struct {
+	Step  int                    `json:"step"`
+	Label string                 `json:"label"`
+	State map[string]interface{} `json:"state"`
+}
+
+// Trace 有上限的事件列表，nil 表示不记录
+type Trace struct {
+	Events    []TraceEvent `json:"events"`
+	Truncated bool         `json:"truncated"`
+	limit     int
+}
+
+// NewTrace 创建最多记录 limit 个事件的 Trace，limit 不合法时使用默认值
+func NewTrace(limit int) *Trace {
+	if limit <= 0 || limit > MaxTraceLimit {
+		limit = DefaultTraceLimit
+	}
+	return &Trace{Events: make([]TraceEvent, 0, min(limit, 64)), limit: limit}
+}
+
+// Enabled 是否还需要记录事件，算法在构造快照之前调用以避免无用的拷贝
+// 达到上限后标记 Truncated
+func (t *Trace) Enabled() bool {
+	if t == nil {
+		return false
+	}
+	if len(t.Events) >= t.limit {
+		t.Truncated = true
+		return false
+	}
+	return true
+}
+
+// Record 记录一帧，state 中的切片和 map 需要是快照，不能是之后会被修改的引用
+func (t *Trace) Record(label string, state map[string]interface{}) {
+	if !t.Enabled() {
+		return
+	}
+	t.Events = append(t.Events, TraceEvent{Step: len(t.Events), Label: label, State: state})
+}
+
+type traceKey struct{}
+
+// WithTrace 把 Trace 放进 context，支持 trace 的算法会从中取出并记录
+func WithTrace(ctx context.Context, t *Trace) context.Context {
+	return context.WithValue(ctx, traceKey{}, t)
+}
+
+// TraceFrom 取出 context 中的 Trace，没有时返回 nil
+func TraceFrom(ctx context.Context) *Trace {
+	t, _ := ctx.Value(traceKey{}).(*Trace)
+	return t
+}
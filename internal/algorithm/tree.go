@@ -0,0 +1,266 @@
+package algorithm
+
+import (
+	"math"
+	"slices"
+)
+
+// TreeNode 二叉树节点
+type TreeNode struct {
+	Val   int
+	Left  *TreeNode
+	Right *TreeNode
+}
+
+// Traversals 二叉树的四种遍历结果
+type Traversals struct {
+	Preorder   []int   `json:"preorder"`
+	Inorder    []int   `json:"inorder"`
+	Postorder  []int   `json:"postorder"`
+	LevelOrder [][]int `json:"levelOrder"`
+}
+
+/*
+TraverseTree leetcode 144 / 94 / 145 / 102 前序、中序、后序、层序遍历，都是迭代实现
+前序：栈顶出栈后先压右孩子再压左孩子
+中序：一路向左压栈，出栈访问后转向右子树
+后序：按 根 -> 右 -> 左 的顺序访问再反转
+层序：队列，每次处理一整层
+*/
+func TraverseTree(root *TreeNode) Traversals {
+	return Traversals{
+		Preorder:   preorder(root),
+		Inorder:    inorder(root, nil),
+		Postorder:  postorder(root),
+		LevelOrder: levelOrder(root),
+	}
+}
+
+func preorder(root *TreeNode) []int {
+	values := []int{}
+	stack := []*TreeNode{root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if node == nil {
+			continue
+		}
+		values = append(values, node.Val)
+		stack = append(stack, node.Right, node.Left)
+	}
+	return values
+}
+
+// inorder visit 返回 false 时提前结束
+func inorder(root *TreeNode, visit func(*TreeNode) bool) []int {
+	values := []int{}
+	var stack []*TreeNode
+	for node := root; node != nil || len(stack) > 0; node = node.Right {
+		for ; node != nil; node = node.Left {
+			stack = append(stack, node)
+		}
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		values = append(values, node.Val)
+		if visit != nil && !visit(node) {
+			break
+		}
+	}
+	return values
+}
+
+func postorder(root *TreeNode) []int {
+	values := []int{}
+	stack := []*TreeNode{root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if node == nil {
+			continue
+		}
+		values = append(values, node.Val)
+		stack = append(stack, node.Left, node.Right)
+	}
+	slices.Reverse(values)
+	return values
+}
+
+func levelOrder(root *TreeNode) [][]int {
+	levels := [][]int{}
+	if root == nil {
+		return levels
+	}
+	for queue := []*TreeNode{root}; len(queue) > 0; {
+		level := make([]int, len(queue))
+		var next []*TreeNode
+		for i, node := range queue {
+			level[i] = node.Val
+			for _, child := range []*TreeNode{node.Left, node.Right} {
+				if child != nil {
+					next = append(next, child)
+				}
+			}
+		}
+		levels, queue = append(levels, level), next
+	}
+	return levels
+}
+
+// TraverseTreeRecursive 递归实现的前序、中序、后序遍历，层序为空
+func TraverseTreeRecursive(root *TreeNode) Traversals {
+	result := Traversals{Preorder: []int{}, Inorder: []int{}, Postorder: []int{}}
+	var walk func(node *TreeNode)
+	walk = func(node *TreeNode) {
+		if node == nil {
+			return
+		}
+		result.Preorder = append(result.Preorder, node.Val)
+		walk(node.Left)
+		result.Inorder = append(result.Inorder, node.Val)
+		walk(node.Right)
+		result.Postorder = append(result.Postorder, node.Val)
+	}
+	walk(root)
+	return result
+}
+
+// MaxDepth leetcode 104 二叉树的最大深度，返回深度以及一条从根到最深叶子的路径
+func MaxDepth(root *TreeNode) (int, []int) {
+	if root == nil {
+		return 0, []int{}
+	}
+	left, leftPath := MaxDepth(root.Left)
+	right, rightPath := MaxDepth(root.Right)
+	if right > left {
+		left, leftPath = right, rightPath
+	}
+	return left + 1, append([]int{root.Val}, leftPath...)
+}
+
+// MaxDepthBFS 层序遍历的层数
+func MaxDepthBFS(root *TreeNode) int {
+	return len(levelOrder(root))
+}
+
+// InvertTree leetcode 226 翻转二叉树，原地交换每个节点的左右子树并返回根
+func InvertTree(root *TreeNode) *TreeNode {
+	if root != nil {
+		root.Left, root.Right = InvertTree(root.Right), InvertTree(root.Left)
+	}
+	return root
+}
+
+// InvertTreeBFS 按层翻转
+func InvertTreeBFS(root *TreeNode) *TreeNode {
+	for queue := []*TreeNode{root}; len(queue) > 0; queue = queue[1:] {
+		if node := queue[0]; node != nil {
+			node.Left, node.Right = node.Right, node.Left
+			queue = append(queue, node.Left, node.Right)
+		}
+	}
+	return root
+}
+
+/*
+ValidateBST leetcode 98 验证二叉搜索树，左子树都小于根、右子树都大于根（严格）
+递归时传入子树取值的开区间 (lower, upper)，返回第一个不在区间内的节点，合法时返回 nil
+*/
+func ValidateBST(root *TreeNode) *TreeNode {
+	return validateBST(root, math.MinInt, math.MaxInt, true, true)
+}
+
+// validateBST open 表示对应一侧还没有界，避免节点值恰好为 MinInt/MaxInt 时误判
+func validateBST(node *TreeNode, lower, upper int, openLower, openUpper bool) *TreeNode {
+	if node == nil {
+		return nil
+	}
+	if !openLower && node.Val <= lower || !openUpper && node.Val >= upper {
+		return node
+	}
+	if bad := validateBST(node.Left, lower, node.Val, openLower, false); bad != nil {
+		return bad
+	}
+	return validateBST(node.Right, node.Val, upper, false, openUpper)
+}
+
+// IsBSTInorder 中序遍历严格递增
+func IsBSTInorder(root *TreeNode) bool {
+	values := inorder(root, nil)
+	for i := 1; i < len(values); i++ {
+		if values[i] <= values[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+LowestCommonAncestor leetcode 236 二叉树的最近公共祖先
+后序递归：p、q 分别在左右子树中时当前节点就是答案，否则答案在找到它们的那一侧
+调用方需要保证 p、q 都在树中
+*/
+func LowestCommonAncestor(root, p, q *TreeNode) *TreeNode {
+	if root == nil || root == p || root == q {
+		return root
+	}
+	left := LowestCommonAncestor(root.Left, p, q)
+	right := LowestCommonAncestor(root.Right, p, q)
+	switch {
+	case left != nil && right != nil:
+		return root
+	case left != nil:
+		return left
+	}
+	return right
+}
+
+// rootPath 从根到 target 的路径，target 不在树中时返回 nil
+func rootPath(root, target *TreeNode) []*TreeNode {
+	if root == nil {
+		return nil
+	}
+	if root == target {
+		return []*TreeNode{root}
+	}
+	for _, child := range []*TreeNode{root.Left, root.Right} {
+		if path := rootPath(child, target); path != nil {
+			return append([]*TreeNode{root}, path...)
+		}
+	}
+	return nil
+}
+
+// LowestCommonAncestorPaths 分别求出根到 p、q 的路径，最后一个公共节点
+func LowestCommonAncestorPaths(root, p, q *TreeNode) *TreeNode {
+	pathP, pathQ := rootPath(root, p), rootPath(root, q)
+	var lca *TreeNode
+	for i := 0; i < len(pathP) && i < len(pathQ) && pathP[i] == pathQ[i]; i++ {
+		lca = pathP[i]
+	}
+	return lca
+}
+
+/*
+KthSmallest leetcode 230 二叉搜索树中第 k 小的元素，中序遍历到第 k 个节点时停止，O(h + k)
+k 超过节点数时返回 nil
+*/
+func KthSmallest(root *TreeNode, k int) *TreeNode {
+	return kthSmallest(root, k, nil)
+}
+
+func kthSmallest(root *TreeNode, k int, t *Trace) *TreeNode {
+	var kth *TreeNode
+	var count int
+	inorder(root, func(node *TreeNode) bool {
+		count++
+		if t.Enabled() {
+			t.Record("visit", map[string]interface{}{"value": node.Val, "rank": count})
+		}
+		if count == k {
+			kth = node
+			return false
+		}
+		return true
+	})
+	return kth
+}
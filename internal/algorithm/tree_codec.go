@@ -0,0 +1,161 @@
+package algorithm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+BuildTree 按 leetcode 层序格式构建二叉树，例如 [3,9,20,null,null,15,7]
+依次为队列中每个节点读取左、右孩子，null 表示空节点，空节点的孩子不再出现
+值比节点能容纳的孩子还多时返回错误
+*/
+func BuildTree(level []*int) (*TreeNode, error) {
+	if len(level) == 0 || level[0] == nil {
+		if len(level) > 1 {
+			return nil, errors.New("root is null but more values follow")
+		}
+		return nil, nil
+	}
+	root := &TreeNode{Val: *level[0]}
+	queue := []*TreeNode{root}
+	i := 1
+	for ; i < len(level) && len(queue) > 0; queue = queue[1:] {
+		for _, child := range []**TreeNode{&queue[0].Left, &queue[0].Right} {
+			if i < len(level) && level[i] != nil {
+				*child = &TreeNode{Val: *level[i]}
+				queue = append(queue, *child)
+			}
+			i++
+		}
+	}
+	if i < len(level) {
+		return nil, fmt.Errorf("value at index %d has no parent, all remaining slots are null", i)
+	}
+	return root, nil
+}
+
+// EncodeTree 按 leetcode 层序格式序列化，去掉末尾的 null
+func EncodeTree(root *TreeNode) []*int {
+	level := []*int{}
+	for queue := []*TreeNode{root}; len(queue) > 0; queue = queue[1:] {
+		node := queue[0]
+		if node == nil {
+			level = append(level, nil)
+			continue
+		}
+		level = append(level, &node.Val)
+		queue = append(queue, node.Left, node.Right)
+	}
+	for len(level) > 0 && level[len(level)-1] == nil {
+		level = level[:len(level)-1]
+	}
+	return level
+}
+
+// treeNull Serialize 中空节点的记号
+const treeNull = "#"
+
+/*
+Serialize leetcode 297 二叉树的序列化与反序列化
+前序遍历，空节点记为 #，逗号分隔，例如 1,2,#,#,3,#,#
+前序加上空节点的位置就能唯一确定一棵树
+*/
+func Serialize(root *TreeNode) string {
+	var tokens []string
+	stack := []*TreeNode{root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if node == nil {
+			tokens = append(tokens, treeNull)
+			continue
+		}
+		tokens = append(tokens, strconv.Itoa(node.Val))
+		stack = append(stack, node.Right, node.Left)
+	}
+	return strings.Join(tokens, ",")
+}
+
+// Deserialize Serialize 的逆过程，记号不足、多余或不是整数时返回错误
+func Deserialize(data string) (*TreeNode, error) {
+	tokens := strings.Split(data, ",")
+	var i int
+	var build func() (*TreeNode, error)
+	build = func() (*TreeNode, error) {
+		if i == len(tokens) {
+			return nil, errors.New("data ends before the tree is complete")
+		}
+		token := strings.TrimSpace(tokens[i])
+		i++
+		if token == treeNull {
+			return nil, nil
+		}
+		val, err := strconv.Atoi(token)
+		if err != nil {
+			return nil, fmt.Errorf("token %d %q is neither an integer nor %s", i-1, token, treeNull)
+		}
+		node := &TreeNode{Val: val}
+		if node.Left, err = build(); err != nil {
+			return nil, err
+		}
+		if node.Right, err = build(); err != nil {
+			return nil, err
+		}
+		return node, nil
+	}
+	root, err := build()
+	if err != nil {
+		return nil, err
+	}
+	if i != len(tokens) {
+		return nil, fmt.Errorf("%d extra tokens after the tree", len(tokens)-i)
+	}
+	return root, nil
+}
+
+// TreeInput 二叉树输入
+type TreeInput struct {
+	Root []*int `json:"root" binding:"max=20001" doc:"leetcode 层序格式，null 表示空节点，例如 [3,9,20,null,null,15,7]"`
+}
+
+// Validate 层序数组能构建出二叉树
+func (in *TreeInput) Validate() error {
+	_, err := BuildTree(in.Root)
+	return err
+}
+
+// Decode 构建二叉树，调用方需要先通过 Validate
+func (in *TreeInput) Decode() *TreeNode {
+	root, _ := BuildTree(in.Root)
+	return root
+}
+
+// treeNodes 按层序返回所有节点
+func treeNodes(root *TreeNode) []*TreeNode {
+	var nodes []*TreeNode
+	if root == nil {
+		return nodes
+	}
+	nodes = append(nodes, root)
+	for i := 0; i < len(nodes); i++ {
+		for _, child := range []*TreeNode{nodes[i].Left, nodes[i].Right} {
+			if child != nil {
+				nodes = append(nodes, child)
+			}
+		}
+	}
+	return nodes
+}
+
+// findValue 按值查找节点，值不唯一时返回层序中的第一个
+func findValue(root *TreeNode, val int) *TreeNode {
+	for _, node := range treeNodes(root) {
+		if node.Val == val {
+			return node
+		}
+	}
+	return nil
+}
@@ -0,0 +1,637 @@
+package algorithm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// LCAInput lowestCommonAncestor 输入，节点值互不相同，p、q 是两个节点的值
+type LCAInput struct {
+	TreeInput
+	P int `json:"p"`
+	Q int `json:"q"`
+}
+
+// Validate 节点值唯一且 p、q 都在树中
+func (in *LCAInput) Validate() error {
+	root, err := BuildTree(in.Root)
+	if err != nil {
+		return err
+	}
+	seen := make(map[int]bool)
+	for _, node := range treeNodes(root) {
+		if seen[node.Val] {
+			return fmt.Errorf("node value %d appears more than once", node.Val)
+		}
+		seen[node.Val] = true
+	}
+	if !seen[in.P] || !seen[in.Q] {
+		return fmt.Errorf("p %d and q %d must both be in the tree", in.P, in.Q)
+	}
+	return nil
+}
+
+// KthInput kthSmallestBST 输入
+type KthInput struct {
+	TreeInput
+	K int `json:"k" binding:"required,min=1" doc:"不超过节点数"`
+}
+
+// Validate 是二叉搜索树且 k 不超过节点数
+func (in *KthInput) Validate() error {
+	root, err := BuildTree(in.Root)
+	if err != nil {
+		return err
+	}
+	if bad := ValidateBST(root); bad != nil {
+		return fmt.Errorf("root is not a binary search tree, node %d is out of order", bad.Val)
+	}
+	if n := len(treeNodes(root)); in.K > n {
+		return fmt.Errorf("k %d exceeds the number of nodes %d", in.K, n)
+	}
+	return nil
+}
+
+// CodecInput serializeBinaryTree 输入，root（层序格式）与 data（前序格式）二选一
+type CodecInput struct {
+	Root []*int  `json:"root" binding:"max=20001" doc:"leetcode 层序格式，空树为 []"`
+	Data *string `json:"data,omitempty" doc:"前序格式，空节点为 #，例如 1,2,#,#,3,#,#"`
+}
+
+// Validate 恰好给出一种格式且能构建出二叉树
+func (in *CodecInput) Validate() error {
+	if (in.Root != nil) == (in.Data != nil) {
+		return errors.New("exactly one of root and data is required")
+	}
+	_, err := in.Decode()
+	return err
+}
+
+// Decode 按给出的格式构建二叉树
+func (in *CodecInput) Decode() (*TreeNode, error) {
+	if in.Data != nil {
+		return Deserialize(*in.Data)
+	}
+	return BuildTree(in.Root)
+}
+
+// TrieInput implementTrie 输入，operations[i] 作用于 words[i]
+type TrieInput struct {
+	Operations []string `json:"operations" binding:"required,min=1,max=10000,dive,oneof=insert search startsWith"`
+	Words      []string `json:"words" binding:"required,max=10000,dive,min=1,max=2000"`
+}
+
+// Validate 操作与单词一一对应
+func (in *TrieInput) Validate() error {
+	if len(in.Operations) != len(in.Words) {
+		return fmt.Errorf("%d operations but %d words", len(in.Operations), len(in.Words))
+	}
+	return nil
+}
+
+// MaxWordsBoard wordSearchII 网格的最大边长
+const MaxWordsBoard = 12
+
+// WordsSearchInput wordSearchII 输入
+type WordsSearchInput struct {
+	Board LetterBoard `json:"board" binding:"required" doc:"每个格子是一个字符"`
+	Words []string    `json:"words" binding:"required,min=1,max=30000,dive,min=1,max=10"`
+}
+
+// Validate 检查网格形状
+func (in *WordsSearchInput) Validate() error {
+	if len(in.Board) > MaxWordsBoard || len(in.Board) > 0 && len(in.Board[0]) > MaxWordsBoard {
+		return fmt.Errorf("board must be at most %dx%d", MaxWordsBoard, MaxWordsBoard)
+	}
+	return in.Board.Validate()
+}
+
+// DepthResult maxDepthBinaryTree 结果，path 是一条从根到最深叶子的路径上的节点值
+type DepthResult struct {
+	Depth int   `json:"depth"`
+	Path  []int `json:"path"`
+}
+
+// TreeResult 返回一棵树，leetcode 层序格式
+type TreeResult struct {
+	Root []*int `json:"root"`
+}
+
+// BSTResult validateBinarySearchTree 结果，violation 是第一个不满足取值范围的节点值
+type BSTResult struct {
+	Valid     bool `json:"valid"`
+	Violation *int `json:"violation,omitempty"`
+}
+
+// LCAResult lowestCommonAncestor 结果，pathP、pathQ 是根到 p、q 的路径
+type LCAResult struct {
+	Ancestor int   `json:"ancestor"`
+	PathP    []int `json:"pathP"`
+	PathQ    []int `json:"pathQ"`
+}
+
+// CodecResult serializeBinaryTree 结果，同一棵树的两种格式
+type CodecResult struct {
+	Root []*int `json:"root"`
+	Data string `json:"data"`
+}
+
+// KthResult kthSmallestBST、kthLargest 结果
+type KthResult struct {
+	Value int `json:"value"`
+}
+
+// TrieResult implementTrie 结果，insert 对应 null，nodes 是前缀树的节点数（不含根）
+type TrieResult struct {
+	Results []*bool `json:"results"`
+	Nodes   int     `json:"nodes"`
+}
+
+// WordSearchResult wordSearchII 结果，按单词排序
+type WordSearchResult struct {
+	Found []WordPath `json:"found"`
+}
+
+func pathValues(path []*TreeNode) []int {
+	values := make([]int, len(path))
+	for i, node := range path {
+		values[i] = node.Val
+	}
+	return values
+}
+
+// RunTrie 依次执行操作
+func RunTrie(operations, words []string) TrieResult {
+	trie := NewTrie()
+	result := TrieResult{Results: make([]*bool, len(operations))}
+	for i, op := range operations {
+		var answer bool
+		switch op {
+		case "insert":
+			result.Nodes += trie.Insert(words[i])
+			continue
+		case "search":
+			answer = trie.Search(words[i])
+		case "startsWith":
+			answer = trie.StartsWith(words[i])
+		}
+		result.Results[i] = &answer
+	}
+	return result
+}
+
+// hasRootPath 存在一条从根到叶子、节点值依次为 path 的路径
+func hasRootPath(node *TreeNode, path []int) bool {
+	if node == nil || len(path) == 0 || node.Val != path[0] {
+		return false
+	}
+	if len(path) == 1 {
+		return node.Left == nil && node.Right == nil
+	}
+	return hasRootPath(node.Left, path[1:]) || hasRootPath(node.Right, path[1:])
+}
+
+func checkDepth(in *TreeInput, expected, got DepthResult) error {
+	if got.Depth != expected.Depth {
+		return fmt.Errorf("depth = %d, want %d", got.Depth, expected.Depth)
+	}
+	if len(got.Path) != got.Depth || got.Depth > 0 && !hasRootPath(in.Decode(), got.Path) {
+		return fmt.Errorf("%v is not a root-to-leaf path of length %d", got.Path, got.Depth)
+	}
+	return nil
+}
+
+func foundWords(found []WordPath) []string {
+	words := make([]string, len(found))
+	for i, path := range found {
+		words[i] = path.Word
+	}
+	return words
+}
+
+func checkWordSearch(in *WordsSearchInput, expected, got WordSearchResult) error {
+	if want, words := foundWords(expected.Found), foundWords(got.Found); !slices.Equal(words, want) {
+		return fmt.Errorf("found %v, want %v", words, want)
+	}
+	for _, found := range got.Found {
+		if err := validWordPath(&WordSearchInput{Board: in.Board, Word: found.Word}, found.Cells); err != nil {
+			return fmt.Errorf("%s: %w", found.Word, err)
+		}
+	}
+	return nil
+}
+
+// randTree 随机形状的二叉树：每个新节点挂到一个随机的空位上，节点值依次取自 values
+func randTree(r *rand.Rand, values []int) *TreeNode {
+	if len(values) == 0 {
+		return nil
+	}
+	root := &TreeNode{Val: values[0]}
+	slots := []**TreeNode{&root.Left, &root.Right}
+	for _, val := range values[1:] {
+		i := r.Intn(len(slots))
+		node := &TreeNode{Val: val}
+		*slots[i] = node
+		slots[i] = slots[len(slots)-1]
+		slots = append(slots[:len(slots)-1], &node.Left, &node.Right)
+	}
+	return root
+}
+
+// bstFrom 按顺序插入构建二叉搜索树，values 互不相同
+func bstFrom(values []int) *TreeNode {
+	var root *TreeNode
+	for _, val := range values {
+		slot := &root
+		for *slot != nil {
+			if val < (*slot).Val {
+				slot = &(*slot).Left
+			} else {
+				slot = &(*slot).Right
+			}
+		}
+		*slot = &TreeNode{Val: val}
+	}
+	return root
+}
+
+// skewedTree 每个节点只有一个孩子，随机挂在左边或右边
+func skewedTree(r *rand.Rand, values []int) *TreeNode {
+	var root *TreeNode
+	slot := &root
+	for _, val := range values {
+		*slot = &TreeNode{Val: val}
+		if r.Intn(2) == 0 {
+			slot = &(*slot).Left
+		} else {
+			slot = &(*slot).Right
+		}
+	}
+	return root
+}
+
+// flavorsTree 二叉树题目通用的 flavor
+var flavorsTree = []string{FlavorRandom, FlavorEmpty, FlavorBST, FlavorSkewed}
+
+// genTree 按 flavor 生成 n 个节点的二叉树，bst 和 distinct 时节点值互不相同（范围不够大时节点数截断）
+func genTree(r *rand.Rand, opts GenerateOptions, n int, distinct bool) *TreeNode {
+	if opts.Flavor == FlavorEmpty {
+		return nil
+	}
+	var values []int
+	if distinct || opts.Flavor == FlavorBST {
+		values = distinctInts(r, opts, n)
+	} else {
+		values = opts.ints(r, n)
+	}
+	switch opts.Flavor {
+	case FlavorBST:
+		return bstFrom(values)
+	case FlavorSkewed:
+		return skewedTree(r, values)
+	}
+	return randTree(r, values)
+}
+
+// randLevel 小规模随机树的层序数组，用于对拍
+func randLevel(r *rand.Rand, size int) []*int {
+	flavor := flavorsTree[r.Intn(len(flavorsTree))]
+	return EncodeTree(genTree(r, GenerateOptions{Min: -size, Max: size, Flavor: flavor}, r.Intn(size+1), false))
+}
+
+// genLCA 节点值互不相同的树以及随机的两个节点
+func genLCA(r *rand.Rand, opts GenerateOptions, n int) LCAInput {
+	nodes := treeNodes(genTree(r, opts, n, true))
+	p, q := nodes[r.Intn(len(nodes))], nodes[r.Intn(len(nodes))]
+	return LCAInput{TreeInput: TreeInput{Root: EncodeTree(nodes[0])}, P: p.Val, Q: q.Val}
+}
+
+// genKth 二叉搜索树以及不超过节点数的 k，skewed 时按升序插入退化成链
+func genKth(r *rand.Rand, opts GenerateOptions, n int) KthInput {
+	values := distinctInts(r, opts, n)
+	if opts.Flavor == FlavorSkewed {
+		slices.Sort(values)
+	}
+	return KthInput{TreeInput: TreeInput{Root: EncodeTree(bstFrom(values))}, K: 1 + r.Intn(len(values))}
+}
+
+// genTrie 小字母表上的短单词，查询中一部分是插入过的单词或其前缀
+func genTrie(r *rand.Rand, n int, alphabet string) TrieInput {
+	operations, words := make([]string, n), make([]string, n)
+	var inserted []string
+	for i := range operations {
+		operations[i] = []string{"insert", "search", "startsWith"}[r.Intn(3)]
+		words[i] = randString(r, 1+r.Intn(4), alphabet)
+		if operations[i] != "insert" && len(inserted) > 0 && r.Intn(2) == 0 {
+			word := []rune(inserted[r.Intn(len(inserted))])
+			words[i] = string(word[:1+r.Intn(len(word))])
+		}
+		if operations[i] == "insert" {
+			inserted = append(inserted, words[i])
+		}
+	}
+	return TrieInput{Operations: operations, Words: words}
+}
+
+// genWordsSearch rows x cols 的网格，一半单词沿网格中的随机路径取出（一定能找到），其余随机
+func genWordsSearch(r *rand.Rand, rows, cols, count int, alphabet string) WordsSearchInput {
+	board := make(LetterBoard, rows)
+	for i := range board {
+		board[i] = strings.Split(randString(r, cols, alphabet), "")
+	}
+	words := make([]string, count)
+	for k := range words {
+		length := 1 + r.Intn(min(10, rows*cols))
+		if r.Intn(2) == 0 {
+			words[k] = randString(r, length, alphabet)
+			continue
+		}
+		i, j := r.Intn(rows), r.Intn(cols)
+		seen := map[[2]int]bool{{i, j}: true}
+		word := board[i][j]
+		for len([]rune(word)) < length {
+			var next [][2]int
+			for _, d := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+				cell := [2]int{i + d[0], j + d[1]}
+				if cell[0] >= 0 && cell[0] < rows && cell[1] >= 0 && cell[1] < cols && !seen[cell] {
+					next = append(next, cell)
+				}
+			}
+			if len(next) == 0 {
+				break
+			}
+			cell := next[r.Intn(len(next))]
+			i, j, seen[cell] = cell[0], cell[1], true
+			word += board[i][j]
+		}
+		words[k] = word
+	}
+	return WordsSearchInput{Board: board, Words: words}
+}
+
+func init() {
+	exampleTree := TreeInput{Root: []*int{intPtr(3), intPtr(9), intPtr(20), nil, nil, intPtr(15), intPtr(7)}}
+	exampleBST := TreeInput{Root: []*int{intPtr(5), intPtr(3), intPtr(6), intPtr(2), intPtr(4), nil, nil, intPtr(1)}}
+
+	Default.MustRegister(
+		Define(Spec{
+			Name: "binaryTreeTraversal", Title: "Binary Tree Inorder Traversal", LeetCode: 94,
+			Category: CategoryTree, Time: "O(n)", Space: "O(n)",
+		}, exampleTree,
+			func(ctx context.Context, in *TreeInput) (Traversals, error) {
+				return TraverseTree(in.Decode()), nil
+			}),
+		Define(Spec{
+			Name: "maxDepthBinaryTree", Title: "Maximum Depth of Binary Tree", LeetCode: 104,
+			Category: CategoryTree, Time: "O(n)", Space: "O(h)",
+		}, exampleTree,
+			func(ctx context.Context, in *TreeInput) (DepthResult, error) {
+				depth, path := MaxDepth(in.Decode())
+				return DepthResult{Depth: depth, Path: path}, nil
+			}),
+		Define(Spec{
+			Name: "invertBinaryTree", Title: "Invert Binary Tree", LeetCode: 226,
+			Category: CategoryTree, Time: "O(n)", Space: "O(h)",
+		}, exampleTree,
+			func(ctx context.Context, in *TreeInput) (TreeResult, error) {
+				return TreeResult{Root: EncodeTree(InvertTree(in.Decode()))}, nil
+			}),
+		Define(Spec{
+			Name: "validateBinarySearchTree", Title: "Validate Binary Search Tree", LeetCode: 98,
+			Category: CategoryTree, Time: "O(n)", Space: "O(h)",
+		}, exampleBST,
+			func(ctx context.Context, in *TreeInput) (BSTResult, error) {
+				if bad := ValidateBST(in.Decode()); bad != nil {
+					return BSTResult{Violation: &bad.Val}, nil
+				}
+				return BSTResult{Valid: true}, nil
+			}),
+		Define(Spec{
+			Name: "lowestCommonAncestor", Title: "Lowest Common Ancestor of a Binary Tree", LeetCode: 236,
+			Category: CategoryTree, Time: "O(n)", Space: "O(h)",
+		}, LCAInput{TreeInput: exampleTree, P: 15, Q: 7},
+			func(ctx context.Context, in *LCAInput) (LCAResult, error) {
+				root := in.Decode()
+				p, q := findValue(root, in.P), findValue(root, in.Q)
+				return LCAResult{
+					Ancestor: LowestCommonAncestor(root, p, q).Val,
+					PathP:    pathValues(rootPath(root, p)),
+					PathQ:    pathValues(rootPath(root, q)),
+				}, nil
+			}),
+		Define(Spec{
+			Name: "serializeBinaryTree", Title: "Serialize and Deserialize Binary Tree", LeetCode: 297,
+			Category: CategoryTree, Time: "O(n)", Space: "O(n)",
+		}, CodecInput{Root: exampleTree.Root},
+			func(ctx context.Context, in *CodecInput) (CodecResult, error) {
+				root, err := in.Decode()
+				if err != nil {
+					return CodecResult{}, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+				}
+				return CodecResult{Root: EncodeTree(root), Data: Serialize(root)}, nil
+			}),
+		Define(Spec{
+			Name: "kthSmallestBST", Title: "Kth Smallest Element in a BST", LeetCode: 230,
+			Category: CategoryTree, Time: "O(h + k)", Space: "O(h)", Traceable: true,
+		}, KthInput{TreeInput: exampleBST, K: 3},
+			func(ctx context.Context, in *KthInput) (KthResult, error) {
+				return KthResult{Value: kthSmallest(in.Decode(), in.K, TraceFrom(ctx)).Val}, nil
+			}),
+		Define(Spec{
+			Name: "implementTrie", Title: "Implement Trie (Prefix Tree)", LeetCode: 208,
+			Category: CategoryTrie, Time: "O(length) per operation", Space: "O(total length)",
+		}, TrieInput{
+			Operations: []string{"insert", "search", "search", "startsWith", "insert", "search"},
+			Words:      []string{"apple", "apple", "app", "app", "app", "app"},
+		},
+			func(ctx context.Context, in *TrieInput) (TrieResult, error) {
+				return RunTrie(in.Operations, in.Words), nil
+			}),
+		Define(Spec{
+			Name: "wordSearchII", Title: "Word Search II", LeetCode: 212,
+			Category: CategoryTrie, Time: "O(m * n * 4^L)", Space: "O(total length)",
+		}, WordsSearchInput{
+			Board: LetterBoard{{"o", "a", "a", "n"}, {"e", "t", "a", "e"}, {"i", "h", "k", "r"}, {"i", "f", "l", "v"}},
+			Words: []string{"oath", "pea", "eat", "rain"},
+		},
+			func(ctx context.Context, in *WordsSearchInput) (WordSearchResult, error) {
+				return WordSearchResult{Found: findWords(in.Board.grid(), in.Words, MeterFrom(ctx))}, nil
+			}),
+	)
+
+	Default.MustRegisterPair(
+		DefinePair("binaryTreeTraversal", "binaryTreeTraversal 迭代 vs 递归",
+			randLevel,
+			func(level []*int) Traversals {
+				root, _ := BuildTree(level)
+				return TraverseTreeRecursive(root)
+			},
+			func(level []*int) Traversals {
+				root, _ := BuildTree(level)
+				traversals := TraverseTree(root)
+				traversals.LevelOrder = nil
+				return traversals
+			},
+			nil, nil),
+		DefinePair("maxDepthBinaryTree", "maxDepthBinaryTree 递归（并检查路径） vs 层数",
+			randLevel,
+			func(level []*int) int {
+				root, _ := BuildTree(level)
+				return MaxDepthBFS(root)
+			},
+			func(level []*int) int {
+				in := TreeInput{Root: level}
+				depth, path := MaxDepth(in.Decode())
+				if checkDepth(&in, DepthResult{Depth: depth}, DepthResult{Depth: depth, Path: path}) != nil {
+					return -1
+				}
+				return depth
+			},
+			nil, nil),
+		DefinePair("invertBinaryTree", "invertBinaryTree 递归 vs 按层",
+			randLevel,
+			func(level []*int) []*int {
+				root, _ := BuildTree(level)
+				return EncodeTree(InvertTreeBFS(root))
+			},
+			func(level []*int) []*int {
+				root, _ := BuildTree(level)
+				return EncodeTree(InvertTree(root))
+			},
+			nil, nil),
+		DefinePair("validateBinarySearchTree", "validateBinarySearchTree 取值范围 vs 中序递增",
+			func(r *rand.Rand, size int) []*int {
+				root := bstFrom(r.Perm(size))
+				// 一半概率改掉一个节点的值，可能破坏也可能不破坏 BST
+				if nodes := treeNodes(root); len(nodes) > 0 && r.Intn(2) == 0 {
+					nodes[r.Intn(len(nodes))].Val = r.Intn(size+2) - 1
+				}
+				return EncodeTree(root)
+			},
+			func(level []*int) bool {
+				root, _ := BuildTree(level)
+				return IsBSTInorder(root)
+			},
+			func(level []*int) bool {
+				root, _ := BuildTree(level)
+				return ValidateBST(root) == nil
+			},
+			nil, nil),
+		DefinePair("lowestCommonAncestor", "lowestCommonAncestor 后序递归 vs 比较根路径",
+			func(r *rand.Rand, size int) LCAInput {
+				flavor := []string{FlavorRandom, FlavorBST, FlavorSkewed}[r.Intn(3)]
+				return genLCA(r, GenerateOptions{Min: 0, Max: 2 * size, Flavor: flavor}, 1+r.Intn(size))
+			},
+			func(in LCAInput) int {
+				root := in.Decode()
+				return LowestCommonAncestorPaths(root, findValue(root, in.P), findValue(root, in.Q)).Val
+			},
+			func(in LCAInput) int {
+				root := in.Decode()
+				return LowestCommonAncestor(root, findValue(root, in.P), findValue(root, in.Q)).Val
+			},
+			nil, nil),
+		DefinePair("serializeBinaryTree", "serializeBinaryTree 前序序列化再反序列化 vs 原树",
+			randLevel,
+			func(level []*int) []*int { return level },
+			func(level []*int) []*int {
+				root, _ := BuildTree(level)
+				decoded, err := Deserialize(Serialize(root))
+				if err != nil {
+					return nil
+				}
+				return EncodeTree(decoded)
+			},
+			nil, nil),
+		DefinePair("kthSmallestBST", "kthSmallestBST 中序提前结束 vs 排序",
+			func(r *rand.Rand, size int) KthInput {
+				flavor := []string{FlavorBST, FlavorSkewed}[r.Intn(2)]
+				return genKth(r, GenerateOptions{Min: -size, Max: size, Flavor: flavor}, 1+r.Intn(size))
+			},
+			func(in KthInput) int {
+				values := pathValues(treeNodes(in.Decode()))
+				slices.Sort(values)
+				return values[in.K-1]
+			},
+			func(in KthInput) int { return KthSmallest(in.Decode(), in.K).Val },
+			nil, nil),
+		DefinePair("implementTrie", "implementTrie 前缀树 vs 哈希集合",
+			func(r *rand.Rand, size int) TrieInput {
+				return genTrie(r, size, []string{"ab", "abc", "前缀ab"}[r.Intn(3)])
+			},
+			func(in TrieInput) []*bool {
+				words := make(map[string]bool)
+				prefixes := map[string]bool{}
+				results := make([]*bool, len(in.Operations))
+				for i, op := range in.Operations {
+					word := in.Words[i]
+					var answer bool
+					switch op {
+					case "insert":
+						words[word] = true
+						runes := []rune(word)
+						for k := 0; k <= len(runes); k++ {
+							prefixes[string(runes[:k])] = true
+						}
+						continue
+					case "search":
+						answer = words[word]
+					case "startsWith":
+						answer = prefixes[word]
+					}
+					results[i] = &answer
+				}
+				return results
+			},
+			func(in TrieInput) []*bool { return RunTrie(in.Operations, in.Words).Results },
+			nil, nil),
+		DefinePair("wordSearchII", "wordSearchII 前缀树 DFS（并检查路径） vs 逐个单词 DFS",
+			func(r *rand.Rand, size int) WordsSearchInput {
+				return genWordsSearch(r, 1+r.Intn(4), 1+r.Intn(4), 1+r.Intn(size), []string{"ab", "abc"}[r.Intn(2)])
+			},
+			func(in WordsSearchInput) []string { return FindWordsBruteForce(in.Board.grid(), in.Words) },
+			func(in WordsSearchInput) []string {
+				found := FindWords(in.Board.grid(), in.Words)
+				if checkWordSearch(&in, WordSearchResult{Found: found}, WordSearchResult{Found: found}) != nil {
+					return nil
+				}
+				return foundWords(found)
+			},
+			nil, nil),
+	)
+
+	Default.MustRegisterChecker(
+		DefineChecker("maxDepthBinaryTree", checkDepth),
+		DefineChecker("wordSearchII", checkWordSearch),
+	)
+
+	genTreeInput := func(r *rand.Rand, opts GenerateOptions) TreeInput {
+		return TreeInput{Root: EncodeTree(genTree(r, opts, opts.n(0, 10000), false))}
+	}
+	Default.MustRegisterGenerator(
+		DefineGenerator("binaryTreeTraversal", -100, 100, flavorsTree, genTreeInput),
+		DefineGenerator("maxDepthBinaryTree", -100, 100, flavorsTree, genTreeInput),
+		DefineGenerator("invertBinaryTree", -100, 100, flavorsTree, genTreeInput),
+		DefineGenerator("validateBinarySearchTree", -10000, 10000, []string{FlavorBST, FlavorRandom, FlavorEmpty, FlavorSkewed}, genTreeInput),
+		DefineGenerator("lowestCommonAncestor", -10000, 10000, []string{FlavorRandom, FlavorBST, FlavorSkewed}, func(r *rand.Rand, opts GenerateOptions) LCAInput {
+			return genLCA(r, opts, opts.n(1, 10000))
+		}),
+		DefineGenerator("serializeBinaryTree", -100, 100, flavorsTree, func(r *rand.Rand, opts GenerateOptions) CodecInput {
+			return CodecInput{Root: EncodeTree(genTree(r, opts, opts.n(0, 10000), false))}
+		}),
+		DefineGenerator("kthSmallestBST", -10000, 10000, []string{FlavorBST, FlavorSkewed}, func(r *rand.Rand, opts GenerateOptions) KthInput {
+			return genKth(r, opts, opts.n(1, 10000))
+		}),
+		DefineGenerator("implementTrie", 0, 0, []string{FlavorRandom, FlavorUnicode}, func(r *rand.Rand, opts GenerateOptions) TrieInput {
+			return genTrie(r, opts.n(1, 10000), alphabetFor(opts.Flavor, "abcd"))
+		}),
+		DefineGenerator("wordSearchII", 0, 0, []string{FlavorRandom, FlavorAllEqual, FlavorUnicode}, func(r *rand.Rand, opts GenerateOptions) WordsSearchInput {
+			side := opts.n(1, MaxWordsBoard)
+			return genWordsSearch(r, side, side, opts.n(1, 30000), alphabetFor(opts.Flavor, "abcde"))
+		}),
+	)
+}
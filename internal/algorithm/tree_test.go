@@ -0,0 +1,191 @@
+package algorithm
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// treeOf 按 leetcode 层序格式的 JSON 构建二叉树
+func treeOf(t *testing.T, level string) *TreeNode {
+	t.Helper()
+	var values []*int
+	if err := json.Unmarshal([]byte(level), &values); err != nil {
+		t.Fatal(err)
+	}
+	root, err := BuildTree(values)
+	if err != nil {
+		t.Fatalf("BuildTree(%s): %v", level, err)
+	}
+	return root
+}
+
+// TestTreeCodec 层序格式和前序序列化都能往返，非法输入返回错误
+func TestTreeCodec(t *testing.T) {
+	for _, level := range []string{`[3,9,20,null,null,15,7]`, `[]`, `[1]`, `[-1,null,-2,null,-3]`, `[-9223372036854775808,9223372036854775807]`} {
+		root := treeOf(t, level)
+		encoded, _ := json.Marshal(EncodeTree(root))
+		if string(encoded) != level {
+			t.Errorf("EncodeTree(BuildTree(%s)) = %s", level, encoded)
+		}
+		decoded, err := Deserialize(Serialize(root))
+		if err != nil || !reflect.DeepEqual(decoded, root) {
+			t.Errorf("%s: Deserialize(%q) = %v, %v", level, Serialize(root), decoded, err)
+		}
+	}
+	if got := Serialize(treeOf(t, `[1,2,3]`)); got != "1,2,#,#,3,#,#" {
+		t.Errorf("Serialize = %q", got)
+	}
+	if _, err := BuildTree([]*int{nil, new(int)}); err == nil {
+		t.Error("BuildTree with null root and children: expected error")
+	}
+	one := 1
+	if _, err := BuildTree([]*int{&one, nil, nil, &one}); err == nil {
+		t.Error("BuildTree with orphan value: expected error")
+	}
+	for _, data := range []string{"1,#", "1,#,#,#", "x,#,#", ""} {
+		if _, err := Deserialize(data); err == nil {
+			t.Errorf("Deserialize(%q): expected error", data)
+		}
+	}
+}
+
+// TestTraverseTree 迭代和递归遍历的已知结果，空树的各遍历都为空
+func TestTraverseTree(t *testing.T) {
+	tests := []struct {
+		level string
+		want  Traversals
+	}{
+		{`[1,null,2,3]`, Traversals{[]int{1, 2, 3}, []int{1, 3, 2}, []int{3, 2, 1}, [][]int{{1}, {2}, {3}}}},
+		{`[3,9,20,null,null,15,7]`, Traversals{[]int{3, 9, 20, 15, 7}, []int{9, 3, 15, 20, 7}, []int{9, 15, 7, 20, 3}, [][]int{{3}, {9, 20}, {15, 7}}}},
+		{`[-5]`, Traversals{[]int{-5}, []int{-5}, []int{-5}, [][]int{{-5}}}},
+		{`[]`, Traversals{[]int{}, []int{}, []int{}, [][]int{}}},
+	}
+	for _, test := range tests {
+		root := treeOf(t, test.level)
+		if got := TraverseTree(root); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("TraverseTree(%s) = %+v, want %+v", test.level, got, test.want)
+		}
+		recursive := TraverseTreeRecursive(root)
+		if len(test.want.Preorder) > 0 && (!reflect.DeepEqual(recursive.Preorder, test.want.Preorder) ||
+			!reflect.DeepEqual(recursive.Inorder, test.want.Inorder) || !reflect.DeepEqual(recursive.Postorder, test.want.Postorder)) {
+			t.Errorf("TraverseTreeRecursive(%s) = %+v, want %+v", test.level, recursive, test.want)
+		}
+	}
+}
+
+// TestTreeOperations 深度、翻转、BST 校验、最近公共祖先和第 k 小的已知答案
+func TestTreeOperations(t *testing.T) {
+	depths := []struct {
+		level string
+		depth int
+		path  []int
+	}{
+		{`[3,9,20,null,null,15,7]`, 3, []int{3, 20, 15}},
+		{`[1,null,2]`, 2, []int{1, 2}},
+		{`[]`, 0, []int{}},
+	}
+	for _, test := range depths {
+		root := treeOf(t, test.level)
+		depth, path := MaxDepth(root)
+		if depth != test.depth || !reflect.DeepEqual(path, test.path) || MaxDepthBFS(root) != test.depth {
+			t.Errorf("MaxDepth(%s) = %d %v, want %d %v", test.level, depth, path, test.depth, test.path)
+		}
+	}
+
+	inverted, _ := json.Marshal(EncodeTree(InvertTree(treeOf(t, `[4,2,7,1,3,6,9]`))))
+	if string(inverted) != `[4,7,2,9,6,3,1]` {
+		t.Errorf("InvertTree = %s", inverted)
+	}
+	if InvertTree(nil) != nil || InvertTreeBFS(nil) != nil {
+		t.Error("InvertTree(nil) != nil")
+	}
+
+	bsts := []struct {
+		level string
+		bad   *int
+	}{
+		{`[2,1,3]`, nil},
+		{`[5,1,4,null,null,3,6]`, intPtr(4)},
+		{`[5,4,6,null,null,3,7]`, intPtr(3)},
+		{`[1,1]`, intPtr(1)},
+		{`[-9223372036854775808,null,9223372036854775807]`, nil},
+		{`[-1,-2,0]`, nil},
+		{`[]`, nil},
+	}
+	for _, test := range bsts {
+		root := treeOf(t, test.level)
+		bad := ValidateBST(root)
+		if (bad == nil) != (test.bad == nil) || bad != nil && bad.Val != *test.bad || IsBSTInorder(root) != (test.bad == nil) {
+			t.Errorf("ValidateBST(%s) = %v, want %v", test.level, bad, test.bad)
+		}
+	}
+
+	root := treeOf(t, `[3,5,1,6,2,0,8,null,null,7,4]`)
+	lcas := []struct{ p, q, lca int }{{5, 1, 3}, {5, 4, 5}, {7, 8, 3}, {6, 6, 6}}
+	for _, test := range lcas {
+		p, q := findValue(root, test.p), findValue(root, test.q)
+		if got := LowestCommonAncestor(root, p, q); got.Val != test.lca {
+			t.Errorf("LowestCommonAncestor(%d, %d) = %d, want %d", test.p, test.q, got.Val, test.lca)
+		}
+		if got := LowestCommonAncestorPaths(root, p, q); got.Val != test.lca {
+			t.Errorf("LowestCommonAncestorPaths(%d, %d) = %d, want %d", test.p, test.q, got.Val, test.lca)
+		}
+	}
+
+	bst := treeOf(t, `[5,3,6,2,4,null,null,1]`)
+	for k, want := range []int{1, 2, 3, 4, 5, 6} {
+		if got := KthSmallest(bst, k+1); got == nil || got.Val != want {
+			t.Errorf("KthSmallest(%d) = %v, want %d", k+1, got, want)
+		}
+	}
+	if got := KthSmallest(bst, 7); got != nil {
+		t.Errorf("KthSmallest beyond size = %v, want nil", got)
+	}
+	if got := KthSmallest(treeOf(t, `[-3,-4,-1]`), 1); got == nil || got.Val != -4 {
+		t.Errorf("KthSmallest all negative = %v, want -4", got)
+	}
+}
+
+// TestTrie 插入、查找、前缀，以及网格中找单词
+func TestTrie(t *testing.T) {
+	trie := NewTrie()
+	if created := trie.Insert("apple"); created != 5 {
+		t.Errorf("Insert(apple) created %d nodes, want 5", created)
+	}
+	if created := trie.Insert("app"); created != 0 {
+		t.Errorf("Insert(app) created %d nodes, want 0", created)
+	}
+	checks := []struct {
+		got, want bool
+		what      string
+	}{
+		{trie.Search("apple"), true, "search apple"},
+		{trie.Search("ap"), false, "search ap"},
+		{trie.StartsWith("ap"), true, "prefix ap"},
+		{trie.StartsWith("b"), false, "prefix b"},
+		{trie.StartsWith(""), true, "empty prefix"},
+		{NewTrie().Search(""), false, "empty trie"},
+	}
+	for _, check := range checks {
+		if check.got != check.want {
+			t.Errorf("%s = %v, want %v", check.what, check.got, check.want)
+		}
+	}
+
+	board := [][]rune{[]rune("oaan"), []rune("etae"), []rune("ihkr"), []rune("iflv")}
+	words := []string{"oath", "pea", "eat", "rain"}
+	var found []string
+	for _, path := range FindWords(board, words) {
+		found = append(found, path.Word)
+		if len(path.Cells) != len([]rune(path.Word)) {
+			t.Errorf("%s: path %v", path.Word, path.Cells)
+		}
+	}
+	if want := []string{"eat", "oath"}; !reflect.DeepEqual(found, want) || !reflect.DeepEqual(FindWordsBruteForce(board, words), want) {
+		t.Errorf("FindWords = %v, want %v", found, want)
+	}
+	if got := FindWords([][]rune{[]rune("a")}, []string{"a", "aa"}); len(got) != 1 || got[0].Word != "a" {
+		t.Errorf("FindWords single cell = %v", got)
+	}
+}
@@ -0,0 +1,145 @@
+package algorithm
+
+import (
+	"slices"
+	"strings"
+)
+
+// Trie 前缀树，按 rune 分支，end 表示有单词在此结束，word 是这个单词
+type Trie struct {
+	children map[rune]*Trie
+	word     string
+	end      bool
+}
+
+// NewTrie 空前缀树
+func NewTrie() *Trie {
+	return &Trie{children: make(map[rune]*Trie)}
+}
+
+/*
+Insert leetcode 208 实现 Trie（前缀树）
+沿单词逐字符向下走，没有对应孩子时新建，最后一个节点标记单词结束，返回新建的节点数
+*/
+func (t *Trie) Insert(word string) int {
+	var created int
+	node := t
+	for _, r := range word {
+		child, ok := node.children[r]
+		if !ok {
+			child = NewTrie()
+			node.children[r] = child
+			created++
+		}
+		node = child
+	}
+	node.end, node.word = true, word
+	return created
+}
+
+// Search 单词是否插入过
+func (t *Trie) Search(word string) bool {
+	node := t.find(word)
+	return node != nil && node.end
+}
+
+// StartsWith 是否有插入过的单词以 prefix 开头
+func (t *Trie) StartsWith(prefix string) bool {
+	return t.find(prefix) != nil
+}
+
+func (t *Trie) find(prefix string) *Trie {
+	node := t
+	for _, r := range prefix {
+		if node = node.children[r]; node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// WordPath 在网格中找到的单词，cells 是依次经过的格子 [行, 列]
+type WordPath struct {
+	Word  string   `json:"word"`
+	Cells [][2]int `json:"cells"`
+}
+
+/*
+FindWords leetcode 212 单词搜索 II
+所有单词建成前缀树，从每个格子出发 DFS，前缀树同步向下走，当前路径不是任何单词的前缀时剪枝
+走过的格子临时置为 0 避免重复使用，找到的单词取消结束标记避免重复输出
+返回按单词排序的结果，每个单词给出一条路径
+*/
+func FindWords(board [][]rune, words []string) []WordPath {
+	return findWords(board, words, nil)
+}
+
+func findWords(board [][]rune, words []string, m *Meter) []WordPath {
+	trie := NewTrie()
+	for _, word := range words {
+		m.Alloc(int64(trie.Insert(word)) * 64)
+	}
+	found := []WordPath{}
+	var path [][2]int
+	var dfs func(node *Trie, i, j int)
+	dfs = func(node *Trie, i, j int) {
+		if i < 0 || i >= len(board) || j < 0 || j >= len(board[i]) || board[i][j] == 0 {
+			return
+		}
+		m.Step(1)
+		child := node.children[board[i][j]]
+		if child == nil {
+			return
+		}
+		r := board[i][j]
+		board[i][j] = 0
+		path = append(path, [2]int{i, j})
+		if child.end {
+			found = append(found, WordPath{Word: child.word, Cells: slices.Clone(path)})
+			child.end = false
+		}
+		dfs(child, i-1, j)
+		dfs(child, i+1, j)
+		dfs(child, i, j-1)
+		dfs(child, i, j+1)
+		path = path[:len(path)-1]
+		board[i][j] = r
+	}
+	for i := range board {
+		for j := range board[i] {
+			dfs(trie, i, j)
+		}
+	}
+	slices.SortFunc(found, func(a, b WordPath) int { return strings.Compare(a.Word, b.Word) })
+	return found
+}
+
+// FindWordsBruteForce 每个单词单独在网格中 DFS（leetcode 79），返回排序去重后的单词
+func FindWordsBruteForce(board [][]rune, words []string) []string {
+	var exist func(word []rune, i, j int) bool
+	exist = func(word []rune, i, j int) bool {
+		if len(word) == 0 {
+			return true
+		}
+		if i < 0 || i >= len(board) || j < 0 || j >= len(board[i]) || board[i][j] != word[0] {
+			return false
+		}
+		board[i][j] = 0
+		defer func() { board[i][j] = word[0] }()
+		return exist(word[1:], i-1, j) || exist(word[1:], i+1, j) || exist(word[1:], i, j-1) || exist(word[1:], i, j+1)
+	}
+	found := []string{}
+	for _, word := range words {
+		runes := []rune(word)
+		for i := 0; i < len(board) && !slices.Contains(found, word); i++ {
+			for j := range board[i] {
+				if exist(runes, i, j) {
+					found = append(found, word)
+					break
+				}
+			}
+		}
+	}
+	slices.Sort(found)
+	return found
+}
@@ -0,0 +1,520 @@
+package algorithm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultVerifyCases 每对实现默认比较的随机用例数
+	DefaultVerifyCases = 200
+	// DefaultVerifyMaxSize 默认的随机输入最大规模
+	DefaultVerifyMaxSize = 30
+	// maxShrinkSteps 最小化失败用例的最大轮数
+	maxShrinkSteps = 200
+)
+
+// Pair 一对应当给出相同答案的实现，例如暴力解和优化解
+type Pair struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	generate func(r *rand.Rand, size int) interface{}
+	check    func(input interface{}) (reference, candidate interface{}, ok bool)
+	shrink   func(input interface{}) []interface{}
+}
+
+// DefinePair 创建一对实现
+// equal 为 nil 时使用 reflect.DeepEqual；shrink 返回比 input 更小的候选输入，可以为 nil
+func DefinePair[In any, Out any](name, description string,
+	generate func(r *rand.Rand, size int) In,
+	reference, candidate func(In) Out,
+	equal func(in In, reference, candidate Out) bool,
+	shrink func(In) []In,
+) *Pair {
+	if equal == nil {
+		equal = func(_ In, a, b Out) bool { return reflect.DeepEqual(a, b) }
+	}
+	pair := &Pair{
+		Name:        name,
+		Description: description,
+		generate:    func(r *rand.Rand, size int) interface{} { return generate(r, size) },
+		check: func(input interface{}) (interface{}, interface{}, bool) {
+			in := input.(In)
+			a, b := reference(in), candidate(in)
+			return a, b, equal(in, a, b)
+		},
+	}
+	if shrink != nil {
+		pair.shrink = func(input interface{}) []interface{} {
+			smaller := shrink(input.(In))
+			result := make([]interface{}, len(smaller))
+			for i, s := range smaller {
+				result[i] = s
+			}
+			return result
+		}
+	}
+	return pair
+}
+
+// VerifyInput verify 接口参数，Pair 为空时验证全部
+type VerifyInput struct {
+	Pair    string `json:"pair" doc:"实现对名称，为空时验证全部"`
+	Seed    int64  `json:"seed" doc:"随机种子，为 0 时使用当前时间"`
+	Cases   int    `json:"cases" binding:"min=0,max=2000"`
+	MaxSize int    `json:"maxSize" binding:"min=0,max=200"`
+}
+
+// Disagreement 两个实现给出不同结果的输入
+type Disagreement struct {
+	Input     interface{} `json:"input"`
+	Reference interface{} `json:"reference"`
+	Candidate interface{} `json:"candidate"`
+}
+
+// VerifyReport 一对实现的验证结果
+type VerifyReport struct {
+	Pair   string `json:"pair"`
+	Seed   int64  `json:"seed"`
+	Cases  int    `json:"cases"`
+	Passed bool   `json:"passed"`
+	// Original 第一次发现的不一致，Minimized 是缩小之后的同类不一致
+	Original  *Disagreement `json:"original,omitempty"`
+	Minimized *Disagreement `json:"minimized,omitempty"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// Verify 生成随机输入比较两个实现，发现不一致时停止并尝试缩小输入
+func Verify(ctx context.Context, pair *Pair, in VerifyInput) VerifyReport {
+	if in.Seed == 0 {
+		in.Seed = time.Now().UnixNano()
+	}
+	if in.Cases <= 0 {
+		in.Cases = DefaultVerifyCases
+	}
+	if in.MaxSize <= 0 {
+		in.MaxSize = DefaultVerifyMaxSize
+	}
+	report := VerifyReport{Pair: pair.Name, Seed: in.Seed, Passed: true}
+	r := rand.New(rand.NewSource(in.Seed))
+	for i := 0; i < in.Cases; i++ {
+		if err := ctx.Err(); err != nil {
+			report.Error = err.Error()
+			return report
+		}
+		// 规模从小到大递增，先暴露小输入上的问题
+		size := i*in.MaxSize/in.Cases + 1
+		input := pair.generate(r, size)
+		report.Cases++
+		reference, candidate, ok := pair.check(input)
+		if ok {
+			continue
+		}
+		report.Passed = false
+		report.Original = &Disagreement{Input: input, Reference: reference, Candidate: candidate}
+		report.Minimized = pair.minimize(ctx, input)
+		return report
+	}
+	return report
+}
+
+// minimize 贪心缩小：只要某个更小的候选输入仍然不一致就采用它，ctx 结束时返回当前缩小到的输入
+func (p *Pair) minimize(ctx context.Context, input interface{}) *Disagreement {
+	for step := 0; step < maxShrinkSteps && p.shrink != nil && ctx.Err() == nil; step++ {
+		shrunk := false
+		for _, smaller := range p.shrink(input) {
+			if _, _, ok := p.check(smaller); !ok {
+				input, shrunk = smaller, true
+				break
+			}
+		}
+		if !shrunk {
+			break
+		}
+	}
+	reference, candidate, _ := p.check(input)
+	return &Disagreement{Input: input, Reference: reference, Candidate: candidate}
+}
+
+// RegisterPair 注册一对实现，名称重复时报错
+func (r *Registry) RegisterPair(pair *Pair) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pairs == nil {
+		r.pairs = make(map[string]*Pair)
+	}
+	if _, exist := r.pairs[pair.Name]; exist {
+		return fmt.Errorf("algorithm: pair %s already registered", pair.Name)
+	}
+	r.pairs[pair.Name] = pair
+	return nil
+}
+
+// MustRegisterPair 注册实现对，失败时 panic，用于 init 阶段
+func (r *Registry) MustRegisterPair(pairs ...*Pair) {
+	for _, pair := range pairs {
+		if err := r.RegisterPair(pair); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Pairs 按名称排序返回所有实现对
+func (r *Registry) Pairs() []*Pair {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	pairs := make([]*Pair, 0, len(r.pairs))
+	for _, pair := range r.pairs {
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Name < pairs[j].Name })
+	return pairs
+}
+
+// VerifyAll 验证 in.Pair 指定的实现对，为空时验证全部，所有实现对共用同一个种子
+func (r *Registry) VerifyAll(ctx context.Context, in VerifyInput) ([]VerifyReport, error) {
+	pairs := r.Pairs()
+	if in.Pair != "" {
+		pairs = slices.DeleteFunc(pairs, func(p *Pair) bool { return p.Name != in.Pair })
+		if len(pairs) == 0 {
+			return nil, fmt.Errorf("%w: pair %s", ErrNotFound, in.Pair)
+		}
+	}
+	if in.Seed == 0 {
+		in.Seed = time.Now().UnixNano()
+	}
+	reports := make([]VerifyReport, 0, len(pairs))
+	for _, pair := range pairs {
+		reports = append(reports, Verify(ctx, pair, in))
+	}
+	return reports, nil
+}
+
+// randInts 生成 n 个 [lo, hi] 范围内的整数
+func randInts(r *rand.Rand, n, lo, hi int) []int {
+	nums := make([]int, n)
+	for i := range nums {
+		nums[i] = lo + r.Intn(hi-lo+1)
+	}
+	return nums
+}
+
+// shrinkInts 依次尝试删掉一个元素、把一个元素向 0 减半
+func shrinkInts(nums []int, minLen int) [][]int {
+	var result [][]int
+	if len(nums) > minLen {
+		for i := range nums {
+			result = append(result, slices.Delete(slices.Clone(nums), i, i+1))
+		}
+	}
+	for i, value := range nums {
+		if value != 0 {
+			smaller := slices.Clone(nums)
+			smaller[i] = value / 2
+			result = append(result, smaller)
+		}
+	}
+	return result
+}
+
+// validTwoSum 结果要么没找到，要么两个下标不同且和为 target
+func validTwoSum(in TwoSumInput, result TwoSumResult[int]) bool {
+	if !result.Found {
+		return true
+	}
+	i, j := result.Indices[0], result.Indices[1]
+	return i != j && in.Nums[i]+in.Nums[j] == *in.Target
+}
+
+// randString 生成 n 个字符（rune）、字符取自 alphabet 的字符串
+func randString(r *rand.Rand, n int, alphabet string) string {
+	letters := []rune(alphabet)
+	text := make([]rune, n)
+	for i := range text {
+		text[i] = letters[r.Intn(len(letters))]
+	}
+	return string(text)
+}
+
+// validAlignment 去掉 '-' 后还原为原字符串，两行都不是 '-' 的位置字符相同并且依次组成公共子序列
+func validAlignment(in TwoStringInput, a LCSAlignment) bool {
+	if len(a.Text1) != len(a.Text2) ||
+		strings.ReplaceAll(a.Text1, "-", "") != in.Text1 || strings.ReplaceAll(a.Text2, "-", "") != in.Text2 {
+		return false
+	}
+	var sequence []byte
+	for i := 0; i < len(a.Text1); i++ {
+		if a.Text1[i] != '-' && a.Text2[i] != '-' {
+			if a.Text1[i] != a.Text2[i] {
+				return false
+			}
+			sequence = append(sequence, a.Text1[i])
+		}
+	}
+	return string(sequence) == a.Sequence
+}
+
+// randCourses 生成 n 门课程的随机先修关系，边数与课程数相当，约一半的图有环
+func randCourses(r *rand.Rand, n int) CourseInput {
+	in := CourseInput{NumCourses: n, MaxPerSemester: r.Intn(4)}
+	for i := 0; i < n; i++ {
+		a, b := r.Intn(n), r.Intn(n)
+		if r.Intn(2) == 0 && a < b {
+			a, b = b, a
+		}
+		in.Prerequisites = append(in.Prerequisites, []int{a, b})
+	}
+	return in
+}
+
+// validSemesters 每门课的先修课都在更早的学期，每学期不超过上限，返回计划中的课程数，不合法时返回 -1
+func validSemesters(in CourseInput, semesters [][]int) int {
+	semesterOf := make(map[int]int)
+	for i, semester := range semesters {
+		if len(semester) == 0 || in.MaxPerSemester > 0 && len(semester) > in.MaxPerSemester {
+			return -1
+		}
+		for _, course := range semester {
+			if _, dup := semesterOf[course]; dup {
+				return -1
+			}
+			semesterOf[course] = i
+		}
+	}
+	for _, pair := range in.Prerequisites {
+		course, ok := semesterOf[pair[0]]
+		if !ok {
+			continue
+		}
+		if prerequisite, ok := semesterOf[pair[1]]; !ok || prerequisite >= course {
+			return -1
+		}
+	}
+	return len(semesterOf)
+}
+
+func init() {
+	Default.MustRegisterPair(
+		DefinePair("twoSum", "sumUpToTarget 暴力解 vs 哈希表",
+			func(r *rand.Rand, size int) TwoSumInput {
+				nums := randInts(r, size+1, -20, 20)
+				return TwoSumInput{Nums: nums, Target: intPtr(r.Intn(41) - 20)}
+			},
+			func(in TwoSumInput) TwoSumResult[int] { return TwoSumBruteForce(in.Nums, *in.Target) },
+			func(in TwoSumInput) TwoSumResult[int] { return TwoSumHashMap(in.Nums, *in.Target) },
+			// 多组答案时下标可以不同，只要求是否找到一致且都合法
+			func(in TwoSumInput, a, b TwoSumResult[int]) bool {
+				return a.Found == b.Found && validTwoSum(in, a) && validTwoSum(in, b)
+			},
+			func(in TwoSumInput) []TwoSumInput {
+				var result []TwoSumInput
+				for _, nums := range shrinkInts(in.Nums, 2) {
+					result = append(result, TwoSumInput{Nums: nums, Target: in.Target})
+				}
+				return result
+			}),
+		DefinePair("containerWithMostWater", "containerWithMostWater 双指针 vs 暴力解",
+			func(r *rand.Rand, size int) []int { return randInts(r, size+1, 0, 100) },
+			MaxArea[int], MaxAreaBruteForce[int], nil,
+			func(heights []int) [][]int { return shrinkInts(heights, 2) }),
+		DefinePair("climbStairsMatrix", "climbStairs 模素数 dp vs 矩阵快速幂",
+			func(r *rand.Rand, size int) uint64 { return uint64(r.Intn(20*size + 1)) },
+			func(n uint64) uint64 {
+				a := ModArith{P: 1_000_000_007}
+				prev, ways := a.One(), a.One()
+				for i := uint64(2); i <= n; i++ {
+					prev, ways = ways, a.Add(prev, ways)
+				}
+				return ways
+			},
+			func(n uint64) uint64 { return ClimbStairsCount[uint64](ModArith{P: 1_000_000_007}, n) },
+			nil, nil),
+		DefinePair("climbStairsBig", "climbStairs int64 dp vs big 矩阵快速幂",
+			func(r *rand.Rand, size int) int { return 1 + r.Intn(MaxIntStairs) },
+			ClimbStairsDP,
+			func(n int) int { return int(ClimbStairsCount[*big.Int](BigArith{}, uint64(n)).Int64()) },
+			nil, nil),
+		DefinePair("climbStairs", "climbStairs 递归 vs dp",
+			func(r *rand.Rand, size int) int { return 1 + r.Intn(min(size, 25)) },
+			ClimbStairsRecursive, ClimbStairsDP, nil,
+			func(n int) []int {
+				if n <= 1 {
+					return nil
+				}
+				return []int{n - 1, n / 2}
+			}),
+		DefinePair("climbStairsConstantSpace", "climbStairs dp vs O(1) 空间",
+			func(r *rand.Rand, size int) int { return 1 + r.Intn(min(size*3, 90)) },
+			ClimbStairsDP, ClimbStairs, nil, nil),
+		DefinePair("numberOfOneBits", "numberOfOneBits n&(n-1) vs 逐位检查",
+			func(r *rand.Rand, size int) uint64 { return r.Uint64() >> uint(r.Intn(64)) },
+			PopCountKernighan, PopCountShift, nil,
+			func(n uint64) []uint64 {
+				if n == 0 {
+					return nil
+				}
+				return []uint64{n >> 1, n & (n - 1)}
+			}),
+		DefinePair("findMinimumInRotatedArray", "findMinimumInRotatedArray 线性扫描 vs 二分",
+			func(r *rand.Rand, size int) []int {
+				// 严格递增再旋转
+				nums := make([]int, size)
+				for i := range nums {
+					nums[i] = i*3 + r.Intn(3)
+				}
+				k := r.Intn(size)
+				return append(nums[k:], nums[:k]...)
+			},
+			func(nums []int) int { return slices.Min(nums) },
+			func(nums []int) int { return nums[FindMinRotated(nums)] },
+			nil, nil),
+		DefinePair("courseTopologySemesters", "courseTopology 可完成的课程数 vs 分学期计划",
+			randCourses,
+			func(in CourseInput) int { return len(CourseSchedule(in.NumCourses, in.Pairs()).Order) },
+			func(in CourseInput) int {
+				return validSemesters(in, CourseSemesters(in.NumCourses, in.Pairs(), in.MaxPerSemester))
+			},
+			nil, nil),
+		DefinePair("courseTopologyCycles", "courseTopology 能否完成 vs 是否找到合法的环",
+			randCourses,
+			func(in CourseInput) bool { return !CourseSchedule(in.NumCourses, in.Pairs()).CanFinish },
+			func(in CourseInput) bool {
+				g := courseGraph(in.NumCourses, in.Pairs())
+				cycles := CourseCycles(in.NumCourses, in.Pairs())
+				for _, cycle := range cycles {
+					if !validCycle(g, cycle) {
+						return false
+					}
+				}
+				return len(cycles) > 0
+			},
+			nil, nil),
+		DefinePair("cloneGraph", "cloneGraph DFS vs BFS，比较序列化结果和与原图的一致性",
+			func(r *rand.Rand, size int) CloneGraphInput {
+				adjList := make([][]int, size)
+				for i := range adjList {
+					adjList[i] = randInts(r, r.Intn(4), 1, size)
+				}
+				return CloneGraphInput{AdjList: adjList}
+			},
+			func(in CloneGraphInput) CloneGraphInput {
+				clone, _ := in.Encode(CloneGraphDFS(in.Decode()))
+				return clone
+			},
+			func(in CloneGraphInput) CloneGraphInput {
+				node := in.Decode()
+				clone := CloneGraphBFS(node)
+				if check := CompareClone(node, clone); !check.Isomorphic || !check.Independent {
+					return CloneGraphInput{}
+				}
+				encoded, _ := in.Encode(clone)
+				return encoded
+			},
+			nil, nil),
+		// 以下几对检查 dp 回溯出的具体解：合法时返回解的得分，不合法时返回 -1
+		DefinePair("longestIncreasingSubsequenceWitness", "longestIncreasingSubsequence 长度 vs 回溯出的子序列",
+			func(r *rand.Rand, size int) []int { return randInts(r, size, 0, 20) },
+			LongestIncreasingSubsequence[int],
+			func(nums []int) int {
+				indices := LongestIncreasingSubsequenceIndices(nums)
+				for i := 1; i < len(indices); i++ {
+					if indices[i] <= indices[i-1] || nums[indices[i]] <= nums[indices[i-1]] {
+						return -1
+					}
+				}
+				return len(indices)
+			},
+			nil, func(nums []int) [][]int { return shrinkInts(nums, 0) }),
+		DefinePair("longestCommonSubsequenceWitness", "twoStringLongestCommonSubsequence 长度 vs 对齐结果",
+			func(r *rand.Rand, size int) TwoStringInput {
+				return TwoStringInput{Text1: randString(r, r.Intn(size+1), "abc"), Text2: randString(r, r.Intn(size+1), "abc")}
+			},
+			func(in TwoStringInput) int { return LongestCommonSubsequenceString(in.Text1, in.Text2) },
+			func(in TwoStringInput) int {
+				alignment := AlignLongestCommonSubsequence(in.Text1, in.Text2)
+				if !validAlignment(in, alignment) {
+					return -1
+				}
+				return len(alignment.Sequence)
+			},
+			nil, nil),
+		DefinePair("coinChargeWitness", "coinCharge 最少硬币数 vs 回溯出的硬币",
+			func(r *rand.Rand, size int) CoinChangeInput {
+				return CoinChangeInput{Coins: randInts(r, 1+r.Intn(4), 1, 12), Amount: r.Intn(size * 4)}
+			},
+			func(in CoinChangeInput) int { return CoinChange(in.Coins, in.Amount) },
+			func(in CoinChangeInput) int {
+				used := CoinChangeCoins(in.Coins, in.Amount)
+				if used == nil {
+					return -1
+				}
+				var sum int
+				for _, coin := range used {
+					if !slices.Contains(in.Coins, coin) {
+						return -2
+					}
+					sum += coin
+				}
+				if sum != in.Amount {
+					return -2
+				}
+				return len(used)
+			},
+			nil, nil),
+		DefinePair("rubHouseWitness", "rubHouse 最大金额 vs 回溯出的房子",
+			func(r *rand.Rand, size int) []int { return randInts(r, size, 0, 50) },
+			Rob[int],
+			func(houses []int) int {
+				var sum int
+				robbed := RobHouses(houses)
+				for i, house := range robbed {
+					if i > 0 && house-robbed[i-1] < 2 {
+						return -1
+					}
+					sum += houses[house]
+				}
+				return sum
+			},
+			nil, func(houses []int) [][]int { return shrinkInts(houses, 1) }),
+		DefinePair("decodeLetterBig", "decodeLetter int dp vs big dp",
+			func(r *rand.Rand, size int) string { return randString(r, r.Intn(min(size, 40)+1), "0112226789") },
+			NumDecodings,
+			func(text string) int { return int(NumDecodingsCount[*big.Int](BigArith{}, text).Int64()) },
+			nil, nil),
+		DefinePair("coinChargeWays", "coinChargeWays dp vs 回溯枚举组合",
+			func(r *rand.Rand, size int) CoinWaysInput {
+				return CoinWaysInput{Coins: randInts(r, 1+r.Intn(4), 1, 10), Amount: r.Intn(min(size, 30) + 1)}
+			},
+			func(in CoinWaysInput) int { return len(CombinationSum(in.Coins, in.Amount)) },
+			func(in CoinWaysInput) int {
+				return int(CoinChangeWays[*big.Int](BigArith{}, in.Coins, in.Amount).Int64())
+			},
+			nil, nil),
+		DefinePair("decodeLetterWitness", "decodeLetter 解码数 vs 枚举出的解码",
+			func(r *rand.Rand, size int) string { return randString(r, 1+r.Intn(min(size, 15)), "0112226789") },
+			NumDecodings,
+			func(text string) int {
+				decodings := Decodings(text, 0, math.MaxInt)
+				if !slices.IsSorted(decodings) || len(slices.Compact(slices.Clone(decodings))) != len(decodings) {
+					return -1
+				}
+				// 分页结果与整体枚举一致
+				for offset := 0; offset < len(decodings); offset += 3 {
+					if !slices.Equal(Decodings(text, offset, 3), decodings[offset:min(offset+3, len(decodings))]) {
+						return -1
+					}
+				}
+				return len(decodings)
+			},
+			nil, nil),
+	)
+}
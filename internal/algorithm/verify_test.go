@@ -0,0 +1,130 @@
+package algorithm
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"testing"
+)
+
+// TestDifferential 用固定种子比较所有已注册的实现对，失败时输出最小化后的输入
+func TestDifferential(t *testing.T) {
+	for _, pair := range Default.Pairs() {
+		t.Run(pair.Name, func(t *testing.T) {
+			t.Parallel()
+			report := Verify(context.Background(), pair, VerifyInput{Seed: 1, Cases: 500})
+			if !report.Passed {
+				minimized, _ := json.Marshal(report.Minimized)
+				t.Fatalf("%s disagrees after %d cases (seed %d): %s", pair.Name, report.Cases, report.Seed, minimized)
+			}
+		})
+	}
+}
+
+// TestMinimize 故意构造不一致的实现对，确认失败用例会被缩小
+func TestMinimize(t *testing.T) {
+	pair := DefinePair("broken", "",
+		func(r *rand.Rand, size int) []int { return randInts(r, size+5, 0, 100) },
+		func(nums []int) bool { return len(nums) < 3 },
+		func(nums []int) bool { return true },
+		nil,
+		func(nums []int) [][]int { return shrinkInts(nums, 0) })
+	report := Verify(context.Background(), pair, VerifyInput{Seed: 1, Cases: 10})
+	if report.Passed {
+		t.Fatal("expected disagreement")
+	}
+	if got := report.Minimized.Input.([]int); len(got) != 3 {
+		t.Fatalf("minimized input = %v, want 3 zero elements", got)
+	}
+}
+
+// TestJudgeExamples 每个算法的示例输入用参考解自己的输出评测，必须通过（输出能按输出类型往返）
+func TestJudgeExamples(t *testing.T) {
+	for _, spec := range Default.List() {
+		t.Run(spec.Name, func(t *testing.T) {
+			expected, err := spec.Execute(context.Background(), spec.Example)
+			if err != nil {
+				t.Fatalf("run example: %v", err)
+			}
+			answer, _ := json.Marshal(expected)
+			verdict, err := Default.Judge(context.Background(), spec.Name, spec.Example, answer)
+			if err != nil {
+				t.Fatalf("judge: %v", err)
+			}
+			if !verdict.Accepted() {
+				t.Fatalf("%s: %s %v", verdict.Status, verdict.Message, verdict.Diff)
+			}
+		})
+	}
+}
+
+// TestBellmanFordNegativeCycle 目标节点能经过负环到达时没有最短路，不能沿成环的前驱死循环
+func TestBellmanFordNegativeCycle(t *testing.T) {
+	spec, err := Default.Get("bellmanFord")
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := `{"n":10,"directed":true,"weighted":true,"edges":[[7,6,9],[7,9,-1],[7,4,20],[6,0,2],[7,6,16],[4,8,-5],[2,3,-2],[9,2,18],[9,7,-5],[2,0,-5]],"source":7,"target":0}`
+	output, err := spec.Execute(context.Background(), []byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := output.(ShortestPathResult)
+	if len(result.NegativeCycle) == 0 {
+		t.Fatal("expected negative cycle")
+	}
+	if result.Path != nil || result.Distance == nil || *result.Distance != NegativeInfinity {
+		t.Fatalf("target 0: path %v, distance %v, want no path and -Infinity", result.Path, result.Distance)
+	}
+	for v, d := range result.Dist {
+		if d != NegativeInfinity && d != Infinity && v != 1 && v != 5 {
+			t.Fatalf("node %d reachable from the cycle has distance %d", v, d)
+		}
+	}
+}
+
+// TestFloydWarshallNegativeCycle 负环上的距离不能翻倍溢出成正数
+func TestFloydWarshallNegativeCycle(t *testing.T) {
+	in := GraphInput{N: 60, Directed: true, Weighted: true}
+	for i := 0; i < in.N; i++ {
+		in.Edges = append(in.Edges, Edge{i, (i + 1) % in.N, -MaxEdgeWeight})
+	}
+	dist := FloydWarshall(in.Graph())
+	for i := range dist {
+		for j := range dist[i] {
+			if dist[i][j] != NegativeInfinity {
+				t.Fatalf("dist[%d][%d] = %d, want -Infinity", i, j, dist[i][j])
+			}
+		}
+	}
+}
+
+// TestGenerators 每个算法都有生成器，每种 flavor 生成的输入都能运行，相同种子生成相同的输入
+func TestGenerators(t *testing.T) {
+	for _, spec := range Default.List() {
+		t.Run(spec.Name, func(t *testing.T) {
+			generator, err := Default.GetGenerator(spec.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, flavor := range generator.Flavors {
+				in := GenerateInput{Seed: 1, Flavor: flavor, Count: 5}
+				result, err := Default.Generate(spec.Name, in)
+				if err != nil {
+					t.Fatalf("%s: %v", flavor, err)
+				}
+				for _, input := range result.Inputs {
+					if _, err := spec.Execute(context.Background(), input); err != nil {
+						t.Fatalf("%s: run %s: %v", flavor, input, err)
+					}
+				}
+				again, _ := Default.Generate(spec.Name, in)
+				first, _ := json.Marshal(result)
+				second, _ := json.Marshal(again)
+				if string(first) != string(second) {
+					t.Fatalf("%s: seed 1 is not reproducible", flavor)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,558 @@
+package algorithm
+
+import (
+	"container/heap"
+	"math"
+	"slices"
+	"sort"
+)
+
+// Window 子数组或子串的边界，闭区间 [left, right]，下标从 0 开始
+type Window struct {
+	Left  int `json:"left"`
+	Right int `json:"right"`
+}
+
+// Len 窗口长度，right < left 表示空窗口
+func (w Window) Len() int {
+	return w.Right - w.Left + 1
+}
+
+/*
+MaxSubarrayWindow leetcode 53 Kadane
+以 i 结尾的最大和：前面的和为负时从 i 重新开始，否则接上 nums[i]
+全为负数时结果是最大的那个元素，nums 不能为空
+*/
+func MaxSubarrayWindow[T Number](nums []T) (T, Window) {
+	best, sum := nums[0], nums[0]
+	var window Window
+	var start int
+	for i := 1; i < len(nums); i++ {
+		if sum < 0 {
+			sum, start = nums[i], i
+		} else {
+			sum += nums[i]
+		}
+		if sum > best {
+			best, window = sum, Window{Left: start, Right: i}
+		}
+	}
+	return best, window
+}
+
+// MaxSubarrayBruteForce 枚举所有子数组 O(n^2)，nums 不能为空
+func MaxSubarrayBruteForce[T Number](nums []T) T {
+	best := nums[0]
+	for i := range nums {
+		var sum T
+		for j := i; j < len(nums); j++ {
+			sum += nums[j]
+			best = max(best, sum)
+		}
+	}
+	return best
+}
+
+/*
+MaxProductSubarray leetcode 152
+乘以负数会让最大积和最小积互换，所以同时记录以 i 结尾的最大积、最小积以及它们的起点
+nums 不能为空，调用方保证任意子数组的积不溢出
+*/
+func MaxProductSubarray(nums []int) (int, Window) {
+	best, window := nums[0], Window{}
+	high, highStart := nums[0], 0
+	low, lowStart := nums[0], 0
+	for i := 1; i < len(nums); i++ {
+		x := nums[i]
+		// 三个候选：从 i 重新开始、接上最大积、接上最小积
+		nextHigh, nextHighStart, nextLow, nextLowStart := x, i, x, i
+		for _, candidate := range [2][2]int{{high * x, highStart}, {low * x, lowStart}} {
+			if candidate[0] > nextHigh {
+				nextHigh, nextHighStart = candidate[0], candidate[1]
+			}
+			if candidate[0] < nextLow {
+				nextLow, nextLowStart = candidate[0], candidate[1]
+			}
+		}
+		high, highStart, low, lowStart = nextHigh, nextHighStart, nextLow, nextLowStart
+		if high > best {
+			best, window = high, Window{Left: highStart, Right: i}
+		}
+	}
+	return best, window
+}
+
+// MaxProductBruteForce 枚举所有子数组 O(n^2)，nums 不能为空
+func MaxProductBruteForce(nums []int) int {
+	best := nums[0]
+	for i := range nums {
+		product := 1
+		for j := i; j < len(nums); j++ {
+			product *= nums[j]
+			best = max(best, product)
+		}
+	}
+	return best
+}
+
+// Trade 一次买卖，第 buy 天买入、第 sell 天卖出
+type Trade struct {
+	Buy  int `json:"buy"`
+	Sell int `json:"sell"`
+}
+
+// TradesResult 多次买卖的最大利润（已扣除手续费）和对应的交易，交易按时间顺序排列
+type TradesResult struct {
+	MaxProfit int     `json:"maxProfit"`
+	Trades    []Trade `json:"trades"`
+}
+
+// impossible 不可达状态的利润，加减价格也不会溢出
+const impossible = math.MinInt / 2
+
+// mergeTrades 把同一天卖出又买入的相邻交易合并为一次，利润不变
+func mergeTrades(trades []Trade) []Trade {
+	merged := make([]Trade, 0, len(trades))
+	for _, trade := range trades {
+		if n := len(merged); n > 0 && merged[n-1].Sell == trade.Buy {
+			merged[n-1].Sell = trade.Sell
+			continue
+		}
+		merged = append(merged, trade)
+	}
+	return merged
+}
+
+// maxProfitUnlimited 不限交易次数时吃下每一段上涨
+func maxProfitUnlimited(prices []int) TradesResult {
+	result := TradesResult{Trades: []Trade{}}
+	for i := 1; i < len(prices); i++ {
+		if prices[i] > prices[i-1] {
+			result.MaxProfit += prices[i] - prices[i-1]
+			result.Trades = append(result.Trades, Trade{Buy: i - 1, Sell: i})
+		}
+	}
+	result.Trades = mergeTrades(result.Trades)
+	return result
+}
+
+/*
+MaxProfitK leetcode 188 至多 k 次交易
+profit[t][i] 至多 t 次交易、第 i 天结束时不持股的最大利润
+profit[t][i] = max(profit[t][i-1], prices[i] + max(profit[t-1][j] - prices[j]), j < i)
+括号里的最大值随 i 递推，O(kn)；2k >= n 时相当于不限次数，直接贪心
+*/
+func MaxProfitK(prices []int, k int) TradesResult {
+	return maxProfitK(prices, k, nil)
+}
+
+func maxProfitK(prices []int, k int, m *Meter) TradesResult {
+	n := len(prices)
+	if n < 2 || k == 0 {
+		return TradesResult{Trades: []Trade{}}
+	}
+	if 2*k >= n {
+		return maxProfitUnlimited(prices)
+	}
+	m.Alloc(intBytes(2 * (k + 1) * n))
+	// sellFrom[t][i] 第 i 天卖出时的买入日，没有卖出为 -1
+	profit := make([][]int, k+1)
+	sellFrom := make([][]int, k+1)
+	profit[0] = make([]int, n)
+	for t := 1; t <= k; t++ {
+		m.Step(int64(n))
+		profit[t] = make([]int, n)
+		sellFrom[t] = make([]int, n)
+		sellFrom[t][0] = -1
+		bestBuy, buyDay := -prices[0], 0
+		for i := 1; i < n; i++ {
+			profit[t][i], sellFrom[t][i] = profit[t][i-1], -1
+			if sell := bestBuy + prices[i]; sell > profit[t][i] {
+				profit[t][i], sellFrom[t][i] = sell, buyDay
+			}
+			if buy := profit[t-1][i] - prices[i]; buy > bestBuy {
+				bestBuy, buyDay = buy, i
+			}
+		}
+	}
+	var trades []Trade
+	for t, i := k, n-1; t > 0 && i > 0; {
+		if sellFrom[t][i] < 0 {
+			i--
+			continue
+		}
+		trades = append(trades, Trade{Buy: sellFrom[t][i], Sell: i})
+		i, t = sellFrom[t][i], t-1
+	}
+	slices.Reverse(trades)
+	return TradesResult{MaxProfit: profit[k][n-1], Trades: mergeTrades(trades)}
+}
+
+/*
+MaxProfitCooldown leetcode 309 卖出后的第二天不能买入
+hold 持股，sold 当天卖出，rest 不持股且不在冷冻期：
+hold[i] = max(hold[i-1], rest[i-1] - prices[i])，sold[i] = hold[i-1] + prices[i]，rest[i] = max(rest[i-1], sold[i-1])
+记录 hold、rest 取的是哪一项，从最后一天倒推出交易
+*/
+func MaxProfitCooldown(prices []int) TradesResult {
+	n := len(prices)
+	if n == 0 {
+		return TradesResult{Trades: []Trade{}}
+	}
+	hold, sold, rest := make([]int, n), make([]int, n), make([]int, n)
+	// bought[i] 第 i 天买入，cooled[i] rest[i] 来自前一天卖出
+	bought, cooled := make([]bool, n), make([]bool, n)
+	hold[0], sold[0], bought[0] = -prices[0], impossible, true
+	for i := 1; i < n; i++ {
+		hold[i] = hold[i-1]
+		if buy := rest[i-1] - prices[i]; buy > hold[i] {
+			hold[i], bought[i] = buy, true
+		}
+		sold[i] = hold[i-1] + prices[i]
+		rest[i] = rest[i-1]
+		if sold[i-1] > rest[i] {
+			rest[i], cooled[i] = sold[i-1], true
+		}
+	}
+	const (
+		stateRest = iota
+		stateHold
+		stateSold
+	)
+	state, result := stateRest, TradesResult{MaxProfit: rest[n-1], Trades: []Trade{}}
+	if sold[n-1] > rest[n-1] {
+		state, result.MaxProfit = stateSold, sold[n-1]
+	}
+	var sell int
+	for i := n - 1; i >= 0; i-- {
+		switch {
+		case state == stateSold:
+			sell, state = i, stateHold
+		case state == stateHold && bought[i]:
+			result.Trades = append(result.Trades, Trade{Buy: i, Sell: sell})
+			state = stateRest
+		case state == stateRest && cooled[i]:
+			state = stateSold
+		}
+	}
+	slices.Reverse(result.Trades)
+	return result
+}
+
+/*
+MaxProfitFee leetcode 714 每次交易付 fee 手续费
+cash[i] = max(cash[i-1], hold[i-1] + prices[i] - fee)，hold[i] = max(hold[i-1], cash[i-1] - prices[i])
+*/
+func MaxProfitFee(prices []int, fee int) TradesResult {
+	n := len(prices)
+	if n == 0 {
+		return TradesResult{Trades: []Trade{}}
+	}
+	cash, hold := make([]int, n), make([]int, n)
+	// bought[i] hold[i] 是第 i 天买入，sold[i] cash[i] 是第 i 天卖出
+	bought, sold := make([]bool, n), make([]bool, n)
+	hold[0], bought[0] = -prices[0], true
+	for i := 1; i < n; i++ {
+		cash[i] = cash[i-1]
+		if sell := hold[i-1] + prices[i] - fee; sell > cash[i] {
+			cash[i], sold[i] = sell, true
+		}
+		hold[i] = hold[i-1]
+		if buy := cash[i-1] - prices[i]; buy > hold[i] {
+			hold[i], bought[i] = buy, true
+		}
+	}
+	result := TradesResult{MaxProfit: cash[n-1], Trades: []Trade{}}
+	holding, sell := false, 0
+	for i := n - 1; i >= 0; i-- {
+		switch {
+		case !holding && sold[i]:
+			holding, sell = true, i
+		case holding && bought[i]:
+			result.Trades = append(result.Trades, Trade{Buy: i, Sell: sell})
+			holding = false
+		}
+	}
+	slices.Reverse(result.Trades)
+	return result
+}
+
+// stockBruteForce 枚举每天买入、卖出或不动，k < 0 表示不限交易次数，cooldown 时卖出后隔一天才能买入
+func stockBruteForce(prices []int, k, fee int, cooldown bool) int {
+	var search func(day, left int, holding bool) int
+	search = func(day, left int, holding bool) int {
+		if day >= len(prices) {
+			if holding {
+				return impossible
+			}
+			return 0
+		}
+		best := search(day+1, left, holding)
+		switch {
+		case holding:
+			next := day + 1
+			if cooldown {
+				next++
+			}
+			best = max(best, prices[day]-fee+search(next, left, false))
+		case left != 0:
+			best = max(best, -prices[day]+search(day+1, left-1, true))
+		}
+		return best
+	}
+	return search(0, k, false)
+}
+
+/*
+LongestUniqueSubstring leetcode 3 无重复字符的最长子串，滑动窗口
+right 每前进一步，若 s[right] 在窗口内出现过，就把 left 移到它上次出现位置的下一位
+按 rune 处理，window 是 rune 下标；s 为空时返回空窗口 {0, -1}
+*/
+func LongestUniqueSubstring(s string) Window {
+	runes := []rune(s)
+	best := Window{Left: 0, Right: -1}
+	last := make(map[rune]int)
+	var left int
+	for right, r := range runes {
+		if i, seen := last[r]; seen && i >= left {
+			left = i + 1
+		}
+		last[r] = right
+		if right-left+1 > best.Len() {
+			best = Window{Left: left, Right: right}
+		}
+	}
+	return best
+}
+
+// LongestUniqueBruteForce 枚举所有起点向右扩展到出现重复为止 O(n^2)，返回长度
+func LongestUniqueBruteForce(s string) int {
+	runes := []rune(s)
+	var best int
+	for i := range runes {
+		seen := make(map[rune]bool)
+		for j := i; j < len(runes) && !seen[runes[j]]; j++ {
+			seen[runes[j]] = true
+			best = max(best, j-i+1)
+		}
+	}
+	return best
+}
+
+/*
+MinWindowSubstring leetcode 76 最小覆盖子串，滑动窗口
+need 记录还缺的每个字符的个数，missing 是还缺的总数
+right 扩展到不缺字符后收缩 left，直到再次缺字符，过程中记录最短的窗口
+按 rune 处理，t 中的重复字符需要同样多次覆盖；没有覆盖窗口时 found 为 false
+*/
+func MinWindowSubstring(s, t string) (window Window, found bool) {
+	runes := []rune(s)
+	need := make(map[rune]int)
+	var missing int
+	for _, r := range t {
+		need[r]++
+		missing++
+	}
+	var left int
+	for right, r := range runes {
+		if need[r] > 0 {
+			missing--
+		}
+		need[r]--
+		for ; missing == 0; left++ {
+			if !found || right-left < window.Right-window.Left {
+				window, found = Window{Left: left, Right: right}, true
+			}
+			if need[runes[left]]++; need[runes[left]] > 0 {
+				missing++
+			}
+		}
+	}
+	return window, found
+}
+
+// MinWindowBruteForce 枚举所有子串检查是否覆盖 t O(n^2 * |t|)，返回最短长度，没有时返回 0
+func MinWindowBruteForce(s, t string) int {
+	runes, target := []rune(s), []rune(t)
+	best := 0
+	for i := range runes {
+		for j := i; j < len(runes) && (best == 0 || j-i+1 < best); j++ {
+			if covers(runes[i:j+1], target) {
+				best = j - i + 1
+			}
+		}
+	}
+	return best
+}
+
+// covers window 中每个字符的个数都不少于 target
+func covers(window, target []rune) bool {
+	counts := make(map[rune]int)
+	for _, r := range window {
+		counts[r]++
+	}
+	for _, r := range target {
+		if counts[r]--; counts[r] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Interval 区间 [start, end]，合并区间时是闭区间，会议室中是左闭右开的时间段
+type Interval [2]int
+
+/*
+MergeIntervals leetcode 56 合并区间
+按起点排序后，起点不超过当前区间终点的都并入当前区间，O(n log n)
+*/
+func MergeIntervals(intervals []Interval) []Interval {
+	sorted := slices.Clone(intervals)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i][0] < sorted[j][0] })
+	merged := make([]Interval, 0, len(sorted))
+	for _, interval := range sorted {
+		if n := len(merged); n > 0 && interval[0] <= merged[n-1][1] {
+			merged[n-1][1] = max(merged[n-1][1], interval[1])
+			continue
+		}
+		merged = append(merged, interval)
+	}
+	return merged
+}
+
+// MergeIntervalsBruteForce 两两重叠的区间连成一组，每组取最小起点和最大终点 O(n^2)
+func MergeIntervalsBruteForce(intervals []Interval) []Interval {
+	uf := NewUnionFind(len(intervals))
+	for i, a := range intervals {
+		for j, b := range intervals[:i] {
+			if a[0] <= b[1] && b[0] <= a[1] {
+				uf.Union(i, j)
+			}
+		}
+	}
+	merged := make([]Interval, 0, uf.Count())
+	for _, group := range uf.Groups() {
+		span := intervals[group[0]]
+		for _, i := range group {
+			span = Interval{min(span[0], intervals[i][0]), max(span[1], intervals[i][1])}
+		}
+		merged = append(merged, span)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i][0] < merged[j][0] })
+	return merged
+}
+
+/*
+InsertInterval leetcode 57 插入区间
+intervals 按起点升序且互不重叠，一次扫描分成三段：完全在左边的、与新区间重叠的（并入新区间）、完全在右边的
+返回插入后的区间、新区间在结果中的下标，以及被并入的原区间的下标范围（没有时为 nil）
+*/
+func InsertInterval(intervals []Interval, add Interval) ([]Interval, int, *Window) {
+	result := make([]Interval, 0, len(intervals)+1)
+	i := 0
+	for ; i < len(intervals) && intervals[i][1] < add[0]; i++ {
+		result = append(result, intervals[i])
+	}
+	index := len(result)
+	var merged *Window
+	for ; i < len(intervals) && intervals[i][0] <= add[1]; i++ {
+		add = Interval{min(add[0], intervals[i][0]), max(add[1], intervals[i][1])}
+		if merged == nil {
+			merged = &Window{Left: i}
+		}
+		merged.Right = i
+	}
+	result = append(result, add)
+	return append(result, intervals[i:]...), index, merged
+}
+
+// MeetingRooms 会议室安排，会议是左闭右开的时间段
+type MeetingRooms struct {
+	// Rooms 最少需要的会议室数
+	Rooms int `json:"rooms"`
+	// Assignment 每个会议分配的会议室编号，从 0 开始
+	Assignment []int `json:"assignment"`
+	// Peak 同时进行的会议最多的时间段，会议数等于 rooms
+	Peak *Interval `json:"peak,omitempty"`
+	// Conflict leetcode 252，第一对时间冲突的会议下标，没有冲突时一个人可以参加所有会议
+	Conflict []int `json:"conflict,omitempty"`
+}
+
+type roomItem struct {
+	end, room int
+}
+
+type roomHeap []roomItem
+
+func (h roomHeap) Len() int { return len(h) }
+func (h roomHeap) Less(i, j int) bool {
+	return h[i].end < h[j].end || h[i].end == h[j].end && h[i].room < h[j].room
+}
+func (h roomHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *roomHeap) Push(x interface{}) { *h = append(*h, x.(roomItem)) }
+func (h *roomHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+/*
+MinMeetingRooms leetcode 253 会议室 II
+按开始时间处理会议，堆里是各会议室的结束时间；最早结束的会议室已经空出来就复用，否则开一间新的
+峰值时间段用扫描线求：结束事件排在同一时刻的开始事件之前，O(n log n)
+*/
+func MinMeetingRooms(meetings []Interval) MeetingRooms {
+	order := make([]int, len(meetings))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return meetings[order[i]][0] < meetings[order[j]][0] })
+	result := MeetingRooms{Assignment: make([]int, len(meetings))}
+	h := &roomHeap{}
+	for k, i := range order {
+		if k > 0 && result.Conflict == nil && meetings[i][0] < meetings[order[k-1]][1] {
+			result.Conflict = []int{order[k-1], i}
+		}
+		room := result.Rooms
+		if h.Len() > 0 && (*h)[0].end <= meetings[i][0] {
+			room = heap.Pop(h).(roomItem).room
+		} else {
+			result.Rooms++
+		}
+		result.Assignment[i] = room
+		heap.Push(h, roomItem{end: meetings[i][1], room: room})
+	}
+	// 扫描线：time 相同的事件中 -1（结束）排在 +1（开始）之前
+	events := make([][2]int, 0, 2*len(meetings))
+	for _, meeting := range meetings {
+		events = append(events, [2]int{meeting[0], 1}, [2]int{meeting[1], -1})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i][0] < events[j][0] || events[i][0] == events[j][0] && events[i][1] < events[j][1]
+	})
+	var active, peak int
+	for i, event := range events {
+		active += event[1]
+		if active > peak && event[1] > 0 {
+			peak = active
+			result.Peak = &Interval{event[0], events[i+1][0]}
+		}
+	}
+	return result
+}
+
+// MeetingRoomsBruteForce 在每个会议的开始时刻数正在进行的会议 O(n^2)，返回最大值
+func MeetingRoomsBruteForce(meetings []Interval) int {
+	var best int
+	for _, at := range meetings {
+		var active int
+		for _, meeting := range meetings {
+			if meeting[0] <= at[0] && at[0] < meeting[1] {
+				active++
+			}
+		}
+		best = max(best, active)
+	}
+	return best
+}
@@ -0,0 +1,655 @@
+package algorithm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+)
+
+// ProductInput 最大乘积子数组输入
+type ProductInput struct {
+	Nums []int `json:"nums" binding:"required,min=1,max=10000,dive,min=-1000,max=1000"`
+}
+
+// Validate 不含 0 的每一段的积都不超过 int64，保证任意子数组的积不溢出
+func (in *ProductInput) Validate() error {
+	if i := productOverflow(in.Nums); i >= 0 {
+		return fmt.Errorf("nums[%d]: product of the run without zeros overflows int64", i)
+	}
+	return nil
+}
+
+// productOverflow 第一个使不含 0 的连续段的积溢出的下标，没有溢出时返回 -1
+func productOverflow(nums []int) int {
+	product := 1
+	for i, x := range nums {
+		if x == 0 {
+			product = 1
+			continue
+		}
+		if product > math.MaxInt64/abs(x) {
+			return i
+		}
+		product *= abs(x)
+	}
+	return -1
+}
+
+// TradingInput 多次买卖股票的价格输入，价格有上限以免利润之和溢出
+type TradingInput struct {
+	Prices []int `json:"prices" binding:"required,min=1,max=10000,dive,min=0,max=1000000000" doc:"每天的股票价格"`
+}
+
+// StockKInput 至多 k 次交易
+type StockKInput struct {
+	TradingInput
+	K int `json:"k" binding:"min=0,max=10000" doc:"最多交易次数"`
+}
+
+// StockFeeInput 每次交易付手续费
+type StockFeeInput struct {
+	TradingInput
+	Fee int `json:"fee" binding:"min=0,max=1000000000" doc:"每次交易的手续费"`
+}
+
+// TextInput 单个字符串输入
+type TextInput struct {
+	Text string `json:"text" binding:"max=10000"`
+}
+
+// MinWindowInput 最小覆盖子串输入
+type MinWindowInput struct {
+	S string `json:"s" binding:"required,max=10000"`
+	T string `json:"t" binding:"required,max=10000"`
+}
+
+// IntervalsInput 区间列表输入，每个区间是 [start, end]
+type IntervalsInput struct {
+	Intervals [][]int `json:"intervals" binding:"required,min=1,max=10000"`
+}
+
+// Validate 合并区间允许 start == end
+func (in *IntervalsInput) Validate() error {
+	_, err := parseIntervals("intervals", in.Intervals, false)
+	return err
+}
+
+// MeetingsInput 会议时间段输入，每个会议是左闭右开的 [start, end)
+type MeetingsInput struct {
+	Intervals [][]int `json:"intervals" binding:"required,min=1,max=10000"`
+}
+
+// Validate 会议的 start 必须小于 end
+func (in *MeetingsInput) Validate() error {
+	_, err := parseIntervals("intervals", in.Intervals, true)
+	return err
+}
+
+// InsertIntervalInput 插入区间输入
+type InsertIntervalInput struct {
+	Intervals   [][]int `json:"intervals" binding:"max=10000" doc:"按起点升序且互不重叠"`
+	NewInterval []int   `json:"newInterval" binding:"required"`
+}
+
+// Validate 原区间按起点升序且互不重叠
+func (in *InsertIntervalInput) Validate() error {
+	intervals, err := parseIntervals("intervals", in.Intervals, false)
+	if err != nil {
+		return err
+	}
+	for i := 1; i < len(intervals); i++ {
+		if intervals[i][0] <= intervals[i-1][1] {
+			return fmt.Errorf("intervals[%d]: intervals must be sorted and must not overlap", i)
+		}
+	}
+	_, err = parseIntervals("newInterval", [][]int{in.NewInterval}, false)
+	return err
+}
+
+// parseIntervals 每个区间恰好两个数且 start <= end，strict 时要求 start < end
+func parseIntervals(field string, raw [][]int, strict bool) ([]Interval, error) {
+	intervals := make([]Interval, len(raw))
+	for i, pair := range raw {
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("%s[%d]: want [start, end], got %v", field, i, pair)
+		}
+		if pair[0] > pair[1] || strict && pair[0] == pair[1] {
+			return nil, fmt.Errorf("%s[%d]: start %d must be less than end %d", field, i, pair[0], pair[1])
+		}
+		intervals[i] = Interval{pair[0], pair[1]}
+	}
+	return intervals, nil
+}
+
+// toIntervals 已经校验过的区间
+func toIntervals(raw [][]int) []Interval {
+	intervals, _ := parseIntervals("", raw, false)
+	return intervals
+}
+
+// MaxProductResult maxProductSubarray 结果，left、right 是子数组的边界
+type MaxProductResult struct {
+	MaxProduct int `json:"maxProduct"`
+	Window
+}
+
+// SubstringResult 子串结果，window 是 rune 下标，没有时 length 为 0 且 window 为空
+type SubstringResult struct {
+	Length    int     `json:"length"`
+	Substring string  `json:"substring"`
+	Window    *Window `json:"window,omitempty"`
+}
+
+func substringResult(s string, window Window) SubstringResult {
+	if window.Len() <= 0 {
+		return SubstringResult{}
+	}
+	return SubstringResult{Length: window.Len(), Substring: string([]rune(s)[window.Left : window.Right+1]), Window: &window}
+}
+
+// IntervalsResult mergeIntervals 结果
+type IntervalsResult struct {
+	Intervals []Interval `json:"intervals"`
+}
+
+// InsertResult insertInterval 结果，index 是新区间在结果中的下标，merged 是被并入的原区间的下标范围
+type InsertResult struct {
+	Intervals []Interval `json:"intervals"`
+	Index     int        `json:"index"`
+	Merged    *Window    `json:"merged,omitempty"`
+}
+
+// checkWindow 窗口在 [0, n) 内且非空
+func checkWindow(w Window, n int) error {
+	if w.Left < 0 || w.Right >= n || w.Left > w.Right {
+		return fmt.Errorf("window [%d, %d] is not a non-empty range of [0, %d)", w.Left, w.Right, n)
+	}
+	return nil
+}
+
+func checkMaxSubarray(in *NumsInput, expected, got MaxSumResult) error {
+	if got.MaxSum != expected.MaxSum {
+		return fmt.Errorf("maxSum = %d, want %d", got.MaxSum, expected.MaxSum)
+	}
+	if err := checkWindow(got.Window, len(in.Nums)); err != nil {
+		return err
+	}
+	var sum int
+	for _, x := range in.Nums[got.Left : got.Right+1] {
+		sum += x
+	}
+	if sum != got.MaxSum {
+		return fmt.Errorf("nums[%d..%d] sums to %d, not %d", got.Left, got.Right, sum, got.MaxSum)
+	}
+	return nil
+}
+
+func checkMaxProduct(in *ProductInput, expected, got MaxProductResult) error {
+	if got.MaxProduct != expected.MaxProduct {
+		return fmt.Errorf("maxProduct = %d, want %d", got.MaxProduct, expected.MaxProduct)
+	}
+	if err := checkWindow(got.Window, len(in.Nums)); err != nil {
+		return err
+	}
+	product := 1
+	for _, x := range in.Nums[got.Left : got.Right+1] {
+		product *= x
+	}
+	if product != got.MaxProduct {
+		return fmt.Errorf("nums[%d..%d] multiplies to %d, not %d", got.Left, got.Right, product, got.MaxProduct)
+	}
+	return nil
+}
+
+// tradesProfit 检查交易按时间顺序、先买后卖、不超过 k 次（k < 0 不限）、冷冻期内不买入，返回扣除手续费后的利润
+func tradesProfit(prices []int, trades []Trade, k, fee int, cooldown bool) (int, error) {
+	if k >= 0 && len(trades) > k {
+		return 0, fmt.Errorf("%d trades exceed the limit of %d", len(trades), k)
+	}
+	var profit, earliest int
+	for i, trade := range trades {
+		if trade.Buy < earliest || trade.Sell <= trade.Buy || trade.Sell >= len(prices) {
+			return 0, fmt.Errorf("trades[%d]: buy on day %d and sell on day %d is not allowed here", i, trade.Buy, trade.Sell)
+		}
+		profit += prices[trade.Sell] - prices[trade.Buy] - fee
+		earliest = trade.Sell
+		if cooldown {
+			earliest += 2
+		}
+	}
+	return profit, nil
+}
+
+// checkTrades 利润与参考解相同，交易合法且恰好得到这个利润
+func checkTrades[In any](rules func(in *In) (prices []int, k, fee int, cooldown bool)) func(in *In, expected, got TradesResult) error {
+	return func(in *In, expected, got TradesResult) error {
+		if got.MaxProfit != expected.MaxProfit {
+			return fmt.Errorf("maxProfit = %d, want %d", got.MaxProfit, expected.MaxProfit)
+		}
+		prices, k, fee, cooldown := rules(in)
+		profit, err := tradesProfit(prices, got.Trades, k, fee, cooldown)
+		if err != nil {
+			return err
+		}
+		if profit != got.MaxProfit {
+			return fmt.Errorf("trades make %d, not %d", profit, got.MaxProfit)
+		}
+		return nil
+	}
+}
+
+// checkSubstring 长度与参考解相同，window、substring 一致，且子串满足 valid
+func checkSubstring(s string, expected, got SubstringResult, valid func(substring []rune) error) error {
+	if got.Length != expected.Length {
+		return fmt.Errorf("length = %d, want %d", got.Length, expected.Length)
+	}
+	if got.Length == 0 {
+		if got.Window != nil || got.Substring != "" {
+			return errors.New("window and substring must be empty when length is 0")
+		}
+		return nil
+	}
+	runes := []rune(s)
+	if got.Window == nil {
+		return errors.New("window is required")
+	}
+	if err := checkWindow(*got.Window, len(runes)); err != nil {
+		return err
+	}
+	substring := runes[got.Window.Left : got.Window.Right+1]
+	if len(substring) != got.Length || string(substring) != got.Substring {
+		return fmt.Errorf("substring %q and length %d do not match window [%d, %d]", got.Substring, got.Length, got.Window.Left, got.Window.Right)
+	}
+	return valid(substring)
+}
+
+func checkLongestUnique(in *TextInput, expected, got SubstringResult) error {
+	return checkSubstring(in.Text, expected, got, func(substring []rune) error {
+		seen := make(map[rune]bool, len(substring))
+		for _, r := range substring {
+			if seen[r] {
+				return fmt.Errorf("%q has repeated character %q", string(substring), r)
+			}
+			seen[r] = true
+		}
+		return nil
+	})
+}
+
+func checkMinWindow(in *MinWindowInput, expected, got SubstringResult) error {
+	return checkSubstring(in.S, expected, got, func(substring []rune) error {
+		if !covers(substring, []rune(in.T)) {
+			return fmt.Errorf("%q does not contain every character of t", string(substring))
+		}
+		return nil
+	})
+}
+
+// validMeetingRooms 同一会议室的会议不重叠，峰值时间段内恰好有 rooms 个会议，冲突的两个会议确实重叠
+func validMeetingRooms(meetings []Interval, result MeetingRooms) error {
+	if len(result.Assignment) != len(meetings) {
+		return fmt.Errorf("want %d assignments, got %d", len(meetings), len(result.Assignment))
+	}
+	overlap := func(a, b Interval) bool { return a[0] < b[1] && b[0] < a[1] }
+	byRoom := make(map[int][]int)
+	for i, room := range result.Assignment {
+		if room < 0 || room >= result.Rooms {
+			return fmt.Errorf("assignment[%d]: room %d out of range [0,%d)", i, room, result.Rooms)
+		}
+		for _, j := range byRoom[room] {
+			if overlap(meetings[i], meetings[j]) {
+				return fmt.Errorf("meetings %d and %d overlap in room %d", j, i, room)
+			}
+		}
+		byRoom[room] = append(byRoom[room], i)
+	}
+	if result.Peak == nil || result.Peak[0] >= result.Peak[1] {
+		return errors.New("peak must be a non-empty time range")
+	}
+	var active int
+	for _, meeting := range meetings {
+		if meeting[0] <= result.Peak[0] && result.Peak[1] <= meeting[1] {
+			active++
+		}
+	}
+	if active != result.Rooms {
+		return fmt.Errorf("%d meetings cover the peak %v, want %d", active, *result.Peak, result.Rooms)
+	}
+	if conflict := result.Conflict; conflict != nil {
+		if len(conflict) != 2 || conflict[0] == conflict[1] || min(conflict[0], conflict[1]) < 0 ||
+			max(conflict[0], conflict[1]) >= len(meetings) || !overlap(meetings[conflict[0]], meetings[conflict[1]]) {
+			return fmt.Errorf("conflict %v is not a pair of overlapping meetings", conflict)
+		}
+	}
+	return nil
+}
+
+func checkMeetingRooms(in *MeetingsInput, expected, got MeetingRooms) error {
+	if got.Rooms != expected.Rooms {
+		return fmt.Errorf("rooms = %d, want %d", got.Rooms, expected.Rooms)
+	}
+	if (got.Conflict == nil) != (expected.Conflict == nil) {
+		return fmt.Errorf("conflict = %v, want a conflict: %v", got.Conflict, expected.Conflict != nil)
+	}
+	return validMeetingRooms(toIntervals(in.Intervals), got)
+}
+
+// randIntervals n 个起点在 [lo, hi] 的区间，长度不超过 maxLen，strict 时长度至少为 1
+func randIntervals(r *rand.Rand, n, lo, hi, maxLen int, strict bool) [][]int {
+	intervals := make([][]int, n)
+	for i := range intervals {
+		start := lo + r.Intn(hi-lo+1)
+		length := r.Intn(maxLen + 1)
+		if strict {
+			length++
+		}
+		intervals[i] = []int{start, start + length}
+	}
+	return intervals
+}
+
+// genIntervals 按 flavor 生成区间，sorted 按起点排序，allEqual 时所有区间相同
+func genIntervals(r *rand.Rand, opts GenerateOptions, strict bool) [][]int {
+	n := opts.n(1, 10000)
+	intervals := randIntervals(r, n, opts.Min, opts.Max, (opts.Max-opts.Min)/4, strict)
+	switch opts.Flavor {
+	case FlavorAllEqual:
+		for i := range intervals {
+			intervals[i] = intervals[0]
+		}
+	case FlavorSorted:
+		sort.Slice(intervals, func(i, j int) bool { return intervals[i][0] < intervals[j][0] })
+	}
+	return intervals
+}
+
+// disjointIntervals 从 2n 个不同的点两两配对，得到按起点升序且互不重叠的区间
+func disjointIntervals(r *rand.Rand, opts GenerateOptions, n int) [][]int {
+	points := distinctInts(r, opts, 2*n)
+	slices.Sort(points)
+	intervals := make([][]int, 0, n)
+	for i := 0; i+1 < len(points); i += 2 {
+		intervals = append(intervals, []int{points[i], points[i+1]})
+	}
+	return intervals
+}
+
+func init() {
+	Default.MustRegister(
+		Define(Spec{
+			Name: "maxProductSubarray", Title: "Maximum Product Subarray", LeetCode: 152,
+			Category: CategoryDP, Time: "O(n)", Space: "O(1)",
+		}, ProductInput{Nums: []int{2, 3, -2, 4}},
+			func(ctx context.Context, in *ProductInput) (MaxProductResult, error) {
+				product, window := MaxProductSubarray(in.Nums)
+				return MaxProductResult{MaxProduct: product, Window: window}, nil
+			}),
+		Define(Spec{
+			Name: "buySoldK", Title: "Best Time to Buy and Sell Stock IV", LeetCode: 188,
+			Category: CategoryDP, Time: "O(k * n)", Space: "O(k * n)",
+		}, StockKInput{TradingInput: TradingInput{Prices: []int{3, 2, 6, 5, 0, 3}}, K: 2},
+			func(ctx context.Context, in *StockKInput) (TradesResult, error) {
+				return maxProfitK(in.Prices, in.K, MeterFrom(ctx)), nil
+			}),
+		Define(Spec{
+			Name: "buySoldCooldown", Title: "Best Time to Buy and Sell Stock with Cooldown", LeetCode: 309,
+			Category: CategoryDP, Time: "O(n)", Space: "O(n)",
+		}, TradingInput{Prices: []int{1, 2, 3, 0, 2}},
+			func(ctx context.Context, in *TradingInput) (TradesResult, error) {
+				return MaxProfitCooldown(in.Prices), nil
+			}),
+		Define(Spec{
+			Name: "buySoldFee", Title: "Best Time to Buy and Sell Stock with Transaction Fee", LeetCode: 714,
+			Category: CategoryDP, Time: "O(n)", Space: "O(n)",
+		}, StockFeeInput{TradingInput: TradingInput{Prices: []int{1, 3, 2, 8, 4, 9}}, Fee: 2},
+			func(ctx context.Context, in *StockFeeInput) (TradesResult, error) {
+				return MaxProfitFee(in.Prices, in.Fee), nil
+			}),
+		Define(Spec{
+			Name: "longestUniqueSubstring", Title: "Longest Substring Without Repeating Characters", LeetCode: 3,
+			Category: CategorySlidingWindow, Time: "O(n)", Space: "O(k)",
+		}, TextInput{Text: "abcabcbb"},
+			func(ctx context.Context, in *TextInput) (SubstringResult, error) {
+				return substringResult(in.Text, LongestUniqueSubstring(in.Text)), nil
+			}),
+		Define(Spec{
+			Name: "minWindowSubstring", Title: "Minimum Window Substring", LeetCode: 76,
+			Category: CategorySlidingWindow, Time: "O(m + n)", Space: "O(k)",
+		}, MinWindowInput{S: "ADOBECODEBANC", T: "ABC"},
+			func(ctx context.Context, in *MinWindowInput) (SubstringResult, error) {
+				window, found := MinWindowSubstring(in.S, in.T)
+				if !found {
+					return SubstringResult{}, nil
+				}
+				return substringResult(in.S, window), nil
+			}),
+		Define(Spec{
+			Name: "mergeIntervals", Title: "Merge Intervals", LeetCode: 56,
+			Category: CategoryInterval, Time: "O(n log n)", Space: "O(n)",
+		}, IntervalsInput{Intervals: [][]int{{1, 3}, {2, 6}, {8, 10}, {15, 18}}},
+			func(ctx context.Context, in *IntervalsInput) (IntervalsResult, error) {
+				return IntervalsResult{Intervals: MergeIntervals(toIntervals(in.Intervals))}, nil
+			}),
+		Define(Spec{
+			Name: "insertInterval", Title: "Insert Interval", LeetCode: 57,
+			Category: CategoryInterval, Time: "O(n)", Space: "O(n)",
+		}, InsertIntervalInput{Intervals: [][]int{{1, 2}, {3, 5}, {6, 7}, {8, 10}, {12, 16}}, NewInterval: []int{4, 8}},
+			func(ctx context.Context, in *InsertIntervalInput) (InsertResult, error) {
+				intervals, index, merged := InsertInterval(toIntervals(in.Intervals), toIntervals([][]int{in.NewInterval})[0])
+				return InsertResult{Intervals: intervals, Index: index, Merged: merged}, nil
+			}),
+		Define(Spec{
+			Name: "meetingRooms", Title: "Meeting Rooms II", LeetCode: 253,
+			Category: CategoryInterval, Time: "O(n log n)", Space: "O(n)",
+		}, MeetingsInput{Intervals: [][]int{{0, 30}, {5, 10}, {15, 20}}},
+			func(ctx context.Context, in *MeetingsInput) (MeetingRooms, error) {
+				return MinMeetingRooms(toIntervals(in.Intervals)), nil
+			}),
+	)
+
+	Default.MustRegisterPair(
+		// 以下几对检查返回的窗口或交易：合法时返回它们得到的值，不合法时返回 math.MinInt 或 -1
+		DefinePair("maxSubarray", "maxSubarray Kadane vs 暴力枚举（含全为负数）",
+			func(r *rand.Rand, size int) []int { return randInts(r, size, -20, 10) },
+			MaxSubarrayBruteForce[int],
+			func(nums []int) int {
+				sum, window := MaxSubarrayWindow(nums)
+				if checkMaxSubarray(&NumsInput{Nums: nums}, MaxSumResult{MaxSum: sum}, MaxSumResult{MaxSum: sum, Window: window}) != nil {
+					return math.MinInt
+				}
+				return sum
+			},
+			nil, func(nums []int) [][]int { return shrinkInts(nums, 1) }),
+		DefinePair("maxProductSubarray", "maxProductSubarray 最大最小积 vs 暴力枚举",
+			func(r *rand.Rand, size int) []int { return randInts(r, size, -3, 3) },
+			MaxProductBruteForce,
+			func(nums []int) int {
+				product, window := MaxProductSubarray(nums)
+				got := MaxProductResult{MaxProduct: product, Window: window}
+				if checkMaxProduct(&ProductInput{Nums: nums}, got, got) != nil {
+					return math.MinInt
+				}
+				return product
+			},
+			nil, func(nums []int) [][]int { return shrinkInts(nums, 1) }),
+		DefinePair("buySoldK", "buySoldK dp vs 暴力枚举每天的操作",
+			func(r *rand.Rand, size int) StockKInput {
+				return StockKInput{TradingInput: TradingInput{Prices: randInts(r, min(size, 12), 0, 20)}, K: r.Intn(4)}
+			},
+			func(in StockKInput) int { return stockBruteForce(in.Prices, in.K, 0, false) },
+			func(in StockKInput) int {
+				result := MaxProfitK(in.Prices, in.K)
+				if profit, err := tradesProfit(in.Prices, result.Trades, in.K, 0, false); err != nil || profit != result.MaxProfit {
+					return -1
+				}
+				return result.MaxProfit
+			},
+			nil, nil),
+		DefinePair("buySoldCooldown", "buySoldCooldown 状态机 dp vs 暴力枚举每天的操作",
+			func(r *rand.Rand, size int) []int { return randInts(r, min(size, 12), 0, 20) },
+			func(prices []int) int { return stockBruteForce(prices, -1, 0, true) },
+			func(prices []int) int {
+				result := MaxProfitCooldown(prices)
+				if profit, err := tradesProfit(prices, result.Trades, -1, 0, true); err != nil || profit != result.MaxProfit {
+					return -1
+				}
+				return result.MaxProfit
+			},
+			nil, func(prices []int) [][]int { return shrinkInts(prices, 1) }),
+		DefinePair("buySoldFee", "buySoldFee dp vs 暴力枚举每天的操作",
+			func(r *rand.Rand, size int) StockFeeInput {
+				return StockFeeInput{TradingInput: TradingInput{Prices: randInts(r, min(size, 12), 0, 20)}, Fee: r.Intn(5)}
+			},
+			func(in StockFeeInput) int { return stockBruteForce(in.Prices, -1, in.Fee, false) },
+			func(in StockFeeInput) int {
+				result := MaxProfitFee(in.Prices, in.Fee)
+				if profit, err := tradesProfit(in.Prices, result.Trades, -1, in.Fee, false); err != nil || profit != result.MaxProfit {
+					return -1
+				}
+				return result.MaxProfit
+			},
+			nil, nil),
+		DefinePair("longestUniqueSubstring", "longestUniqueSubstring 滑动窗口 vs 暴力枚举",
+			func(r *rand.Rand, size int) string { return randString(r, r.Intn(size+1), "abcd") },
+			LongestUniqueBruteForce,
+			func(text string) int {
+				got := substringResult(text, LongestUniqueSubstring(text))
+				if checkLongestUnique(&TextInput{Text: text}, got, got) != nil {
+					return -1
+				}
+				return got.Length
+			},
+			nil, nil),
+		DefinePair("minWindowSubstring", "minWindowSubstring 滑动窗口 vs 暴力枚举",
+			func(r *rand.Rand, size int) MinWindowInput {
+				return MinWindowInput{S: randString(r, size, "abc"), T: randString(r, 1+r.Intn(4), "abc")}
+			},
+			func(in MinWindowInput) int { return MinWindowBruteForce(in.S, in.T) },
+			func(in MinWindowInput) int {
+				window, found := MinWindowSubstring(in.S, in.T)
+				if !found {
+					return 0
+				}
+				got := substringResult(in.S, window)
+				if checkMinWindow(&in, got, got) != nil {
+					return -1
+				}
+				return got.Length
+			},
+			nil, nil),
+		DefinePair("mergeIntervals", "mergeIntervals 排序扫描 vs 两两重叠的连通分量",
+			func(r *rand.Rand, size int) []Interval {
+				return toIntervals(randIntervals(r, size, 0, 3*size, 5, false))
+			},
+			MergeIntervalsBruteForce, MergeIntervals,
+			nil, nil),
+		DefinePair("insertInterval", "insertInterval 一次扫描 vs 追加后重新合并",
+			func(r *rand.Rand, size int) InsertIntervalInput {
+				opts := GenerateOptions{Min: 0, Max: 4 * size}
+				add := randIntervals(r, 1, 0, 4*size, size, false)[0]
+				return InsertIntervalInput{Intervals: disjointIntervals(r, opts, r.Intn(size+1)), NewInterval: add}
+			},
+			func(in InsertIntervalInput) []Interval {
+				return MergeIntervals(toIntervals(append(slices.Clone(in.Intervals), in.NewInterval)))
+			},
+			func(in InsertIntervalInput) []Interval {
+				add := toIntervals([][]int{in.NewInterval})[0]
+				intervals, index, _ := InsertInterval(toIntervals(in.Intervals), add)
+				if intervals[index][0] > add[0] || intervals[index][1] < add[1] {
+					return nil
+				}
+				return intervals
+			},
+			nil, nil),
+		DefinePair("meetingRooms", "meetingRooms 堆 vs 在每个开始时刻数会议（并检查分配）",
+			func(r *rand.Rand, size int) []Interval {
+				return toIntervals(randIntervals(r, size, 0, 2*size, 6, true))
+			},
+			MeetingRoomsBruteForce,
+			func(meetings []Interval) int {
+				result := MinMeetingRooms(meetings)
+				if validMeetingRooms(meetings, result) != nil {
+					return -1
+				}
+				return result.Rooms
+			},
+			nil, nil),
+	)
+
+	Default.MustRegisterChecker(
+		DefineChecker("maxSubarray", checkMaxSubarray),
+		DefineChecker("maxProductSubarray", checkMaxProduct),
+		DefineChecker("buySoldK", checkTrades(func(in *StockKInput) ([]int, int, int, bool) { return in.Prices, in.K, 0, false })),
+		DefineChecker("buySoldCooldown", checkTrades(func(in *TradingInput) ([]int, int, int, bool) { return in.Prices, -1, 0, true })),
+		DefineChecker("buySoldFee", checkTrades(func(in *StockFeeInput) ([]int, int, int, bool) { return in.Prices, -1, in.Fee, false })),
+		DefineChecker("longestUniqueSubstring", checkLongestUnique),
+		DefineChecker("minWindowSubstring", checkMinWindow),
+		DefineChecker("meetingRooms", checkMeetingRooms),
+	)
+
+	Default.MustRegisterGenerator(
+		DefineGenerator("maxProductSubarray", -5, 5, flavorsArray, func(r *rand.Rand, opts GenerateOptions) ProductInput {
+			nums := opts.within(-1000, 1000).ints(r, opts.n(1, 10000))
+			// 积会溢出时截断
+			if i := productOverflow(nums); i > 0 {
+				nums = nums[:i]
+			}
+			return ProductInput{Nums: nums}
+		}),
+		DefineGenerator("buySoldK", 0, 100, flavorsArray, func(r *rand.Rand, opts GenerateOptions) StockKInput {
+			prices := opts.within(0, 1000000000).ints(r, opts.n(1, 10000))
+			return StockKInput{TradingInput: TradingInput{Prices: prices}, K: r.Intn(min(len(prices), 10) + 1)}
+		}),
+		DefineGenerator("buySoldCooldown", 0, 100, flavorsArray, func(r *rand.Rand, opts GenerateOptions) TradingInput {
+			return TradingInput{Prices: opts.within(0, 1000000000).ints(r, opts.n(1, 10000))}
+		}),
+		DefineGenerator("buySoldFee", 0, 100, flavorsArray, func(r *rand.Rand, opts GenerateOptions) StockFeeInput {
+			opts = opts.within(0, 1000000000)
+			prices := opts.ints(r, opts.n(1, 10000))
+			return StockFeeInput{TradingInput: TradingInput{Prices: prices}, Fee: r.Intn((opts.Max-opts.Min)/4 + 1)}
+		}),
+		DefineGenerator("longestUniqueSubstring", 0, 0, flavorsString, func(r *rand.Rand, opts GenerateOptions) TextInput {
+			switch opts.Flavor {
+			case FlavorEmpty:
+				return TextInput{}
+			case FlavorAllEqual:
+				return TextInput{Text: randString(r, opts.n(0, 10000), "a")}
+			}
+			return TextInput{Text: randString(r, opts.n(0, 10000), "abcdefgh")}
+		}),
+		DefineGenerator("minWindowSubstring", 0, 0, []string{FlavorRandom, FlavorAllEqual}, func(r *rand.Rand, opts GenerateOptions) MinWindowInput {
+			alphabet := "ABCDEF"
+			if opts.Flavor == FlavorAllEqual {
+				alphabet = "A"
+			}
+			s := randString(r, opts.n(1, 10000), alphabet)
+			length := 1 + r.Intn(min(len(s), 5))
+			// 大约七成的 t 取自 s 中的字符，保证有覆盖窗口
+			if r.Intn(10) < 7 {
+				t := make([]byte, length)
+				for i := range t {
+					t[i] = s[r.Intn(len(s))]
+				}
+				return MinWindowInput{S: s, T: string(t)}
+			}
+			return MinWindowInput{S: s, T: randString(r, length, alphabet)}
+		}),
+		DefineGenerator("mergeIntervals", 0, 100, flavorsArray, func(r *rand.Rand, opts GenerateOptions) IntervalsInput {
+			return IntervalsInput{Intervals: genIntervals(r, opts, false)}
+		}),
+		DefineGenerator("insertInterval", 0, 100, []string{FlavorRandom, FlavorEmpty}, func(r *rand.Rand, opts GenerateOptions) InsertIntervalInput {
+			in := InsertIntervalInput{Intervals: [][]int{}, NewInterval: randIntervals(r, 1, opts.Min, opts.Max, (opts.Max-opts.Min)/4, false)[0]}
+			if opts.Flavor != FlavorEmpty {
+				in.Intervals = disjointIntervals(r, opts, opts.n(1, 10000))
+			}
+			return in
+		}),
+		DefineGenerator("meetingRooms", 0, 100, flavorsArray, func(r *rand.Rand, opts GenerateOptions) MeetingsInput {
+			return MeetingsInput{Intervals: genIntervals(r, opts, true)}
+		}),
+	)
+}
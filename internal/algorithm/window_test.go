@@ -0,0 +1,179 @@
+package algorithm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestMaxSubarrayWindow 已知答案，全为负数时取最大的单个元素
+func TestMaxSubarrayWindow(t *testing.T) {
+	tests := []struct {
+		nums   []int
+		sum    int
+		window Window
+	}{
+		{[]int{-2, 1, -3, 4, -1, 2, 1, -5, 4}, 6, Window{3, 6}},
+		{[]int{5}, 5, Window{0, 0}},
+		{[]int{-7}, -7, Window{0, 0}},
+		{[]int{-3, -1, -2}, -1, Window{1, 1}},
+		{[]int{5, 4, -1, 7, 8}, 23, Window{0, 4}},
+		{[]int{0, 0, 0}, 0, Window{0, 0}},
+	}
+	for _, test := range tests {
+		sum, window := MaxSubarrayWindow(test.nums)
+		if sum != test.sum || window != test.window {
+			t.Errorf("MaxSubarrayWindow(%v) = %d %v, want %d %v", test.nums, sum, window, test.sum, test.window)
+		}
+	}
+}
+
+// TestMaxProductSubarray 已知答案，包含负数翻转和 0 截断
+func TestMaxProductSubarray(t *testing.T) {
+	tests := []struct {
+		nums    []int
+		product int
+		window  Window
+	}{
+		{[]int{2, 3, -2, 4}, 6, Window{0, 1}},
+		{[]int{-2, 0, -1}, 0, Window{1, 1}},
+		{[]int{-4}, -4, Window{0, 0}},
+		{[]int{-2, -3, -4}, 12, Window{1, 2}},
+		{[]int{-2, 3, -4}, 24, Window{0, 2}},
+	}
+	for _, test := range tests {
+		product, window := MaxProductSubarray(test.nums)
+		if product != test.product || window != test.window {
+			t.Errorf("MaxProductSubarray(%v) = %d %v, want %d %v", test.nums, product, window, test.product, test.window)
+		}
+	}
+}
+
+// TestMaxProfit 几种股票买卖的已知答案，单日价格和一直下跌时没有交易
+func TestMaxProfit(t *testing.T) {
+	tests := []struct {
+		name   string
+		got    TradesResult
+		profit int
+		trades []Trade
+	}{
+		{"k=2", MaxProfitK([]int{3, 2, 6, 5, 0, 3}, 2), 7, []Trade{{1, 2}, {4, 5}}},
+		{"k=1", MaxProfitK([]int{3, 3, 5, 0, 0, 3, 1, 4}, 1), 4, []Trade{{3, 7}}},
+		{"k=0", MaxProfitK([]int{1, 5}, 0), 0, []Trade{}},
+		{"k single day", MaxProfitK([]int{7}, 3), 0, []Trade{}},
+		{"k unlimited", MaxProfitK([]int{1, 2, 3, 4, 5}, 5), 4, []Trade{{0, 4}}},
+		{"cooldown", MaxProfitCooldown([]int{1, 2, 3, 0, 2}), 3, []Trade{{0, 1}, {3, 4}}},
+		{"cooldown falling", MaxProfitCooldown([]int{5, 4, 3}), 0, []Trade{}},
+		{"cooldown single day", MaxProfitCooldown([]int{1}), 0, []Trade{}},
+		{"fee", MaxProfitFee([]int{1, 3, 2, 8, 4, 9}, 2), 8, []Trade{{0, 3}, {4, 5}}},
+		{"fee too high", MaxProfitFee([]int{1, 3, 7}, 10), 0, []Trade{}},
+	}
+	for _, test := range tests {
+		if test.got.MaxProfit != test.profit || !reflect.DeepEqual(test.got.Trades, test.trades) {
+			t.Errorf("%s: got %d %v, want %d %v", test.name, test.got.MaxProfit, test.got.Trades, test.profit, test.trades)
+		}
+	}
+}
+
+// TestSubstringWindows 最长无重复子串和最小覆盖子串，空串和多字节字符按 rune 计算
+func TestSubstringWindows(t *testing.T) {
+	unique := []struct {
+		s      string
+		window Window
+	}{
+		{"abcabcbb", Window{0, 2}},
+		{"bbbbb", Window{0, 0}},
+		{"pwwkew", Window{2, 4}},
+		{"", Window{0, -1}},
+		{"a", Window{0, 0}},
+		{"中文中", Window{0, 1}},
+	}
+	for _, test := range unique {
+		if window := LongestUniqueSubstring(test.s); window != test.window {
+			t.Errorf("LongestUniqueSubstring(%q) = %v, want %v", test.s, window, test.window)
+		}
+	}
+	cover := []struct {
+		s, t   string
+		window Window
+		found  bool
+	}{
+		{"ADOBECODEBANC", "ABC", Window{9, 12}, true},
+		{"a", "a", Window{0, 0}, true},
+		{"a", "aa", Window{}, false},
+		{"aa", "aa", Window{0, 1}, true},
+		{"", "a", Window{}, false},
+	}
+	for _, test := range cover {
+		window, found := MinWindowSubstring(test.s, test.t)
+		if window != test.window || found != test.found {
+			t.Errorf("MinWindowSubstring(%q, %q) = %v %v, want %v %v", test.s, test.t, window, found, test.window, test.found)
+		}
+	}
+}
+
+// TestIntervals 合并、插入区间和会议室的已知答案，包含负坐标和单个区间
+func TestIntervals(t *testing.T) {
+	merge := []struct {
+		in, want []Interval
+	}{
+		{[]Interval{{1, 3}, {2, 6}, {8, 10}, {15, 18}}, []Interval{{1, 6}, {8, 10}, {15, 18}}},
+		{[]Interval{{1, 4}, {4, 5}}, []Interval{{1, 5}}},
+		{[]Interval{{-5, -3}, {-10, -4}}, []Interval{{-10, -3}}},
+		{[]Interval{{2, 2}}, []Interval{{2, 2}}},
+		{[]Interval{}, []Interval{}},
+	}
+	for _, test := range merge {
+		if got := MergeIntervals(test.in); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("MergeIntervals(%v) = %v, want %v", test.in, got, test.want)
+		}
+	}
+
+	intervals, index, merged := InsertInterval([]Interval{{1, 2}, {3, 5}, {6, 7}, {8, 10}, {12, 16}}, Interval{4, 8})
+	if want := []Interval{{1, 2}, {3, 10}, {12, 16}}; !reflect.DeepEqual(intervals, want) || index != 1 || *merged != (Window{1, 3}) {
+		t.Errorf("InsertInterval = %v %d %v, want %v 1 {1 3}", intervals, index, merged, want)
+	}
+	intervals, index, merged = InsertInterval(nil, Interval{-1, 1})
+	if want := []Interval{{-1, 1}}; !reflect.DeepEqual(intervals, want) || index != 0 || merged != nil {
+		t.Errorf("InsertInterval into empty = %v %d %v, want %v 0 nil", intervals, index, merged, want)
+	}
+
+	rooms := []struct {
+		in   []Interval
+		want int
+		peak Interval
+	}{
+		{[]Interval{{0, 30}, {5, 10}, {15, 20}}, 2, Interval{5, 10}},
+		{[]Interval{{7, 10}, {2, 4}}, 1, Interval{2, 4}},
+		{[]Interval{{1, 5}, {5, 10}}, 1, Interval{1, 5}},
+		{[]Interval{{-3, 0}}, 1, Interval{-3, 0}},
+	}
+	for _, test := range rooms {
+		got := MinMeetingRooms(test.in)
+		if got.Rooms != test.want || got.Peak == nil || *got.Peak != test.peak {
+			t.Errorf("MinMeetingRooms(%v) = %d rooms peak %v, want %d peak %v", test.in, got.Rooms, got.Peak, test.want, test.peak)
+		}
+	}
+}
+
+// TestWindowInputValidation 空输入和溢出的乘积在执行前被拒绝
+func TestWindowInputValidation(t *testing.T) {
+	tests := []struct{ name, input string }{
+		{"maxProductSubarray", `{"nums":[]}`},
+		{"maxProductSubarray", `{"nums":[1000,1000,1000,1000,1000,1000,1000]}`},
+		{"buySoldCooldown", `{"prices":[]}`},
+		{"mergeIntervals", `{"intervals":[[3,1]]}`},
+		{"meetingRooms", `{"intervals":[[2,2]]}`},
+		{"insertInterval", `{"intervals":[[1,5],[3,7]],"newInterval":[2,3]}`},
+	}
+	for _, test := range tests {
+		spec, err := Default.Get(test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := spec.Execute(context.Background(), []byte(test.input)); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s %s: err = %v, want ErrInvalidInput", test.name, test.input, err)
+		}
+	}
+}
@@ -2,637 +2,415 @@ package controller
 
 import "C"
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mango/internal/algorithm"
 	"mango/internal/service"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 )
 
 type AlgorithmHandler struct {
-	UserService service.UserService
+	UserService  service.UserService
+	benchService service.AlgorithmBenchmarkService
+	judgeService service.AlgorithmJudgeService
+	runService   service.AlgorithmRunService
+	registry     *algorithm.Registry
+	jobs         *algorithm.JobQueue
 }
 
-func NewAlgorithmHandler(userService service.UserService) *AlgorithmHandler {
+func NewAlgorithmHandler(userService service.UserService, benchService service.AlgorithmBenchmarkService, judgeService service.AlgorithmJudgeService, runService service.AlgorithmRunService) *AlgorithmHandler {
 	return &AlgorithmHandler{
-		UserService: userService,
+		UserService:  storeOrNil("users", userService),
+		benchService: storeOrNil("benchmark history", benchService),
+		judgeService: storeOrNil("judge history", judgeService),
+		runService:   storeOrNil("run history", runService),
+		registry:     algorithm.Default,
+		jobs:         algorithm.NewJobQueue(AsyncWorkers, AsyncQueueSize, AsyncRetainedJobs),
 	}
 }
 
-// Register 注册路由
+// Close 停止异步任务池，正在执行的任务被取消
+func (v *AlgorithmHandler) Close() error {
+	v.jobs.Close()
+	return nil
+}
+
+// BenchmarkTimeout 一次 benchmark 的最长时间，超时返回已完成的规模
+const BenchmarkTimeout = 60 * time.Second
+
+const (
+	// AsyncWorkers 同时执行的异步任务数
+	AsyncWorkers = 4
+	// AsyncQueueSize 最多等待执行的异步任务数，超过时返回 503
+	AsyncQueueSize = 64
+	// AsyncRetainedJobs 保留结果的已结束任务数，更早的任务查询时返回 404
+	AsyncRetainedJobs = 1000
+)
+
+// Register 注册路由，每个已注册的算法对应 POST /algorithm/{name}、/algorithm/{name}/generate 和 /algorithm/{name}/batch
 func (v *AlgorithmHandler) Register(router *gin.RouterGroup) {
 	userRouter := router.Group("/algorithm")
 	{
-		userRouter.POST("/sumUpToTarget", v.sumUpToTarget)
-		userRouter.POST("/sumUpToTargetHashMap", v.sumUpToTargetHashMap)
-		userRouter.POST("/buySold", v.buySold)
-		userRouter.POST("/maxSubarray", v.maxSubarray)
-		userRouter.POST("/findMinimumInRotatedArray", v.findMinimumInRotatedArray)
-		userRouter.POST("/containerWithMostWater", v.containerWithMostWater)
-		userRouter.POST("/numberOfOneBits", v.numberOfOneBits)
-		userRouter.POST("/climbStairs", v.climbStairs)
-		userRouter.POST("/coinCharge", v.coinCharge)
-		userRouter.POST("/longestIncreasingSubsequence", v.longestIncreasingSubsequence)
-		userRouter.POST("/twoStringLongestCommonSubsequence", v.twoStringLongestCommonSubsequence)
-		userRouter.POST("/backTrack", v.backTrack)
-		userRouter.POST("/rubHouse", v.rubHouse)
-		userRouter.POST("/decodeLetter", v.decodeLetter)
-		userRouter.POST("/cloneGraph", v.cloneGraph)
-		userRouter.POST("/courseTopology", v.courseTopology)
-
+		userRouter.GET("", v.catalog)
+		userRouter.POST("/verify", v.verify)
+		userRouter.POST("/benchmark", v.benchmark)
+		userRouter.GET("/benchmark", v.benchmarkHistory)
+		userRouter.POST("/judge", v.judge)
+		userRouter.GET("/judge/tests", v.testCases)
+		userRouter.POST("/judge/tests", v.createTestCase)
+		userRouter.GET("/judge/submissions", v.submissions)
+		userRouter.GET("/runs", v.runs)
+		userRouter.GET("/jobs/:id", v.job)
+		userRouter.GET("/jobs/:id/events", v.jobEvents)
+		for _, spec := range v.registry.List() {
+			userRouter.POST("/"+spec.Name, v.run(spec))
+			userRouter.POST("/"+spec.Name+"/generate", v.generate(spec))
+			userRouter.POST("/"+spec.Name+"/batch", v.batch(spec))
+		}
 	}
 }
 
-type SumUpToTargetRequest struct {
-	Target int `json:"target" form:"target" binding:"required"`
+// MaxBodyBytes 算法接口请求体的上限，超过时返回 413
+const MaxBodyBytes = 8 << 20
+
+// AlgorithmResponse 算法接口统一返回结构
+type AlgorithmResponse struct {
+	Algorithm string                 `json:"algorithm"`
+	Result    interface{}            `json:"result,omitempty"`
+	Trace     *algorithm.Trace       `json:"trace,omitempty"`
+	Budget    *algorithm.BudgetUsage `json:"budget,omitempty"`
+	Error     string                 `json:"error,omitempty"`
+	RunID     uint                   `json:"runId,omitempty"`
+	ReplayOf  uint                   `json:"replayOf,omitempty"`
 }
 
-/*
-given an array of integers, return indexes of two numbers that sum up to the request target
-array :=[]int{1,3,4,5}  target := 6  return 0,3
-*/
-func (v *AlgorithmHandler) sumUpToTarget(c *gin.Context) {
-	var request SumUpToTargetRequest
-	err := c.ShouldBind(&request)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, nil)
-		return
-	}
-	givenArray := []int{1, 3, 4, 5, 8}
-	for i := 0; i < len(givenArray); i++ {
-		for j := i + 1; j < len(givenArray); j++ {
-			if givenArray[i]+givenArray[j] == request.Target {
-				fmt.Printf("find index i:%d and j:%d\n", i, j)
-				c.JSON(http.StatusOK, gin.H{
-					"found":   true,
-					"indices": []int{i, j},
-					"values":  []int{givenArray[i], givenArray[j]},
-				})
-				return
-			}
-		}
-	}
-	c.JSON(http.StatusCreated, nil)
+func algorithmSuccess(c *gin.Context, name string, result interface{}, trace *algorithm.Trace) {
+	c.JSON(http.StatusOK, AlgorithmResponse{Algorithm: name, Result: result, Trace: trace})
 }
 
-/*
-given an array of integers, return indexes of two numbers that sum up to the request target
-array :=[]int{1,3,4,5}  target := 6  return 0,3
-*/
-func (v *AlgorithmHandler) sumUpToTargetHashMap(c *gin.Context) {
-	var request SumUpToTargetRequest
-	err := c.ShouldBind(&request)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, nil)
-		return
-	}
-	givenArray := []int{1, 3, 4, 5, 8}
-	hashMap := make(map[int]int, len(givenArray))
-	for key, value := range givenArray {
-		hashMap[value] = key
-	}
-	for i := 0; i < len(givenArray); i++ {
-		findTarget := request.Target - givenArray[i]
-		if value, exist := hashMap[findTarget]; exist {
-			fmt.Printf("find index i:%d and j:%d\n", i, value)
-			c.JSON(http.StatusOK, gin.H{
-				"found":   true,
-				"indices": []int{i, value},
-				"values":  []int{givenArray[i], givenArray[value]},
-			})
-			return
-		}
-	}
-	c.JSON(http.StatusCreated, nil)
+func algorithmError(c *gin.Context, status int, name string, err error) {
+	c.JSON(status, AlgorithmResponse{Algorithm: name, Error: err.Error()})
 }
 
-/*
-slide window
-given an array  the elements are the price of stock one day, you can only buy and sell once to find
-maximum the profit
+// ErrStoreUnavailable 没有配置数据库，依赖数据库的接口返回 503
+var ErrStoreUnavailable = errors.New("store unavailable: database not configured")
 
-	array :=[]int{7,1,5,3,6,4}   the profit is 5(1,6)
-*/
-func (v *AlgorithmHandler) buySold(c *gin.Context) {
-	givenArray := []int{7, 1, 5, 3, 6, 4}
-	var leftPoint, rightPoint int
-	var maxProfit int
-	for key, _ := range givenArray {
-		rightPoint = key
-		if givenArray[leftPoint] < givenArray[rightPoint] {
-			maxProfit = max(maxProfit, givenArray[rightPoint]-givenArray[leftPoint])
-		} else {
-			leftPoint = rightPoint
-		}
+// storeOrNil 服务配置了数据库时原样返回，否则记录一次警告并返回 nil；
+// 处理器中为 nil 的服务跳过保存，查询时返回 ErrStoreUnavailable
+func storeOrNil[S interface{ Available() bool }](store string, s S) S {
+	var none S
+	if any(s) == nil {
+		return none
 	}
-	c.JSON(http.StatusOK, gin.H{
-		"maxProfit": maxProfit,
-		"leftPoint": leftPoint,
-	})
+	if !s.Available() {
+		logrus.Warnf("algorithm %s disabled: database not configured", store)
+		return none
+	}
+	return s
 }
 
-/*
-leetcode 53
-slide window
-given an integer array  ,find the contiguous subarray that has the largest sum and return the sum
-
-	array :=[]int{1, -4, 2, 3, 6, 4}   the profit is 2, 3, 6, 4 = 13
-*/
-func (v *AlgorithmHandler) maxSubarray(c *gin.Context) {
-	givenArray := []int{1, -4, 2, 3, 6, 4}
-	var sum int
-
-	for _, value := range givenArray {
-		sum += value
-		if sum <= 0 {
-			sum = 0
-		}
+// MaxPageSize 列表接口每页最多的条数
+const MaxPageSize = 100
 
-	}
-	c.JSON(http.StatusOK, gin.H{
-		"maxProfit": sum,
-	})
+// pageQuery 解析 ?page 和 ?page_size（默认 10），page 至少为 1，page_size 限制在 [1, MaxPageSize]
+func pageQuery(c *gin.Context) (page, pageSize int) {
+	page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ = strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	return max(page, 1), min(max(pageSize, 1), MaxPageSize)
 }
 
-/*
-leetcode 153 二分查找
-假设一个按照升序排列的数组在某个点上进行了旋转（例如，数组 [0,1,2,4,5,6,7] 可能变成 [4,5,6,7,0,1,2]）。请找出其中最小的元素。
-*/
-func (v *AlgorithmHandler) findMinimumInRotatedArray(c *gin.Context) {
-	givenArray := []int{4, 5, 6, 7, 0, 1, 2}
-	var left int
-	var right = len(givenArray) - 1
-
-	for left < right {
-		mid := left + (right-left)/2
-		// 如果中间元素大于最右元素，说明最小值在右半部分
-		if givenArray[mid] > givenArray[right] {
-			left = mid + 1
-		} else {
-			// 否则最小值在左半部分（包括中间元素）
-			right = mid
-		}
+// algorithmStatus 把算法错误映射为 HTTP 状态码
+func algorithmStatus(err error) int {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrStoreUnavailable):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, algorithm.ErrInvalidInput):
+		return http.StatusBadRequest
+	case errors.Is(err, algorithm.ErrNotFound), errors.Is(err, algorithm.ErrJobNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, algorithm.ErrBudgetExceeded):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, algorithm.ErrQueueFull), errors.Is(err, algorithm.ErrQueueClosed):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
 	}
+}
 
+// catalog 返回所有算法的元数据、输入 schema 和示例，以及各题生成器支持的 flavor
+func (v *AlgorithmHandler) catalog(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
-		"maxProfit": givenArray[left],
+		"algorithms": v.registry.List(),
+		"generators": v.registry.Generators(),
 	})
 }
 
-/*
-leetcode 11 双指针
-container with the most water
-双指针 左指针在最左，右指针在最右，比较两边指针值的高度，如果左边高度比右边高则移动右指针，相反则移动左指针。移动指针宽度肯定变小，
-移动高度低的 有可能面积会变大，所以要移动高度低的指针
-*/
-func (v *AlgorithmHandler) containerWithMostWater(c *gin.Context) {
-	givenArray := []int{1, 5, 6, 7, 4, 3, 4}
-	var left int
-	var right = len(givenArray) - 1
-	var maxArea int
-	for left < right {
-		if givenArray[left] < givenArray[right] {
-			maxAreaNew := (right - left) * givenArray[left]
-			maxArea = max(maxArea, maxAreaNew)
-			left++
-		} else {
-			maxAreaNew := (right - left) * givenArray[right]
-			maxArea = max(maxArea, maxAreaNew)
-			right--
+// budgetQuery 解析 ?timeoutMs、?maxSteps、?maxMemory（字节），未指定的项使用默认预算，超过 limit 的项降为 limit
+func budgetQuery(c *gin.Context, limit algorithm.Budget) (algorithm.Budget, error) {
+	var values [3]int64
+	for i, key := range []string{"timeoutMs", "maxSteps", "maxMemory"} {
+		raw := c.Query(key)
+		if raw == "" {
+			continue
 		}
-
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || value < 0 {
+			return algorithm.Budget{}, fmt.Errorf("%s must be a non-negative integer, got %q", key, raw)
+		}
+		values[i] = value
 	}
+	return algorithm.Budget{
+		Timeout:   time.Duration(values[0]) * time.Millisecond,
+		MaxSteps:  values[1],
+		MaxMemory: values[2],
+	}.ClampTo(limit), nil
+}
 
-	//brute force
-	var maxAreaBF int
-	for i := 0; i < len(givenArray); i++ {
-		for j := 1; j < len(givenArray); j++ {
-			if givenArray[i] < givenArray[j] {
-				maxAreaNew := (j - i) * givenArray[i]
-				maxAreaBF = max(maxAreaBF, maxAreaNew)
-			} else {
-				maxAreaNew := (j - i) * givenArray[j]
-				maxAreaBF = max(maxAreaBF, maxAreaNew)
+// run 解析请求体并执行对应算法，示例输入见 GET /algorithm；请求体超过 MaxBodyBytes 时返回 413
+// ?trace=true 时返回执行过程，?traceLimit 控制最多记录的帧数
+// 每次执行都有时间、步数和内存预算（见 budgetQuery），返回的 budget 是本次的消耗，超出预算返回 422
+// 每次调用都记录到运行历史（?userId 指定调用用户），返回的 runId 是本次的记录；相同输入已经成功执行过时
+// 直接重放保存的结果，replayOf 是原始记录，?replay=false 或 ?trace=true 时总是重新执行
+// 不完整的结果（回溯搜索达到解数上限或超时）只记录不重放；没有配置数据库时不记录，指定 ?userId 返回 503
+// ?async=true 时输入校验通过后放入任务池并返回 202 和任务 id，预算上限放宽到 MaxAsyncBudget，
+// 结果见 GET /algorithm/jobs/{id}，进度见 GET /algorithm/jobs/{id}/events
+func (v *AlgorithmHandler) run(spec *algorithm.Spec) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxBodyBytes)
+		body, err := c.GetRawData()
+		if err != nil {
+			algorithmError(c, algorithmStatus(fmt.Errorf("%w: %w", algorithm.ErrInvalidInput, err)), spec.Name, err)
+			return
+		}
+		async := c.Query("async") == "true"
+		limit := algorithm.MaxBudget
+		if async {
+			limit = algorithm.MaxAsyncBudget
+		}
+		request, budget, err := v.runOptions(c, spec, limit)
+		if err != nil {
+			algorithmError(c, algorithmStatus(err), spec.Name, err)
+			return
+		}
+		if response, err := v.decode(c.Request.Context(), &request, body); err != nil {
+			c.JSON(algorithmStatus(err), response)
+			return
+		}
+		if async {
+			job, err := v.jobs.Submit(spec.Name, budget, func(ctx context.Context) (interface{}, error) {
+				return v.execute(ctx, request)
+			})
+			if err != nil {
+				algorithmError(c, algorithmStatus(err), spec.Name, err)
+				return
 			}
+			c.JSON(http.StatusAccepted, AlgorithmResponse{Algorithm: spec.Name, Result: job.Status()})
+			return
 		}
+		ctx, _, cancel := algorithm.WithBudget(c.Request.Context(), budget)
+		defer cancel()
+		response, err := v.execute(ctx, request)
+		if err != nil {
+			c.JSON(algorithmStatus(err), response)
+			return
+		}
+		c.JSON(http.StatusOK, response)
 	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"maxArea":   maxArea,
-		"maxAreaBF": maxAreaBF,
-	})
 }
 
-/*
-leetcode 191
-given an integer and  return the the number of 1 bits
-1:dynamic programming
-2:逐位检查
-*/
-func (v *AlgorithmHandler) numberOfOneBits(c *gin.Context) {
-	//1：dynamic programming n&(n-1) 将数字最右边的1变成0
-	dynamic := 23
-	var number int
-	for dynamic != 0 {
-		dynamic = dynamic & (dynamic - 1)
-		number++
+// runOptions 解析算法接口共用的查询参数：预算（上限为 limit）、?userId、?trace、?traceLimit、?replay
+// 返回的错误包装了 ErrInvalidInput 或 ErrNotFound
+func (v *AlgorithmHandler) runOptions(c *gin.Context, spec *algorithm.Spec, limit algorithm.Budget) (runRequest, algorithm.Budget, error) {
+	request := runRequest{spec: spec, replay: c.Query("replay") != "false"}
+	budget, err := budgetQuery(c, limit)
+	if err != nil {
+		return request, budget, fmt.Errorf("%w: %v", algorithm.ErrInvalidInput, err)
 	}
-
-	//2：逐位检查
-	request := 23
-	var count int
-	for request > 0 {
-		if request&1 != 0 {
-			count++
+	if request.userID, err = v.runUser(c); err != nil {
+		return request, budget, err
+	}
+	if c.Query("trace") == "true" {
+		if !spec.Traceable {
+			return request, budget, fmt.Errorf("%w: %s does not support trace", algorithm.ErrInvalidInput, spec.Name)
 		}
-		//向又移动一位
-		request >>= 1
+		request.traceLimit, _ = strconv.Atoi(c.Query("traceLimit"))
+		request.trace = true
 	}
-	c.JSON(http.StatusOK, gin.H{
-		"count":  count,
-		"number": number,
-	})
+	return request, budget, nil
 }
 
-/*
-leetcode 70 climb stairs
-
-	it takes n steps to reach the top  ,every time  you can climb either one or two steps  ,how many
-
-ways can  you climb to  the top?
-*/
-func (v *AlgorithmHandler) climbStairs(c *gin.Context) {
-	/*1：fn(n) 是n个台阶 有多少种方法的函数
-		fn(n) = fn(n-1)+fn(n-2)
-		fn(n-1) = fn(n-2)-fn(n-3)
-		fn(n-2) = fn(n-3)-fn(n-4)
-	    如果用递归计算 会存在很多重复的计算 时间复杂度大概为 2^n*/
-	result := fibonacci(5)
-
-	//2:可以用dynamic programming 处理  extra space O(n)  runtime O(N)
-	//fn(1)=1 fn(2)=2 fn(3)=f(1)+fn(3) fn(n)=fn(n-1)+fn(n-2)
-	n := 5
-	dp := make([]int, n+1)
-	dp[1] = 1
-	dp[2] = 2
-	for i := 3; i <= n; i++ {
-		dp[i] = dp[i-1] + dp[i-2]
+// decode 校验输入并填入 request，失败时记录到运行历史，返回的 response 带有错误信息
+func (v *AlgorithmHandler) decode(ctx context.Context, request *runRequest, body []byte) (AlgorithmResponse, error) {
+	name := request.spec.Name
+	input, err := request.spec.Decode(body)
+	if err != nil {
+		run := v.saveRun(ctx, request.userID, name, body, nil, false, 0, err)
+		return AlgorithmResponse{Algorithm: name, Error: err.Error(), RunID: run.ID}, err
 	}
-	dynamic := dp[n]
-
-	//3:还有一种空间复杂度为 O(1)
-	A := 1
-	B := 2
-	for i := 3; i <= n; i++ {
-		A, B = B, (A + B)
+	// 规范化后字段顺序和空白不同的相同输入得到相同的记录
+	canonical, err := json.Marshal(input)
+	if err != nil {
+		return AlgorithmResponse{Algorithm: name, Error: err.Error()}, err
 	}
-	c.JSON(http.StatusOK, gin.H{
-		"fibonacci": result,
-		"dynamic":   dynamic,
-		"B":         B,
-	})
+	request.input, request.canonical = input, canonical
+	return AlgorithmResponse{Algorithm: name}, nil
 }
-func fibonacci(n int) int {
-	if n <= 1 {
-		return n
-	}
-	if n <= 2 {
-		return n
-	}
-	return fibonacci(n-1) + fibonacci(n-2)
+
+// runRequest 校验通过的一次算法调用
+type runRequest struct {
+	spec       *algorithm.Spec
+	userID     uint
+	input      interface{}
+	canonical  []byte
+	trace      bool
+	traceLimit int
+	replay     bool
 }
 
-/*
-leetcode 322 背包问题  动态规划
-动态规划：考虑所有可能性，可以回头看，类似走迷宫，一般会记录中间状态，一点是最优解）
-贪心算法：一条路走到黑，不一定是最优解，只考虑当前最优解，一般不需要记录中间状态。可以从后往前推，也可以从前往后推，有终止值
-一个切片 里边包含 各种整数的零钱，给一个值amount，返回相加等于m的最少元素个数
-想要找到fn(m)的最小值，最后一步一定要取一个零钱 这个零钱可能是任何一个
-fn(m) = min(fn(m),fn(m-1)+1,fn(m-2)+1,....) 知道对应的最小值就可以得出结果
-*/
-func (v *AlgorithmHandler) coinCharge(c *gin.Context) {
-	amount := 10
-	dp := make([]int, amount+1)
-	for i := range dp {
-		dp[i] = amount + 1
-	}
-	dp[0] = 0
-	var charge = []int{1, 2, 5, 2, 5, 10}
-	for i := 1; i < len(dp); i++ {
-		for _, value := range charge {
-			if i >= value {
-				dp[i] = min(dp[i], dp[amount-value]+1)
-			}
+// execute 重放或执行一次调用并记录到运行历史，ctx 中需要带有预算，同步调用和异步任务共用
+// 失败时返回的 response 带有错误信息和预算消耗
+func (v *AlgorithmHandler) execute(ctx context.Context, request runRequest) (AlgorithmResponse, error) {
+	spec, meter := request.spec, algorithm.MeterFrom(ctx)
+	// 运行历史在预算的截止时间之后仍然要保存
+	history := context.WithoutCancel(ctx)
+	if !request.trace && request.replay {
+		if run := v.replayRun(history, request.userID, spec.Name, request.canonical); run != nil {
+			return AlgorithmResponse{Algorithm: spec.Name, Result: json.RawMessage(run.Output), RunID: run.ID, ReplayOf: run.ReplayOf}, nil
 		}
 	}
-	// 检查是否能凑齐目标金额
-	if dp[amount] > amount {
-		dp[amount] = -1
+	var trace *algorithm.Trace
+	if request.trace {
+		trace = algorithm.NewTrace(request.traceLimit)
+		ctx = algorithm.WithTrace(ctx, trace)
 	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"coinCharge": dp[amount],
-	})
-}
-
-/*
-leetcode 300   动态规划
-given an integer array nums, find the longest increasing subsequence
-Input: nums = [10,9,2,5,3,7,101,18]
-Output: 4   [2,3,7,101]
-*/
-func (v *AlgorithmHandler) longestIncreasingSubsequence(c *gin.Context) {
-	array := []int{10, 9, 2, 5, 3, 7, 101, 18}
-	var fn = make(map[int]int, len(array))
-	for k, _ := range array {
-		fn[k] = 1
+	start := time.Now()
+	result, err := spec.Run(ctx, request.input)
+	elapsed := time.Since(start)
+	if err != nil {
+		run := v.saveRun(history, request.userID, spec.Name, request.canonical, nil, false, elapsed, err)
+		return AlgorithmResponse{Algorithm: spec.Name, Budget: meter.Usage(), Error: err.Error(), RunID: run.ID}, err
 	}
-	var maxLength int
-	for i := 0; i < len(array); i++ {
-		for j := 0; j <= i; j++ {
-			if array[i] > array[j] {
-				fn[i] = max(fn[i], fn[j]+1)
-			}
-		}
-		maxLength = max(maxLength, fn[i])
+	output, err := json.Marshal(result)
+	if err != nil {
+		return AlgorithmResponse{Algorithm: spec.Name, Error: err.Error()}, err
 	}
-	c.JSON(http.StatusOK, gin.H{
-		"longestIncreasingSubsequence": maxLength,
-	})
+	run := v.saveRun(history, request.userID, spec.Name, request.canonical, output, partialResult(result), elapsed, nil)
+	return AlgorithmResponse{Algorithm: spec.Name, Result: json.RawMessage(output), Trace: trace, Budget: meter.Usage(), RunID: run.ID}, nil
 }
 
-/*
-leetcode 1143   动态规划
-given two string text1 text2, return the common longest  subsequence length
-Input: text1 = Input: text1 = "abcde", text2 = "ace"
-Output: 3
-假如 text1 的长度是 i  text2的长度是 j
-dp[i][j] 代表 text1第i个字符 text2第j个字符 最长相同的长度
-如果 最后一位相同 那么 dp[i][j]=dp[i-1][j-1]+1
-如果最后一位 不相同 dp[i][j] = max(dp[i][j-1],dp[i-1][j])
-*/
-func (v *AlgorithmHandler) twoStringLongestCommonSubsequence(c *gin.Context) {
-	text1 := "abcde"
-	text2 := "ace"
-	m, n := len(text1), len(text2)
-
-	//初始化值
-	dp := make([][]int, m+1)
-	for i := range dp {
-		dp[i] = make([]int, n+1)
+// verify 随机比较每对实现（暴力解 vs 优化解等），返回第一个不一致的输入及其最小化结果
+// 整个请求共用一份预算（见 budgetQuery），超时的实现对在 error 中说明，已经比较的用例数见 cases
+// example: {"pair":"twoSum","seed":1,"cases":500,"maxSize":50}
+func (v *AlgorithmHandler) verify(c *gin.Context) {
+	var request algorithm.VerifyInput
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		algorithmError(c, http.StatusBadRequest, "verify", err)
+		return
 	}
-	for i := 1; i <= m; i++ {
-		for j := 1; j <= n; j++ {
-			if text1[i-1] == text2[j-1] {
-				dp[i][j] = dp[i-1][j-1] + 1
-			} else {
-				dp[i][j] = max(dp[i][j-1], dp[i-1][j])
-			}
-		}
+	budget, err := budgetQuery(c, algorithm.MaxBudget)
+	if err != nil {
+		algorithmError(c, http.StatusBadRequest, "verify", err)
+		return
 	}
-	c.JSON(http.StatusOK, gin.H{
-		"twoStringLongestCommonSubsequence": dp[m][n],
-	})
-}
-
-/*
-leetcode 39   backTrack
-three key points
-one : 路径path:记录作出选择的路径
-two: 选择要素：当时可以做选择的要素 candidates[start:]
-tree:结束条件：找到结果或者超过结果
-
-			func backtrack(path,candidate){
-			    if 满足条件{
-			       result.add(路径)
-		          return
-			    }
-			    for 选择列表 {
-		            选择要素
-		            backTrack(path,candidate)
-	                撤销选择要素
-		        }
-			}
-*/
-func (v *AlgorithmHandler) backTrack(c *gin.Context) {
-	candidates1 := []int{2, 3, 6, 7}
-	target1 := 7
-	result := [][]int{}
-	path := []int{}
-	//必包函数 必须先声明 再赋值不然 函数内不能调用
-	var backTrackDetail func(start, currentSum int)
-	backTrackDetail = func(start int, currentSum int) {
-		// 终止条件
-		if currentSum == target1 {
-			// 需要深拷贝，否则后续修改会影响结果
-			temp := make([]int, len(path))
-			copy(temp, path)
-			result = append(result, temp)
-			return
-		}
-		if currentSum > target1 {
-			return // 剪枝
-		}
-		for i := start; i < len(candidates1); i++ {
-			//选择元素
-			path = append(path, candidates1[i])
-			//关键：传入 i 而不是 i+1，因为可以重复选择
-			backTrackDetail(i, candidates1[i]+currentSum)
-			path = path[:len(path)-1] //回溯
-		}
+	ctx, meter, cancel := algorithm.WithBudget(c.Request.Context(), budget)
+	defer cancel()
+	reports, err := v.registry.VerifyAll(ctx, request)
+	if err != nil {
+		algorithmError(c, algorithmStatus(err), "verify", err)
+		return
 	}
-	backTrackDetail(0, 0)
-
-	c.JSON(http.StatusOK, gin.H{
-		"backTrack": result,
-	})
-}
-
-/*
-leetcode 198  dynamic programming
-given an integer array ,each term represent the money that the house has , if two near  house were  broken
-the alert system will trigger , return the most money the thief can rub
-*/
-func (v *AlgorithmHandler) rubHouse(c *gin.Context) {
-	house := []int{2, 7, 9, 3, 1}
-	var sum int
-	dp := make([]int, len(house))
-	dp[0] = 2
-	dp[1] = 7
-	for i := 2; i < len(house); i++ {
-		dp[i] = max(dp[i-1], dp[i-2]+house[i])
-		sum = max(sum, dp[i])
+	passed := true
+	for _, report := range reports {
+		passed = passed && report.Passed && report.Error == ""
 	}
-	c.JSON(http.StatusOK, gin.H{
-		"rubHouse": sum,
-	})
+	c.JSON(http.StatusOK, AlgorithmResponse{Algorithm: "verify", Result: gin.H{
+		"passed":  passed,
+		"reports": reports,
+	}, Budget: meter.Usage()})
 }
 
-/*
-leetcode 91  dynamic programming
-given a string which is made of (0-9) number , decode the numbers to letters  such as '1'->A ,'2'->'B' '26'->Z ，
-how many ways it can decode into
-*/
-func (v *AlgorithmHandler) decodeLetter(c *gin.Context) {
-	var text = "226"
-	dp := make([]int, len(text)+1)
-	dp[0] = 1
-	dp[1] = 1
-	for i := 2; i <= len(text); i++ {
-		//singe letter
-		if text[i-1] != '0' {
-			dp[i] += dp[i-1]
+// generate 按规模、取值范围和 flavor 生成随机输入，结果中的 inputs 可以直接作为 POST /algorithm/{name} 的请求体
+// 请求体可以为空，相同的 seed 和参数生成相同的输入
+// example: {"size":8,"flavor":"cyclic","seed":7,"count":3}
+func (v *AlgorithmHandler) generate(spec *algorithm.Spec) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request algorithm.GenerateInput
+		if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+			algorithmError(c, http.StatusBadRequest, spec.Name, err)
+			return
 		}
-		// 双数字解码 - 修正字符转换
-		twoDigit := int(text[i-2]-'0')*10 + int(text[i-1]-'0')
-		if twoDigit >= 10 && twoDigit <= 26 {
-			dp[i] += dp[i-2]
+		result, err := v.registry.Generate(spec.Name, request)
+		if err != nil {
+			algorithmError(c, algorithmStatus(err), spec.Name, err)
+			return
 		}
-
+		algorithmSuccess(c, spec.Name, result, nil)
 	}
-	c.JSON(http.StatusOK, gin.H{
-		"decodeLetter": dp[len(text)],
-	})
-}
-
-/*
-leetcode 133 cloneGraph
-dfs : 深度遍历 主要用递归
-bfs   遍历相邻节点  队列 入队 出队
-*/
-func (v *AlgorithmHandler) cloneGraph(c *gin.Context) {
-	node := createTestGraph()
-	visited := make(map[*Node]*Node, 0)
-	cloneNode := cloneGraphDFS(node, visited)
-	bfs := cloneGraphBFS(node)
-	c.JSON(http.StatusOK, gin.H{
-		"node": len(node.Neighbors),
-		"dfs":  len(cloneNode.Neighbors),
-		"bfs":  len(bfs.Neighbors),
-	})
-}
-
-type Node struct {
-	Val       int
-	Neighbors []*Node
-}
-
-func createTestGraph() *Node {
-	node1 := &Node{Val: 1}
-	node2 := &Node{Val: 2}
-	node3 := &Node{Val: 3}
-	node4 := &Node{Val: 4}
-
-	node1.Neighbors = []*Node{node2, node4}
-	node2.Neighbors = []*Node{node1, node3}
-	node3.Neighbors = []*Node{node2, node4}
-	node4.Neighbors = []*Node{node1, node3}
-
-	return node1
 }
 
-func cloneGraphDFS(node *Node, visited map[*Node]*Node) *Node {
-	//查看是否访问过
-	if value, exist := visited[node]; exist {
-		//返回新的值clone
-		return value
-	}
-	clone := &Node{
-		Val:       node.Val,
-		Neighbors: make([]*Node, 0),
+// benchmark 按多个输入规模测速并估计复杂度，结果按版本保存，?format=table 返回文本表格
+// example: {"name":"climbStairsRecursive","sizes":[10,15,20,25],"release":"v1.2.0"}
+func (v *AlgorithmHandler) benchmark(c *gin.Context) {
+	var request algorithm.BenchInput
+	if err := c.ShouldBindJSON(&request); err != nil {
+		algorithmError(c, http.StatusBadRequest, "benchmark", err)
+		return
 	}
-	// key 为原始值 node ，value 为新clone
-	visited[node] = clone
-	for _, value := range node.Neighbors {
-		neighbors := cloneGraphDFS(value, visited)
-		clone.Neighbors = append(clone.Neighbors, neighbors)
+	bench, err := v.registry.GetBench(request.Name)
+	if err != nil {
+		algorithmError(c, algorithmStatus(err), "benchmark", err)
+		return
 	}
-	return clone
-}
-
-func cloneGraphBFS(node *Node) *Node {
-	visited := make(map[*Node]*Node)
-	//1首先初始化一个值
-	visited[node] = &Node{
-		Val:       node.Val,
-		Neighbors: []*Node{},
+	ctx, cancel := context.WithTimeout(c.Request.Context(), BenchmarkTimeout)
+	defer cancel()
+	report, err := algorithm.RunBench(ctx, bench, request)
+	if err != nil {
+		algorithmError(c, algorithmStatus(err), "benchmark", err)
+		return
 	}
-	//2然后压入队列
-	queue := []*Node{node}
-	for len(queue) > 0 {
-		//3：出队列
-		currentQueue := queue[0]
-		queue = queue[1:]
-		//4：遍历出队列元素 子节点
-		for _, value := range currentQueue.Neighbors {
-			//5：子节点不存在 依次压入队列
-			if _, exist := visited[value]; !exist {
-				queue = append(queue, value)
-				//5：添加访问过的子节点
-				visited[value] = &Node{Val: value.Val, Neighbors: []*Node{}}
-			}
-			//赋值当前节点的 临节点
-			visited[currentQueue].Neighbors = append(visited[currentQueue].Neighbors, &Node{Val: value.Val, Neighbors: []*Node{}})
+	// 没有数据库或保存失败不影响本次结果
+	stored := v.benchService != nil
+	if stored {
+		if _, err := v.benchService.Save(c.Request.Context(), report); err != nil {
+			logrus.Errorf("save benchmark %s: %v", report.Name, err)
+			stored = false
 		}
-
 	}
-	return visited[node]
-}
-
-/*
-leetcode 207 courseTopology  拓扑排序
-本学期有 m 门课程要修，但是课程之前 有限制
-先修课程按数组 prerequisites 给出，
-其中 prerequisites[i] = [ai, bi] ，表示如果要学习课程 ai 则必须先学习课程 bi。b->a
-问 能否学习完 m 门课程
-解题思路：
-1：构建图：构建prerequisites 课程间的关联关系
-2：计算入度：计算每门课程的前置数量
-3：bfs 遍历 ：从入度为0 的课程开始
-4：判断是否有环，无环  能全部学完
-*/
-func (v *AlgorithmHandler) courseTopology(c *gin.Context) {
-	var coursesNum = 4
-	prerequisites := [][]int{{1, 0}, {2, 0}, {3, 1}, {3, 2}}
-	//1构建图
-	graphy := make(map[int][]int, 0)
-	//2:构建入度
-	inDegree := make(map[int]int, 0)
-	for _, value := range prerequisites {
-		// value[1] → value[0]：必须先修value[1]才能修value[0]
-		graphy[value[1]] = append(graphy[value[1]], value[0])
-		inDegree[value[0]]++
+	if c.Query("format") == "table" {
+		c.String(http.StatusOK, report.Table())
+		return
 	}
-	//3: bfs 遍历 ：3从入度为0 的课程开始
-	//初始化队列  把入度为0 的压入队列
-	queue := make([]int, 0)
-	for i := 0; i < coursesNum; i++ {
-		if inDegree[i] == 0 {
-			queue = append(queue, i)
-		}
+	algorithmSuccess(c, "benchmark", gin.H{
+		"report": report,
+		"stored": stored,
+	}, nil)
+}
 
+// benchmarkHistory 查询已保存的 benchmark，可按 name、version 过滤，用于跨版本比较；没有配置数据库时返回 503
+func (v *AlgorithmHandler) benchmarkHistory(c *gin.Context) {
+	if v.benchService == nil {
+		algorithmError(c, algorithmStatus(ErrStoreUnavailable), "benchmark", fmt.Errorf("benchmark history: %w", ErrStoreUnavailable))
+		return
 	}
-	var visited = make([]int, 0)
-	//3: bfs 遍历 ：从入度为0 的课程开始
-	for len(queue) > 0 {
-		//出队列
-		currentQueue := queue[0]
-		visited = append(visited, currentQueue)
-
-		queue = queue[1:]
-		//如果有相邻节点 相邻节点 入度-1后如果 为0 压入
-		if _, exist := graphy[currentQueue]; exist {
-			for _, value := range graphy[currentQueue] {
-				inDegree[value] = inDegree[value] - 1
-				if inDegree[value] == 0 {
-					queue = append(queue, value)
-				}
-			}
-		}
+	page, pageSize := pageQuery(c)
+	benchmarks, err := v.benchService.List(c.Request.Context(), c.Query("name"), c.Query("version"), page, pageSize)
+	if err != nil {
+		algorithmError(c, http.StatusInternalServerError, "benchmark", err)
+		return
 	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"coursesNum": coursesNum,
-		"visited":    len(visited),
-	})
+	algorithmSuccess(c, "benchmark", gin.H{
+		"benchmarks": benchmarks,
+		"available":  v.registry.Benches(),
+	}, nil)
 }
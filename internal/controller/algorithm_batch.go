@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mango/internal/algorithm"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultBatchParallelism 没有指定 ?parallelism 时同时执行的输入数
+	DefaultBatchParallelism = 4
+	// MaxBatchParallelism ?parallelism 的上限
+	MaxBatchParallelism = 32
+	// MaxBatchItems 一次批量调用最多的输入数，超过的部分返回错误并停止读取
+	MaxBatchItems = 100000
+	// MaxBatchBytes 批量接口请求体的上限，超过时返回 413 并停止读取；单个输入的上限是 MaxBodyBytes
+	MaxBatchBytes = 64 << 20
+)
+
+// BatchItemResponse 批量接口每一行的结果，index 是在输入中的位置（从 0 开始），status 是单独调用时的 HTTP 状态码
+type BatchItemResponse struct {
+	Index  int `json:"index"`
+	Status int `json:"status"`
+	AlgorithmResponse
+}
+
+// batchReader 逐个读取 JSON 数组的元素或 NDJSON 的每一行，不需要把整个请求体读进内存
+type batchReader struct {
+	dec   *json.Decoder
+	array bool
+}
+
+// newBatchReader 第一个非空白字符是 '[' 时按 JSON 数组读取，否则按 NDJSON 读取
+func newBatchReader(r io.Reader) (*batchReader, error) {
+	br := bufio.NewReader(r)
+	for {
+		b, err := br.Peek(1)
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: empty body", algorithm.ErrInvalidInput)
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			_, _ = br.ReadByte()
+			continue
+		}
+		reader := &batchReader{dec: json.NewDecoder(br), array: b[0] == '['}
+		if reader.array {
+			// 读掉开头的 '['
+			if _, err := reader.dec.Token(); err != nil {
+				return nil, fmt.Errorf("%w: %v", algorithm.ErrInvalidInput, err)
+			}
+		}
+		return reader, nil
+	}
+}
+
+// next 下一个输入，读完时返回 io.EOF，JSON 不合法时返回的错误包装了 ErrInvalidInput，
+// 请求体超过上限时还包装了 *http.MaxBytesError
+func (r *batchReader) next() (json.RawMessage, error) {
+	if r.array && !r.dec.More() {
+		if _, err := r.dec.Token(); err != nil {
+			return nil, fmt.Errorf("%w: %v", algorithm.ErrInvalidInput, err)
+		}
+		return nil, io.EOF
+	}
+	var raw json.RawMessage
+	if err := r.dec.Decode(&raw); err != nil {
+		if errors.Is(err, io.EOF) && !r.array {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("%w: %w", algorithm.ErrInvalidInput, err)
+	}
+	return raw, nil
+}
+
+// parallelismQuery 解析 ?parallelism，为空时使用 DefaultBatchParallelism
+func parallelismQuery(c *gin.Context) (int, error) {
+	raw := c.Query("parallelism")
+	if raw == "" {
+		return DefaultBatchParallelism, nil
+	}
+	parallelism, err := strconv.Atoi(raw)
+	if err != nil || parallelism < 1 || parallelism > MaxBatchParallelism {
+		return 0, fmt.Errorf("%w: parallelism must be within [1, %d], got %q", algorithm.ErrInvalidInput, MaxBatchParallelism, raw)
+	}
+	return parallelism, nil
+}
+
+// batch 对请求体中的每个输入执行算法，请求体是输入的 JSON 数组或 NDJSON（每行一个输入）
+// 以 NDJSON 按输入的顺序流式返回每个输入的结果，单个输入失败时该行带有 error 和 status，不影响其他输入
+// 最多 ?parallelism 个输入同时执行，查询参数与单个调用相同，预算对每个输入单独计算；
+// 请求体的 JSON 本身不合法或超过 MaxBatchBytes 时返回一行错误后停止，单个输入超过 MaxBodyBytes 时该行返回 413
+// example: [{"nums":[3,2,1,5,6,4],"k":2},{"nums":[1],"k":1}]
+func (v *AlgorithmHandler) batch(spec *algorithm.Spec) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parallelism, err := parallelismQuery(c)
+		if err != nil {
+			algorithmError(c, algorithmStatus(err), spec.Name, err)
+			return
+		}
+		request, budget, err := v.runOptions(c, spec, algorithm.MaxBudget)
+		if err != nil {
+			algorithmError(c, algorithmStatus(err), spec.Name, err)
+			return
+		}
+		reader, err := newBatchReader(http.MaxBytesReader(c.Writer, c.Request.Body, MaxBatchBytes))
+		if err != nil {
+			algorithmError(c, algorithmStatus(err), spec.Name, err)
+			return
+		}
+		// HTTP/1.1 默认在开始写响应后不能再读请求体，边读边写需要全双工
+		_ = http.NewResponseController(c.Writer).EnableFullDuplex()
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Status(http.StatusOK)
+
+		ctx := c.Request.Context()
+		// pending 按输入顺序排列每个输入的结果通道，容量限制了领先于输出的输入数
+		pending := make(chan chan BatchItemResponse, parallelism)
+		go func() {
+			defer close(pending)
+			running := make(chan struct{}, parallelism)
+			for index := 0; ; index++ {
+				raw, err := reader.next()
+				if errors.Is(err, io.EOF) {
+					return
+				}
+				if err == nil && index == MaxBatchItems {
+					err = fmt.Errorf("%w: at most %d items per batch", algorithm.ErrInvalidInput, MaxBatchItems)
+				}
+				out := make(chan BatchItemResponse, 1)
+				select {
+				case pending <- out:
+				case <-ctx.Done():
+					return
+				}
+				if err != nil {
+					out <- BatchItemResponse{Index: index, Status: algorithmStatus(err), AlgorithmResponse: AlgorithmResponse{Algorithm: spec.Name, Error: err.Error()}}
+					return
+				}
+				// 单个输入超过上限时只有这一行返回 413，继续读取后面的输入
+				if len(raw) > MaxBodyBytes {
+					err = fmt.Errorf("item is %d bytes: %w", len(raw), &http.MaxBytesError{Limit: MaxBodyBytes})
+					out <- BatchItemResponse{Index: index, Status: algorithmStatus(err), AlgorithmResponse: AlgorithmResponse{Algorithm: spec.Name, Error: err.Error()}}
+					continue
+				}
+				running <- struct{}{}
+				go func(index int, raw json.RawMessage) {
+					defer func() { <-running }()
+					out <- v.batchItem(ctx, request, budget, index, raw)
+				}(index, raw)
+			}
+		}()
+
+		encoder := json.NewEncoder(c.Writer)
+		for out := range pending {
+			if err := encoder.Encode(<-out); err != nil {
+				// 客户端已经断开，继续取出结果让生产者退出
+				continue
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// batchItem 校验并执行一个输入，request 按值传入，每个输入有独立的预算
+// 在单独的 goroutine 中执行，实现 panic 时该行返回 500，不影响其他输入和整个进程
+func (v *AlgorithmHandler) batchItem(ctx context.Context, request runRequest, budget algorithm.Budget, index int, raw json.RawMessage) (item BatchItemResponse) {
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Errorf("batch %s item %d panicked: %v", request.spec.Name, index, r)
+			item = BatchItemResponse{Index: index, Status: http.StatusInternalServerError, AlgorithmResponse: AlgorithmResponse{
+				Algorithm: request.spec.Name, Error: fmt.Sprintf("item %d panicked: %v", index, r)}}
+		}
+	}()
+	item = BatchItemResponse{Index: index, Status: http.StatusOK}
+	response, err := v.decode(ctx, &request, raw)
+	if err == nil {
+		runCtx, _, cancel := algorithm.WithBudget(ctx, budget)
+		response, err = v.execute(runCtx, request)
+		cancel()
+	}
+	if err != nil {
+		item.Status = algorithmStatus(err)
+	}
+	item.AlgorithmResponse = response
+	return item
+}
@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// spaces 无限的空白，用来构造超过 MaxBatchBytes 的请求体而不占用内存
+type spaces struct{}
+
+func (spaces) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = ' '
+	}
+	return len(p), nil
+}
+
+// postBatch 调用批量接口，返回状态码和每一行的结果
+func postBatch(t *testing.T, router *gin.Engine, path string, body io.Reader) (int, []BatchItemResponse) {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, body))
+	var items []BatchItemResponse
+	scanner := bufio.NewScanner(recorder.Body)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var item BatchItemResponse
+		if err := json.Unmarshal(scanner.Bytes(), &item); err != nil {
+			t.Fatalf("%s: line %d: %v: %s", path, len(items), err, scanner.Bytes())
+		}
+		items = append(items, item)
+	}
+	return recorder.Code, items
+}
+
+// TestBatch 并行执行时按输入顺序返回，与单个调用的结果相同；单个输入的错误只影响该行
+func TestBatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	NewAlgorithmHandler(nil, nil, nil, nil).Register(router.Group("/api"))
+
+	var inputs []string
+	for n := 1; n <= 40; n++ {
+		inputs = append(inputs, fmt.Sprintf(`{"k":%d,"n":%d}`, 1+n%4, n))
+	}
+	status, items := postBatch(t, router, "/api/algorithm/combinationSum3/batch?parallelism=8", strings.NewReader("["+strings.Join(inputs, ",")+"]"))
+	if status != http.StatusOK || len(items) != len(inputs) {
+		t.Fatalf("status %d, %d lines, want 200 and %d lines", status, len(items), len(inputs))
+	}
+	for i, item := range items {
+		_, single := postRun(t, router, "/api/algorithm/combinationSum3", inputs[i])
+		if item.Index != i || item.Status != http.StatusOK || !reflect.DeepEqual(item.Result, single.Result) {
+			t.Errorf("line %d: index %d status %d result %v, want %v", i, item.Index, item.Status, item.Result, single.Result)
+		}
+	}
+
+	// 第二个输入不满足约束，单个输入超过 MaxBodyBytes，都不影响后面的输入
+	tooLarge := `{"k":3,"n":9,"padding":"` + strings.Repeat("x", MaxBodyBytes) + `"}`
+	body := strings.Join([]string{`{"k":3,"n":9}`, `{"k":0,"n":9}`, tooLarge, `{"k":2,"n":5}`}, "\n")
+	status, items = postBatch(t, router, "/api/algorithm/combinationSum3/batch?parallelism=2", strings.NewReader(body))
+	want := []int{http.StatusOK, http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusOK}
+	if status != http.StatusOK || len(items) != len(want) {
+		t.Fatalf("status %d, %d lines, want 200 and %d lines", status, len(items), len(want))
+	}
+	for i, item := range items {
+		if item.Index != i || item.Status != want[i] || (item.Status != http.StatusOK) != (item.Error != "") {
+			t.Errorf("line %d: index %d status %d error %q, want status %d", i, item.Index, item.Status, item.Error, want[i])
+		}
+	}
+}
+
+// TestBatchStops 请求体的 JSON 不合法、输入数超过 MaxBatchItems 或请求体超过 MaxBatchBytes 时返回一行错误后停止
+func TestBatchStops(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	NewAlgorithmHandler(nil, nil, nil, nil).Register(router.Group("/api"))
+
+	tests := []struct {
+		name   string
+		body   io.Reader
+		lines  int
+		status int
+	}{
+		{"malformed line", strings.NewReader("{\"k\":3,\"n\":9}\n{\"k\":3,\n{\"k\":2,\"n\":5}\n"), 2, http.StatusBadRequest},
+		{"unterminated array", strings.NewReader(`[{"k":3,"n":9}`), 2, http.StatusBadRequest},
+		{"too many items", strings.NewReader(strings.Repeat("{}\n", MaxBatchItems+1)), MaxBatchItems + 1, http.StatusBadRequest},
+		{"too many bytes", io.MultiReader(strings.NewReader(`{"k":3,"n":9}`), io.LimitReader(spaces{}, MaxBatchBytes+1)), 2, http.StatusRequestEntityTooLarge},
+	}
+	for _, test := range tests {
+		status, items := postBatch(t, router, "/api/algorithm/combinationSum3/batch", test.body)
+		if status != http.StatusOK || len(items) != test.lines {
+			t.Errorf("%s: status %d, %d lines, want 200 and %d lines", test.name, status, len(items), test.lines)
+			continue
+		}
+		if last := items[len(items)-1]; last.Index != test.lines-1 || last.Status != test.status || last.Error == "" {
+			t.Errorf("%s: last line %+v, want status %d", test.name, last, test.status)
+		}
+	}
+
+	if status, _ := postBatch(t, router, "/api/algorithm/combinationSum3/batch?parallelism=0", strings.NewReader(`[]`)); status != http.StatusBadRequest {
+		t.Errorf("parallelism=0: status %d, want 400", status)
+	}
+	if status, items := postBatch(t, router, "/api/algorithm/combinationSum3/batch", strings.NewReader(`[]`)); status != http.StatusOK || len(items) != 0 {
+		t.Errorf("empty array: status %d, %d lines", status, len(items))
+	}
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobProgressInterval SSE 推送任务进度的间隔
+const JobProgressInterval = 500 * time.Millisecond
+
+// job 查询异步任务的状态，结束后 result 与同步调用的返回相同
+func (v *AlgorithmHandler) job(c *gin.Context) {
+	job, err := v.jobs.Get(c.Param("id"))
+	if err != nil {
+		algorithmError(c, algorithmStatus(err), "jobs", err)
+		return
+	}
+	algorithmSuccess(c, "jobs", job.Status(), nil)
+}
+
+// jobEvents 以 SSE 推送异步任务的进度：执行中每隔 JobProgressInterval 发送一次 message（状态快照），
+// 结束时成功发送 message、失败发送 error，最后发送 close 并断开
+func (v *AlgorithmHandler) jobEvents(c *gin.Context) {
+	job, err := v.jobs.Get(c.Param("id"))
+	if err != nil {
+		algorithmError(c, algorithmStatus(err), "jobs", err)
+		return
+	}
+	c.Writer.Header().Set("Content-Type", "text/event-stream")
+	c.Writer.Header().Set("Cache-Control", "no-cache")
+	c.Writer.Header().Set("Connection", "keep-alive")
+	flusher, _ := c.Writer.(http.Flusher)
+
+	StreamSuccessResponse(c, job.Status())
+	flusher.Flush()
+
+	ticker := time.NewTicker(JobProgressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-job.Done():
+			status := job.Status()
+			if job.Err() != nil {
+				StreamErrorResponse(c, status)
+			} else {
+				StreamSuccessResponse(c, status)
+			}
+			StreamCloseResponse(c, status.State)
+			flusher.Flush()
+			return
+		case <-ticker.C:
+			StreamSuccessResponse(c, job.Status())
+			flusher.Flush()
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
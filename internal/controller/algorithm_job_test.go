@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"mango/internal/algorithm"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// submitJob 以 ?async=true 调用算法接口，返回任务 id
+func submitJob(t *testing.T, router *gin.Engine, path, body string) string {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path+"?async=true", strings.NewReader(body)))
+	var response struct {
+		Result algorithm.JobStatus `json:"result"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil || recorder.Code != http.StatusAccepted || response.Result.ID == "" {
+		t.Fatalf("%s: status %d: %s", path, recorder.Code, recorder.Body)
+	}
+	return response.Result.ID
+}
+
+// TestJobEvents 任务结束后事件流依次是状态快照和 close，查询接口返回同步调用的结果
+func TestJobEvents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := NewAlgorithmHandler(nil, nil, nil, nil)
+	defer handler.Close()
+	router := gin.New()
+	handler.Register(router.Group("/api"))
+
+	id := submitJob(t, router, "/api/algorithm/combinationSum3", `{"k":3,"n":9}`)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/algorithm/jobs/"+id+"/events", nil))
+	events := recorder.Body.String()
+	if recorder.Header().Get("Content-Type") != "text/event-stream" || !strings.HasPrefix(events, "event:message") ||
+		!strings.HasSuffix(events, "event:close\ndata:"+algorithm.JobSucceeded+"\n\n") {
+		t.Fatalf("events:\n%s", events)
+	}
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/algorithm/jobs/"+id, nil))
+	var response struct {
+		Result struct {
+			State  string `json:"state"`
+			Result struct {
+				Result struct {
+					Count int `json:"count"`
+				} `json:"result"`
+			} `json:"result"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil || response.Result.State != algorithm.JobSucceeded || response.Result.Result.Result.Count != 3 {
+		t.Fatalf("job: %s", recorder.Body)
+	}
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/algorithm/jobs/job_unknown/events", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("unknown job events: status %d, want 404", recorder.Code)
+	}
+}
+
+// TestJobEventsClose 客户端断开时事件流结束；handler 关闭时正在执行的任务失败，之后提交返回 503
+func TestJobEventsClose(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := NewAlgorithmHandler(nil, nil, nil, nil)
+	router := gin.New()
+	handler.Register(router.Group("/api"))
+
+	started := make(chan struct{})
+	job, err := handler.jobs.Submit("block", algorithm.DefaultBudget, func(ctx context.Context) (interface{}, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/algorithm/jobs/"+job.ID()+"/events", nil).WithContext(ctx))
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("event stream did not end after the client disconnected")
+	}
+
+	if err := handler.Close(); err != nil {
+		t.Fatal(err)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/algorithm/jobs/"+job.ID()+"/events", nil))
+	if events := recorder.Body.String(); !strings.Contains(events, "event:error") || !strings.HasSuffix(events, "event:close\ndata:"+algorithm.JobFailed+"\n\n") {
+		t.Errorf("events after Close:\n%s", events)
+	}
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/algorithm/combinationSum3?async=true", strings.NewReader(`{"k":3,"n":9}`)))
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf("submit after Close: status %d, want 503: %s", recorder.Code, recorder.Body)
+	}
+}
@@ -0,0 +1,190 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mango/internal/algorithm"
+	"mango/internal/model"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// JudgeRequest 评测请求，input 与 testCaseId 二选一；使用测试用例时 problem 可以省略
+type JudgeRequest struct {
+	Problem    string          `json:"problem" binding:"required_without=TestCaseID"`
+	Input      json.RawMessage `json:"input"`
+	TestCaseID uint            `json:"testCaseId"`
+	Answer     json.RawMessage `json:"answer" binding:"required"`
+	UserID     uint            `json:"userId"`
+}
+
+// TestCaseRequest 新增评测用例请求
+type TestCaseRequest struct {
+	Problem string          `json:"problem" binding:"required"`
+	Input   json.RawMessage `json:"input" binding:"required"`
+	Hidden  bool            `json:"hidden"`
+	Note    string          `json:"note" binding:"max=255"`
+}
+
+// judge 用参考解评测提交的答案，返回 accepted / wrong_answer / invalid_answer 以及期望输出和差异
+// 隐藏用例只返回判定结果，不返回输入、期望输出和差异；每次评测都记录到提交历史，预算参数与算法接口相同
+// example: {"problem":"sumUpToTarget","input":{"nums":[1,3,4,5],"target":6},"answer":{"found":true,"indices":[0,3],"values":[1,5]},"userId":1}
+func (v *AlgorithmHandler) judge(c *gin.Context) {
+	var request JudgeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		algorithmError(c, http.StatusBadRequest, "judge", err)
+		return
+	}
+	if (len(request.Input) > 0) == (request.TestCaseID != 0) {
+		algorithmError(c, http.StatusBadRequest, "judge", errors.New("exactly one of input or testCaseId is required"))
+		return
+	}
+	budget, err := budgetQuery(c, algorithm.MaxBudget)
+	if err != nil {
+		algorithmError(c, http.StatusBadRequest, "judge", err)
+		return
+	}
+	if request.UserID != 0 {
+		if v.UserService == nil {
+			algorithmError(c, algorithmStatus(ErrStoreUnavailable), "judge", fmt.Errorf("user %d: %w", request.UserID, ErrStoreUnavailable))
+			return
+		}
+		if _, err := v.UserService.GetByID(c.Request.Context(), request.UserID); err != nil {
+			algorithmError(c, http.StatusNotFound, "judge", fmt.Errorf("user %d not found", request.UserID))
+			return
+		}
+	}
+	var testCase *model.AlgorithmTestCase
+	if request.TestCaseID != 0 {
+		if v.judgeService == nil {
+			algorithmError(c, algorithmStatus(ErrStoreUnavailable), "judge", fmt.Errorf("test case %d: %w", request.TestCaseID, ErrStoreUnavailable))
+			return
+		}
+		testCase, err = v.judgeService.GetTestCase(c.Request.Context(), request.TestCaseID)
+		if err != nil {
+			algorithmError(c, recordStatus(err), "judge", fmt.Errorf("test case %d: %w", request.TestCaseID, err))
+			return
+		}
+		if request.Problem != "" && request.Problem != testCase.Problem {
+			algorithmError(c, http.StatusBadRequest, "judge", fmt.Errorf("test case %d belongs to %s", testCase.ID, testCase.Problem))
+			return
+		}
+		request.Problem, request.Input = testCase.Problem, json.RawMessage(testCase.Input)
+	}
+
+	ctx, meter, cancel := algorithm.WithBudget(c.Request.Context(), budget)
+	defer cancel()
+	verdict, err := v.registry.Judge(ctx, request.Problem, request.Input, request.Answer)
+	if err != nil {
+		c.JSON(algorithmStatus(err), AlgorithmResponse{Algorithm: "judge", Budget: meter.Usage(), Error: err.Error()})
+		return
+	}
+	// 隐藏用例的输入不写进提交记录，通过 testCaseId 关联，提交历史里同样看不到
+	input := request.Input
+	if testCase != nil && testCase.Hidden {
+		input = nil
+		verdict.Expected, verdict.Diff = nil, nil
+	}
+	// 没有数据库或保存失败不影响本次结果
+	var submission *model.AlgorithmSubmission
+	if v.judgeService != nil {
+		submission, err = v.judgeService.SaveSubmission(c.Request.Context(), request.UserID, request.TestCaseID, input, request.Answer, verdict)
+		if err != nil {
+			logrus.Errorf("save submission for %s: %v", request.Problem, err)
+		}
+	}
+	c.JSON(http.StatusOK, AlgorithmResponse{Algorithm: "judge", Result: gin.H{
+		"verdict":    verdict,
+		"submission": submission,
+		"stored":     submission != nil,
+	}, Budget: meter.Usage()})
+}
+
+// recordStatus 记录不存在返回 404，其他数据库错误返回 500
+func recordStatus(err error) int {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// createTestCase 新增评测用例，输入必须能通过对应算法的校验
+// example: {"problem":"courseTopology","input":{"numCourses":2,"prerequisites":[[1,0]]},"hidden":true,"note":"two courses"}
+func (v *AlgorithmHandler) createTestCase(c *gin.Context) {
+	var request TestCaseRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		algorithmError(c, http.StatusBadRequest, "judge", err)
+		return
+	}
+	spec, err := v.registry.Get(request.Problem)
+	if err != nil {
+		algorithmError(c, algorithmStatus(err), "judge", err)
+		return
+	}
+	if _, err := spec.Decode(request.Input); err != nil {
+		algorithmError(c, algorithmStatus(err), "judge", err)
+		return
+	}
+	if v.judgeService == nil {
+		algorithmError(c, algorithmStatus(ErrStoreUnavailable), "judge", fmt.Errorf("test cases: %w", ErrStoreUnavailable))
+		return
+	}
+	testCase, err := v.judgeService.CreateTestCase(c.Request.Context(), request.Problem, request.Input, request.Hidden, request.Note)
+	if err != nil {
+		algorithmError(c, http.StatusInternalServerError, "judge", err)
+		return
+	}
+	algorithmSuccess(c, "judge", gin.H{"testCase": testCase}, nil)
+}
+
+// testCases 查询评测用例，可按 problem 过滤，checkers 是有专用校验器（接受多种答案）的算法
+func (v *AlgorithmHandler) testCases(c *gin.Context) {
+	if v.judgeService == nil {
+		algorithmError(c, algorithmStatus(ErrStoreUnavailable), "judge", fmt.Errorf("test cases: %w", ErrStoreUnavailable))
+		return
+	}
+	page, pageSize := pageQuery(c)
+	testCases, err := v.judgeService.ListTestCases(c.Request.Context(), c.Query("problem"), page, pageSize)
+	if err != nil {
+		algorithmError(c, http.StatusInternalServerError, "judge", err)
+		return
+	}
+	for i, testCase := range testCases {
+		testCases[i] = publicTestCase(testCase)
+	}
+	algorithmSuccess(c, "judge", gin.H{
+		"testCases": testCases,
+		"checkers":  v.registry.Checkers(),
+	}, nil)
+}
+
+// publicTestCase 隐藏用例只返回 id、算法名等元信息，不返回输入和说明
+func publicTestCase(testCase *model.AlgorithmTestCase) *model.AlgorithmTestCase {
+	if !testCase.Hidden {
+		return testCase
+	}
+	hidden := *testCase
+	hidden.Input, hidden.Note = "", ""
+	return &hidden
+}
+
+// submissions 查询提交历史，可按 user_id、problem、verdict 过滤，最新的在前
+func (v *AlgorithmHandler) submissions(c *gin.Context) {
+	if v.judgeService == nil {
+		algorithmError(c, algorithmStatus(ErrStoreUnavailable), "judge", fmt.Errorf("submissions: %w", ErrStoreUnavailable))
+		return
+	}
+	page, pageSize := pageQuery(c)
+	userID, _ := strconv.ParseUint(c.Query("user_id"), 10, 32)
+	submissions, err := v.judgeService.ListSubmissions(c.Request.Context(), uint(userID), c.Query("problem"), c.Query("verdict"), page, pageSize)
+	if err != nil {
+		algorithmError(c, http.StatusInternalServerError, "judge", err)
+		return
+	}
+	algorithmSuccess(c, "judge", gin.H{"submissions": submissions}, nil)
+}
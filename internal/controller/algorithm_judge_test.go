@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"context"
+	"mango/internal/algorithm"
+	"mango/internal/model"
+	"mango/internal/repository"
+	"mango/internal/service"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// judgeServiceStub 内存里的评测服务，保存提交时原样记录输入
+type judgeServiceStub struct {
+	testCases []*model.AlgorithmTestCase
+}
+
+func (*judgeServiceStub) Close() error    { return nil }
+func (*judgeServiceStub) Available() bool { return true }
+
+func (s *judgeServiceStub) CreateTestCase(ctx context.Context, problem string, input []byte, hidden bool, note string) (*model.AlgorithmTestCase, error) {
+	testCase := &model.AlgorithmTestCase{ID: uint(len(s.testCases) + 1), Problem: problem, Input: string(input), Hidden: hidden, Note: note}
+	s.testCases = append(s.testCases, testCase)
+	return testCase, nil
+}
+
+func (s *judgeServiceStub) GetTestCase(ctx context.Context, id uint) (*model.AlgorithmTestCase, error) {
+	if id == 0 || int(id) > len(s.testCases) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return s.testCases[id-1], nil
+}
+
+func (s *judgeServiceStub) ListTestCases(ctx context.Context, problem string, page, pageSize int) ([]*model.AlgorithmTestCase, error) {
+	return append([]*model.AlgorithmTestCase(nil), s.testCases...), nil
+}
+
+func (s *judgeServiceStub) SaveSubmission(ctx context.Context, userID, testCaseID uint, input, answer []byte, verdict *algorithm.Verdict) (*model.AlgorithmSubmission, error) {
+	return &model.AlgorithmSubmission{ID: 1, UserID: userID, Problem: verdict.Problem, TestCaseID: testCaseID,
+		Input: string(input), Answer: string(answer), Verdict: verdict.Status, Message: verdict.Message}, nil
+}
+
+func (s *judgeServiceStub) ListSubmissions(ctx context.Context, userID uint, problem, verdict string, page, pageSize int) ([]*model.AlgorithmSubmission, error) {
+	return nil, nil
+}
+
+// TestJudgeHiddenTestCase 隐藏用例在用例列表和评测结果里都不暴露输入、说明和期望输出
+func TestJudgeHiddenTestCase(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	judgeService := &judgeServiceStub{}
+	judgeService.CreateTestCase(context.Background(), "sumUpToTarget", []byte(`{"nums":[1,3,4,5],"target":6}`), true, "secret note")
+	router := gin.New()
+	NewAlgorithmHandler(nil, nil, judgeService, nil).Register(router.Group("/api"))
+
+	requests := []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/api/algorithm/judge/tests", nil),
+		httptest.NewRequest(http.MethodPost, "/api/algorithm/judge", strings.NewReader(`{"testCaseId":1,"answer":{"found":false}}`)),
+	}
+	for _, request := range requests {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("%s %s: status %d: %s", request.Method, request.URL, recorder.Code, recorder.Body)
+		}
+		body := recorder.Body.String()
+		for _, secret := range []string{"nums", "secret note", "indices"} {
+			if strings.Contains(body, secret) {
+				t.Fatalf("%s %s exposes %q: %s", request.Method, request.URL, secret, body)
+			}
+		}
+	}
+}
+
+// TestJudgeWithoutDatabase 没有配置数据库时自带输入的评测照常返回，依赖数据库的请求返回 503
+func TestJudgeWithoutDatabase(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := &gorm.DB{}
+	userService := service.NewUserService(repository.NewUserRepository(db))
+	judgeService := service.NewAlgorithmJudgeService(repository.NewAlgorithmTestCaseRepository(db), repository.NewAlgorithmSubmissionRepository(db))
+	router := gin.New()
+	NewAlgorithmHandler(userService, nil, judgeService, nil).Register(router.Group("/api"))
+
+	tests := []struct {
+		method, path, body string
+		status             int
+	}{
+		{http.MethodPost, "/api/algorithm/judge", `{"problem":"sumUpToTarget","input":{"nums":[1,3,4,5],"target":6},"answer":{"found":true,"indices":[0,3],"values":[1,5]}}`, http.StatusOK},
+		{http.MethodPost, "/api/algorithm/judge", `{"testCaseId":1,"answer":{"found":false}}`, http.StatusServiceUnavailable},
+		{http.MethodPost, "/api/algorithm/judge", `{"problem":"sumUpToTarget","input":{"nums":[1],"target":1},"answer":{"found":true},"userId":1}`, http.StatusServiceUnavailable},
+		{http.MethodGet, "/api/algorithm/judge/tests", "", http.StatusServiceUnavailable},
+		{http.MethodGet, "/api/algorithm/judge/submissions", "", http.StatusServiceUnavailable},
+	}
+	for _, test := range tests {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(test.method, test.path, strings.NewReader(test.body)))
+		if recorder.Code != test.status {
+			t.Errorf("%s %s %s: status %d, want %d: %s", test.method, test.path, test.body, recorder.Code, test.status, recorder.Body)
+		}
+	}
+}
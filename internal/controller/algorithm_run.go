@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mango/internal/algorithm"
+	"mango/internal/model"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// runUser 解析 ?userId，为空时是匿名调用，用户不存在时返回 ErrNotFound，没有配置数据库时返回 ErrStoreUnavailable
+func (v *AlgorithmHandler) runUser(c *gin.Context) (uint, error) {
+	raw := c.Query("userId")
+	if raw == "" {
+		return 0, nil
+	}
+	userID, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil || userID == 0 {
+		return 0, fmt.Errorf("%w: userId must be a positive integer, got %q", algorithm.ErrInvalidInput, raw)
+	}
+	if v.UserService == nil {
+		return 0, fmt.Errorf("user %d: %w", userID, ErrStoreUnavailable)
+	}
+	if _, err := v.UserService.GetByID(c.Request.Context(), uint(userID)); err != nil {
+		return 0, fmt.Errorf("%w: user %d", algorithm.ErrNotFound, userID)
+	}
+	return uint(userID), nil
+}
+
+// saveRun 记录一次实际执行，partial 的结果不会被重放；没有配置数据库时不记录，保存失败时只记日志，都返回 id 为 0 的空记录
+func (v *AlgorithmHandler) saveRun(ctx context.Context, userID uint, name string, input, output []byte, partial bool, elapsed time.Duration, runErr error) *model.AlgorithmRun {
+	if v.runService == nil {
+		return &model.AlgorithmRun{}
+	}
+	run, err := v.runService.Save(ctx, userID, name, input, output, partial, elapsed, runErr)
+	if err != nil {
+		logrus.Errorf("save run of %s: %v", name, err)
+		return &model.AlgorithmRun{}
+	}
+	return run
+}
+
+// replayRun 相同输入已经成功且完整地执行过时返回记录下来的重放，否则（包括没有配置数据库）返回 nil
+func (v *AlgorithmHandler) replayRun(ctx context.Context, userID uint, name string, input []byte) *model.AlgorithmRun {
+	if v.runService == nil {
+		return nil
+	}
+	run, err := v.runService.Replay(ctx, userID, name, input)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logrus.Errorf("replay run of %s: %v", name, err)
+		}
+		return nil
+	}
+	return run
+}
+
+// partialResult 结果不完整（例如回溯搜索达到解数上限或超时），这样的结果不能重放
+func partialResult(result interface{}) bool {
+	partial, ok := result.(interface{ Partial() bool })
+	return ok && partial.Partial()
+}
+
+// runs 查询运行历史，可按 user_id、name、input_hash、status（ok / error）过滤，最新的在前；没有配置数据库时返回 503
+func (v *AlgorithmHandler) runs(c *gin.Context) {
+	if v.runService == nil {
+		algorithmError(c, algorithmStatus(ErrStoreUnavailable), "runs", fmt.Errorf("run history: %w", ErrStoreUnavailable))
+		return
+	}
+	page, pageSize := pageQuery(c)
+	userID, _ := strconv.ParseUint(c.Query("user_id"), 10, 32)
+	status := c.Query("status")
+	if status != "" && status != "ok" && status != "error" {
+		algorithmError(c, http.StatusBadRequest, "runs", fmt.Errorf("status must be ok or error, got %q", status))
+		return
+	}
+	runs, err := v.runService.List(c.Request.Context(), uint(userID), c.Query("name"), c.Query("input_hash"), status, page, pageSize)
+	if err != nil {
+		algorithmError(c, http.StatusInternalServerError, "runs", err)
+		return
+	}
+	algorithmSuccess(c, "runs", gin.H{"runs": runs}, nil)
+}
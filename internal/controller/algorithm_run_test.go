@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"mango/internal/model"
+	"mango/internal/repository"
+	"mango/internal/service"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// runServiceStub 内存里的运行历史，重放规则与 AlgorithmRunRepository.FindSucceeded 相同
+type runServiceStub struct {
+	runs           []*model.AlgorithmRun
+	page, pageSize int
+}
+
+func (*runServiceStub) Close() error    { return nil }
+func (*runServiceStub) Available() bool { return true }
+
+func (s *runServiceStub) Save(ctx context.Context, userID uint, name string, input, output []byte, partial bool, duration time.Duration, runErr error) (*model.AlgorithmRun, error) {
+	run := &model.AlgorithmRun{ID: uint(len(s.runs) + 1), UserID: userID, Name: name, InputHash: service.InputHash(input),
+		Input: string(input), Output: string(output), Partial: partial}
+	if runErr != nil {
+		run.Output, run.Error = "", runErr.Error()
+	}
+	s.runs = append(s.runs, run)
+	return run, nil
+}
+
+func (s *runServiceStub) Replay(ctx context.Context, userID uint, name string, input []byte) (*model.AlgorithmRun, error) {
+	for i := len(s.runs) - 1; i >= 0; i-- {
+		stored := s.runs[i]
+		if stored.Name == name && stored.InputHash == service.InputHash(input) && stored.Error == "" && !stored.Partial && stored.ReplayOf == 0 {
+			run := &model.AlgorithmRun{ID: uint(len(s.runs) + 1), UserID: userID, Name: name, InputHash: stored.InputHash,
+				Input: stored.Input, Output: stored.Output, ReplayOf: stored.ID}
+			s.runs = append(s.runs, run)
+			return run, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *runServiceStub) List(ctx context.Context, userID uint, name, inputHash, status string, page, pageSize int) ([]*model.AlgorithmRun, error) {
+	s.page, s.pageSize = page, pageSize
+	return s.runs, nil
+}
+
+// postRun 调用算法接口，返回状态码和解析后的响应
+func postRun(t *testing.T, router *gin.Engine, path, body string) (int, AlgorithmResponse) {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	var response AlgorithmResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("%s: %v: %s", path, err, recorder.Body)
+	}
+	return recorder.Code, response
+}
+
+// TestRunReplay 字段顺序不同的相同输入重放第一次的结果，不完整的回溯结果只记录不重放
+func TestRunReplay(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	runService := &runServiceStub{}
+	router := gin.New()
+	NewAlgorithmHandler(nil, nil, nil, runService).Register(router.Group("/api"))
+
+	_, first := postRun(t, router, "/api/algorithm/combinationSum3", `{"k":3,"n":9}`)
+	_, again := postRun(t, router, "/api/algorithm/combinationSum3", `{ "n": 9, "k": 3 }`)
+	if first.RunID == 0 || first.ReplayOf != 0 || again.ReplayOf != first.RunID {
+		t.Fatalf("complete run %d replayed as %d of %d, want a replay of the first run", first.RunID, again.RunID, again.ReplayOf)
+	}
+
+	_, truncated := postRun(t, router, "/api/algorithm/combinationSum3", `{"k":3,"n":9,"maxSolutions":1}`)
+	_, rerun := postRun(t, router, "/api/algorithm/combinationSum3", `{"k":3,"n":9,"maxSolutions":1}`)
+	if truncated.ReplayOf != 0 || rerun.ReplayOf != 0 || rerun.RunID == truncated.RunID {
+		t.Fatalf("truncated run %d was replayed by %d (replayOf %d)", truncated.RunID, rerun.RunID, rerun.ReplayOf)
+	}
+	if stored := runService.runs[truncated.RunID-1]; !stored.Partial {
+		t.Fatalf("truncated run stored without partial: %+v", stored)
+	}
+}
+
+// TestRunWithoutDatabase 没有配置数据库时算法照常执行但不记录，指定用户和查询历史返回 503
+func TestRunWithoutDatabase(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := &gorm.DB{}
+	userService := service.NewUserService(repository.NewUserRepository(db))
+	runService := service.NewAlgorithmRunService(repository.NewAlgorithmRunRepository(db))
+	router := gin.New()
+	NewAlgorithmHandler(userService, nil, nil, runService).Register(router.Group("/api"))
+
+	if status, response := postRun(t, router, "/api/algorithm/combinationSum3", `{"k":3,"n":9}`); status != http.StatusOK || response.RunID != 0 {
+		t.Errorf("anonymous run: status %d run %d, want 200 without a run id", status, response.RunID)
+	}
+	if status, response := postRun(t, router, "/api/algorithm/combinationSum3?userId=1", `{"k":3,"n":9}`); status != http.StatusServiceUnavailable {
+		t.Errorf("run with userId: status %d %s, want 503", status, response.Error)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/algorithm/runs", nil))
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf("run history: status %d, want 503: %s", recorder.Code, recorder.Body)
+	}
+}
+
+// TestRunsPaging 列表的 page 至少为 1，page_size 限制在 [1, MaxPageSize]
+func TestRunsPaging(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	runService := &runServiceStub{}
+	router := gin.New()
+	NewAlgorithmHandler(nil, nil, nil, runService).Register(router.Group("/api"))
+
+	tests := []struct {
+		query          string
+		page, pageSize int
+	}{
+		{"", 1, 10},
+		{"?page=0&page_size=0", 1, 1},
+		{"?page=-3&page_size=-1", 1, 1},
+		{"?page=2&page_size=1000000", 2, MaxPageSize},
+	}
+	for _, test := range tests {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/algorithm/runs"+test.query, nil))
+		if recorder.Code != http.StatusOK || runService.page != test.page || runService.pageSize != test.pageSize {
+			t.Errorf("%q: status %d page %d size %d, want %d %d", test.query, recorder.Code, runService.page, runService.pageSize, test.page, test.pageSize)
+		}
+	}
+}
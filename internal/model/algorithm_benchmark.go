@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// AlgorithmBenchmark 算法 benchmark 结果，按版本保存以便跨版本比较
+type AlgorithmBenchmark struct {
+	ID         uint      `json:"id" gorm:"column:id"`
+	Name       string    `json:"name" gorm:"column:name"`             // bench 名称
+	Version    string    `json:"version" gorm:"column:version"`       // 发布版本
+	Seed       int64     `json:"seed" gorm:"column:seed"`             // 随机种子
+	Complexity string    `json:"complexity" gorm:"column:complexity"` // 估计的复杂度
+	Exponent   float64   `json:"exponent" gorm:"column:exponent"`     // log-log 斜率
+	Result     string    `json:"result" gorm:"column:result"`         // 完整结果 json
+	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (AlgorithmBenchmark) TableName() string {
+	return "algorithm_benchmark"
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// AlgorithmTestCase 评测用的测试输入，hidden 为 true 时列表和评测结果都不返回输入、说明、期望输出和差异
+type AlgorithmTestCase struct {
+	ID        uint      `json:"id" gorm:"column:id"`
+	Problem   string    `json:"problem" gorm:"column:problem"`       // 算法名
+	Input     string    `json:"input,omitempty" gorm:"column:input"` // 输入 json
+	Hidden    bool      `json:"hidden" gorm:"column:hidden"`         // 是否隐藏期望输出
+	Note      string    `json:"note,omitempty" gorm:"column:note"`   // 说明，例如覆盖的边界情况
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (AlgorithmTestCase) TableName() string {
+	return "algorithm_test_case"
+}
+
+// AlgorithmSubmission 一次评测提交
+type AlgorithmSubmission struct {
+	ID         uint      `json:"id" gorm:"column:id"`
+	UserID     uint      `json:"user_id" gorm:"column:user_id"`           // 提交用户，0 表示匿名
+	Problem    string    `json:"problem" gorm:"column:problem"`           // 算法名
+	TestCaseID uint      `json:"test_case_id" gorm:"column:test_case_id"` // 测试用例，0 表示自带输入
+	Input      string    `json:"input,omitempty" gorm:"column:input"`     // 输入 json，隐藏用例为空
+	Answer     string    `json:"answer" gorm:"column:answer"`             // 提交的答案 json
+	Verdict    string    `json:"verdict" gorm:"column:verdict"`           // accepted / wrong_answer / invalid_answer
+	Message    string    `json:"message" gorm:"column:message"`           // 判定说明
+	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (AlgorithmSubmission) TableName() string {
+	return "algorithm_submission"
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// AlgorithmRun 一次算法调用，相同算法和 input_hash 的成功且完整的结果可以直接重放
+type AlgorithmRun struct {
+	ID         uint      `json:"id" gorm:"column:id"`
+	UserID     uint      `json:"user_id" gorm:"column:user_id"`         // 调用用户，0 表示匿名
+	Name       string    `json:"name" gorm:"column:name"`               // 算法名
+	InputHash  string    `json:"input_hash" gorm:"column:input_hash"`   // 规范化输入的 sha256
+	Input      string    `json:"input" gorm:"column:input"`             // 规范化的输入 json，解析失败时为原始请求体
+	Output     string    `json:"output" gorm:"column:output"`           // 结果 json，失败时为空
+	Error      string    `json:"error" gorm:"column:error"`             // 错误信息，成功时为空
+	Partial    bool      `json:"partial" gorm:"column:partial"`         // 结果不完整（回溯搜索达到解数上限或超时），不会被重放
+	DurationUs int64     `json:"duration_us" gorm:"column:duration_us"` // 执行耗时（微秒），重放为 0
+	ReplayOf   uint      `json:"replay_of" gorm:"column:replay_of"`     // 重放的原始记录，0 表示实际执行
+	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (AlgorithmRun) TableName() string {
+	return "algorithm_run"
+}
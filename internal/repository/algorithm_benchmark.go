@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"mango/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// AlgorithmBenchmarkRepository benchmark 结果仓库接口
+type AlgorithmBenchmarkRepository interface {
+	Repository
+	// Available 数据库连接已经配置
+	Available() bool
+	Create(ctx context.Context, benchmark *model.AlgorithmBenchmark) error
+	List(ctx context.Context, name, version string, offset, limit int) ([]*model.AlgorithmBenchmark, error)
+}
+
+// AlgorithmBenchmarkRepositoryS benchmark 结果仓库实现
+type AlgorithmBenchmarkRepositoryS struct {
+	db *gorm.DB
+}
+
+// NewAlgorithmBenchmarkRepository 创建 benchmark 结果仓库
+func NewAlgorithmBenchmarkRepository(db *gorm.DB) AlgorithmBenchmarkRepository {
+	return &AlgorithmBenchmarkRepositoryS{db: db}
+}
+
+func (r *AlgorithmBenchmarkRepositoryS) Close() error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+func (r *AlgorithmBenchmarkRepositoryS) Available() bool {
+	return available(r.db)
+}
+
+func (r *AlgorithmBenchmarkRepositoryS) Create(ctx context.Context, benchmark *model.AlgorithmBenchmark) error {
+	return r.db.WithContext(ctx).Create(benchmark).Error
+}
+
+// List 按名称、版本过滤，为空时不过滤，最新的在前
+func (r *AlgorithmBenchmarkRepositoryS) List(ctx context.Context, name, version string, offset, limit int) ([]*model.AlgorithmBenchmark, error) {
+	var benchmarks []*model.AlgorithmBenchmark
+	query := r.db.WithContext(ctx)
+	if name != "" {
+		query = query.Where("name = ?", name)
+	}
+	if version != "" {
+		query = query.Where("version = ?", version)
+	}
+	if err := query.Order("id desc").Offset(offset).Limit(limit).Find(&benchmarks).Error; err != nil {
+		return nil, err
+	}
+	return benchmarks, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+
+	"mango/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// AlgorithmTestCaseRepository 评测用例仓库接口
+type AlgorithmTestCaseRepository interface {
+	Repository
+	// Available 数据库连接已经配置
+	Available() bool
+	Create(ctx context.Context, testCase *model.AlgorithmTestCase) error
+	FindByID(ctx context.Context, id uint) (*model.AlgorithmTestCase, error)
+	List(ctx context.Context, problem string, offset, limit int) ([]*model.AlgorithmTestCase, error)
+}
+
+// AlgorithmTestCaseRepositoryS 评测用例仓库实现
+type AlgorithmTestCaseRepositoryS struct {
+	db *gorm.DB
+}
+
+// NewAlgorithmTestCaseRepository 创建评测用例仓库
+func NewAlgorithmTestCaseRepository(db *gorm.DB) AlgorithmTestCaseRepository {
+	return &AlgorithmTestCaseRepositoryS{db: db}
+}
+
+func (r *AlgorithmTestCaseRepositoryS) Close() error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+func (r *AlgorithmTestCaseRepositoryS) Available() bool {
+	return available(r.db)
+}
+
+func (r *AlgorithmTestCaseRepositoryS) Create(ctx context.Context, testCase *model.AlgorithmTestCase) error {
+	return r.db.WithContext(ctx).Create(testCase).Error
+}
+
+func (r *AlgorithmTestCaseRepositoryS) FindByID(ctx context.Context, id uint) (*model.AlgorithmTestCase, error) {
+	var testCase model.AlgorithmTestCase
+	if err := r.db.WithContext(ctx).First(&testCase, id).Error; err != nil {
+		return nil, err
+	}
+	return &testCase, nil
+}
+
+// List 按算法名过滤，为空时不过滤，按 id 升序
+func (r *AlgorithmTestCaseRepositoryS) List(ctx context.Context, problem string, offset, limit int) ([]*model.AlgorithmTestCase, error) {
+	var testCases []*model.AlgorithmTestCase
+	query := r.db.WithContext(ctx)
+	if problem != "" {
+		query = query.Where("problem = ?", problem)
+	}
+	if err := query.Order("id").Offset(offset).Limit(limit).Find(&testCases).Error; err != nil {
+		return nil, err
+	}
+	return testCases, nil
+}
+
+// AlgorithmSubmissionRepository 评测提交仓库接口
+type AlgorithmSubmissionRepository interface {
+	Repository
+	// Available 数据库连接已经配置
+	Available() bool
+	Create(ctx context.Context, submission *model.AlgorithmSubmission) error
+	List(ctx context.Context, userID uint, problem, verdict string, offset, limit int) ([]*model.AlgorithmSubmission, error)
+}
+
+// AlgorithmSubmissionRepositoryS 评测提交仓库实现
+type AlgorithmSubmissionRepositoryS struct {
+	db *gorm.DB
+}
+
+// NewAlgorithmSubmissionRepository 创建评测提交仓库
+func NewAlgorithmSubmissionRepository(db *gorm.DB) AlgorithmSubmissionRepository {
+	return &AlgorithmSubmissionRepositoryS{db: db}
+}
+
+func (r *AlgorithmSubmissionRepositoryS) Close() error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+func (r *AlgorithmSubmissionRepositoryS) Available() bool {
+	return available(r.db)
+}
+
+func (r *AlgorithmSubmissionRepositoryS) Create(ctx context.Context, submission *model.AlgorithmSubmission) error {
+	return r.db.WithContext(ctx).Create(submission).Error
+}
+
+// List 按用户、算法名、判定结果过滤，为零值时不过滤，最新的在前
+func (r *AlgorithmSubmissionRepositoryS) List(ctx context.Context, userID uint, problem, verdict string, offset, limit int) ([]*model.AlgorithmSubmission, error) {
+	var submissions []*model.AlgorithmSubmission
+	query := r.db.WithContext(ctx)
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if problem != "" {
+		query = query.Where("problem = ?", problem)
+	}
+	if verdict != "" {
+		query = query.Where("verdict = ?", verdict)
+	}
+	if err := query.Order("id desc").Offset(offset).Limit(limit).Find(&submissions).Error; err != nil {
+		return nil, err
+	}
+	return submissions, nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+
+	"mango/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// AlgorithmRunRepository 算法调用记录仓库接口
+type AlgorithmRunRepository interface {
+	Repository
+	// Available 数据库连接已经配置
+	Available() bool
+	Create(ctx context.Context, run *model.AlgorithmRun) error
+	FindSucceeded(ctx context.Context, name, inputHash string) (*model.AlgorithmRun, error)
+	List(ctx context.Context, userID uint, name, inputHash, status string, offset, limit int) ([]*model.AlgorithmRun, error)
+}
+
+// AlgorithmRunRepositoryS 算法调用记录仓库实现
+type AlgorithmRunRepositoryS struct {
+	db *gorm.DB
+}
+
+// NewAlgorithmRunRepository 创建算法调用记录仓库
+func NewAlgorithmRunRepository(db *gorm.DB) AlgorithmRunRepository {
+	return &AlgorithmRunRepositoryS{db: db}
+}
+
+func (r *AlgorithmRunRepositoryS) Close() error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+func (r *AlgorithmRunRepositoryS) Available() bool {
+	return available(r.db)
+}
+
+func (r *AlgorithmRunRepositoryS) Create(ctx context.Context, run *model.AlgorithmRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+// FindSucceeded 相同输入最近一次实际执行、成功且结果完整的记录，没有时返回 gorm.ErrRecordNotFound
+func (r *AlgorithmRunRepositoryS) FindSucceeded(ctx context.Context, name, inputHash string) (*model.AlgorithmRun, error) {
+	var run model.AlgorithmRun
+	err := r.db.WithContext(ctx).
+		Where("name = ? AND input_hash = ?", name, inputHash).
+		Where("error = ? AND partial = ? AND replay_of = ?", "", false, 0).
+		Order("id desc").First(&run).Error
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// List 按用户、算法名、输入 hash、状态（ok / error）过滤，为零值时不过滤，最新的在前
+func (r *AlgorithmRunRepositoryS) List(ctx context.Context, userID uint, name, inputHash, status string, offset, limit int) ([]*model.AlgorithmRun, error) {
+	var runs []*model.AlgorithmRun
+	query := r.db.WithContext(ctx)
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if name != "" {
+		query = query.Where("name = ?", name)
+	}
+	if inputHash != "" {
+		query = query.Where("input_hash = ?", inputHash)
+	}
+	switch status {
+	case "ok":
+		query = query.Where("error = ?", "")
+	case "error":
+		query = query.Where("error != ?", "")
+	}
+	if err := query.Order("id desc").Offset(offset).Limit(limit).Find(&runs).Error; err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
@@ -1,6 +1,13 @@
 package repository
 
+import "gorm.io/gorm"
+
 // Repository 基础仓库接口
 type Repository interface {
 	Close() error
 }
+
+// available 数据库连接已经配置；没有配置数据库时注入的 &gorm.DB{} 调用任何方法都会 panic
+func available(db *gorm.DB) bool {
+	return db != nil && db.Config != nil && db.ConnPool != nil
+}
@@ -11,6 +11,8 @@ import (
 // UserRepository 用户仓库接口
 type UserRepository interface {
 	Repository
+	// Available 数据库连接已经配置
+	Available() bool
 	FindByID(ctx context.Context, id uint) (*model.User, error)
 	FindByUsername(ctx context.Context, username string) (*model.User, error)
 	FindByEmail(ctx context.Context, email string) (*model.User, error)
@@ -38,6 +40,10 @@ func (r *UserRepositoryS) Close() error {
 	return sqlDB.Close()
 }
 
+func (r *UserRepositoryS) Available() bool {
+	return available(r.db)
+}
+
 func (r *UserRepositoryS) FindByID(ctx context.Context, id uint) (*model.User, error) {
 	var user model.User
 	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
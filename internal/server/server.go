@@ -2,7 +2,9 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"mango/internal/controller"
 	"net/http"
 	"time"
@@ -52,12 +54,18 @@ func (s *Server) Start() error {
 	return s.httpServer.ListenAndServe()
 }
 
-// Stop 停止服务器
+// Stop 停止服务器，之后关闭持有后台资源（实现了 io.Closer）的 handler
 func (s *Server) Stop() error {
+	var err error
 	if s.httpServer != nil {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
-		return s.httpServer.Shutdown(ctx)
+		err = s.httpServer.Shutdown(ctx)
 	}
-	return nil
+	for _, h := range s.handlers {
+		if closer, ok := h.(io.Closer); ok {
+			err = errors.Join(err, closer.Close())
+		}
+	}
+	return err
 }
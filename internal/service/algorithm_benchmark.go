@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"mango/internal/algorithm"
+	"mango/internal/model"
+	"mango/internal/repository"
+)
+
+// AlgorithmBenchmarkService benchmark 结果服务接口
+type AlgorithmBenchmarkService interface {
+	Service
+	// Available 数据库连接已经配置，为 false 时不能调用其他方法
+	Available() bool
+	Save(ctx context.Context, report algorithm.BenchReport) (*model.AlgorithmBenchmark, error)
+	List(ctx context.Context, name, version string, page, pageSize int) ([]*model.AlgorithmBenchmark, error)
+}
+
+// AlgorithmBenchmarkServiceS benchmark 结果服务实现
+type AlgorithmBenchmarkServiceS struct {
+	benchRepo repository.AlgorithmBenchmarkRepository
+}
+
+// NewAlgorithmBenchmarkService 创建 benchmark 结果服务
+func NewAlgorithmBenchmarkService(benchRepo repository.AlgorithmBenchmarkRepository) AlgorithmBenchmarkService {
+	return &AlgorithmBenchmarkServiceS{benchRepo: benchRepo}
+}
+
+func (s *AlgorithmBenchmarkServiceS) Close() error {
+	return s.benchRepo.Close()
+}
+
+func (s *AlgorithmBenchmarkServiceS) Available() bool {
+	return s.benchRepo.Available()
+}
+
+func (s *AlgorithmBenchmarkServiceS) Save(ctx context.Context, report algorithm.BenchReport) (*model.AlgorithmBenchmark, error) {
+	result, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	benchmark := &model.AlgorithmBenchmark{
+		Name:       report.Name,
+		Version:    report.Release,
+		Seed:       report.Seed,
+		Complexity: report.Complexity,
+		Exponent:   report.Exponent,
+		Result:     string(result),
+		CreatedAt:  time.Now(),
+	}
+	if err := s.benchRepo.Create(ctx, benchmark); err != nil {
+		return nil, err
+	}
+	return benchmark, nil
+}
+
+func (s *AlgorithmBenchmarkServiceS) List(ctx context.Context, name, version string, page, pageSize int) ([]*model.AlgorithmBenchmark, error) {
+	offset := (page - 1) * pageSize
+	return s.benchRepo.List(ctx, name, version, offset, pageSize)
+}
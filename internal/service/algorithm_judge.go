@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"mango/internal/algorithm"
+	"mango/internal/model"
+	"mango/internal/repository"
+)
+
+// AlgorithmJudgeService 评测用例和提交记录服务接口
+type AlgorithmJudgeService interface {
+	Service
+	// Available 数据库连接已经配置，为 false 时不能调用其他方法
+	Available() bool
+	CreateTestCase(ctx context.Context, problem string, input []byte, hidden bool, note string) (*model.AlgorithmTestCase, error)
+	GetTestCase(ctx context.Context, id uint) (*model.AlgorithmTestCase, error)
+	ListTestCases(ctx context.Context, problem string, page, pageSize int) ([]*model.AlgorithmTestCase, error)
+	SaveSubmission(ctx context.Context, userID, testCaseID uint, input, answer []byte, verdict *algorithm.Verdict) (*model.AlgorithmSubmission, error)
+	ListSubmissions(ctx context.Context, userID uint, problem, verdict string, page, pageSize int) ([]*model.AlgorithmSubmission, error)
+}
+
+// AlgorithmJudgeServiceS 评测服务实现
+type AlgorithmJudgeServiceS struct {
+	testCaseRepo   repository.AlgorithmTestCaseRepository
+	submissionRepo repository.AlgorithmSubmissionRepository
+}
+
+// NewAlgorithmJudgeService 创建评测服务
+func NewAlgorithmJudgeService(testCaseRepo repository.AlgorithmTestCaseRepository, submissionRepo repository.AlgorithmSubmissionRepository) AlgorithmJudgeService {
+	return &AlgorithmJudgeServiceS{testCaseRepo: testCaseRepo, submissionRepo: submissionRepo}
+}
+
+func (s *AlgorithmJudgeServiceS) Close() error {
+	return errors.Join(s.testCaseRepo.Close(), s.submissionRepo.Close())
+}
+
+func (s *AlgorithmJudgeServiceS) Available() bool {
+	return s.testCaseRepo.Available() && s.submissionRepo.Available()
+}
+
+func (s *AlgorithmJudgeServiceS) CreateTestCase(ctx context.Context, problem string, input []byte, hidden bool, note string) (*model.AlgorithmTestCase, error) {
+	testCase := &model.AlgorithmTestCase{
+		Problem:   problem,
+		Input:     string(input),
+		Hidden:    hidden,
+		Note:      note,
+		CreatedAt: time.Now(),
+	}
+	if err := s.testCaseRepo.Create(ctx, testCase); err != nil {
+		return nil, err
+	}
+	return testCase, nil
+}
+
+func (s *AlgorithmJudgeServiceS) GetTestCase(ctx context.Context, id uint) (*model.AlgorithmTestCase, error) {
+	return s.testCaseRepo.FindByID(ctx, id)
+}
+
+func (s *AlgorithmJudgeServiceS) ListTestCases(ctx context.Context, problem string, page, pageSize int) ([]*model.AlgorithmTestCase, error) {
+	offset := (page - 1) * pageSize
+	return s.testCaseRepo.List(ctx, problem, offset, pageSize)
+}
+
+func (s *AlgorithmJudgeServiceS) SaveSubmission(ctx context.Context, userID, testCaseID uint, input, answer []byte, verdict *algorithm.Verdict) (*model.AlgorithmSubmission, error) {
+	submission := &model.AlgorithmSubmission{
+		UserID:     userID,
+		Problem:    verdict.Problem,
+		TestCaseID: testCaseID,
+		Input:      string(input),
+		Answer:     string(answer),
+		Verdict:    verdict.Status,
+		Message:    verdict.Message,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.submissionRepo.Create(ctx, submission); err != nil {
+		return nil, err
+	}
+	return submission, nil
+}
+
+func (s *AlgorithmJudgeServiceS) ListSubmissions(ctx context.Context, userID uint, problem, verdict string, page, pageSize int) ([]*model.AlgorithmSubmission, error) {
+	offset := (page - 1) * pageSize
+	return s.submissionRepo.List(ctx, userID, problem, verdict, offset, pageSize)
+}
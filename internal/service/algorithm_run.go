@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"mango/internal/model"
+	"mango/internal/repository"
+)
+
+// AlgorithmRunService 算法调用记录服务接口
+type AlgorithmRunService interface {
+	Service
+	// Available 数据库连接已经配置，为 false 时不能调用其他方法
+	Available() bool
+	Save(ctx context.Context, userID uint, name string, input, output []byte, partial bool, duration time.Duration, runErr error) (*model.AlgorithmRun, error)
+	Replay(ctx context.Context, userID uint, name string, input []byte) (*model.AlgorithmRun, error)
+	List(ctx context.Context, userID uint, name, inputHash, status string, page, pageSize int) ([]*model.AlgorithmRun, error)
+}
+
+// AlgorithmRunServiceS 算法调用记录服务实现
+type AlgorithmRunServiceS struct {
+	runRepo repository.AlgorithmRunRepository
+}
+
+// NewAlgorithmRunService 创建算法调用记录服务
+func NewAlgorithmRunService(runRepo repository.AlgorithmRunRepository) AlgorithmRunService {
+	return &AlgorithmRunServiceS{runRepo: runRepo}
+}
+
+func (s *AlgorithmRunServiceS) Close() error {
+	return s.runRepo.Close()
+}
+
+func (s *AlgorithmRunServiceS) Available() bool {
+	return s.runRepo.Available()
+}
+
+// InputHash 输入 json 的 sha256，输入应当先规范化，使字段顺序和空白不同的相同输入得到相同的 hash
+func InputHash(input []byte) string {
+	sum := sha256.Sum256(input)
+	return hex.EncodeToString(sum[:])
+}
+
+// Save 记录一次实际执行，runErr 不为 nil 时 output 被忽略；partial 的结果只记录，不会被 Replay 找到
+func (s *AlgorithmRunServiceS) Save(ctx context.Context, userID uint, name string, input, output []byte, partial bool, duration time.Duration, runErr error) (*model.AlgorithmRun, error) {
+	run := &model.AlgorithmRun{
+		UserID:     userID,
+		Name:       name,
+		InputHash:  InputHash(input),
+		Input:      string(input),
+		Output:     string(output),
+		Partial:    partial,
+		DurationUs: duration.Microseconds(),
+		CreatedAt:  time.Now(),
+	}
+	if runErr != nil {
+		run.Output, run.Error = "", runErr.Error()
+	}
+	if err := s.runRepo.Create(ctx, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// Replay 查找相同输入最近一次成功且完整执行的结果，找到时记录一次重放并返回它，没有时返回 gorm.ErrRecordNotFound
+func (s *AlgorithmRunServiceS) Replay(ctx context.Context, userID uint, name string, input []byte) (*model.AlgorithmRun, error) {
+	stored, err := s.runRepo.FindSucceeded(ctx, name, InputHash(input))
+	if err != nil {
+		return nil, err
+	}
+	run := &model.AlgorithmRun{
+		UserID:    userID,
+		Name:      name,
+		InputHash: stored.InputHash,
+		Input:     stored.Input,
+		Output:    stored.Output,
+		ReplayOf:  stored.ID,
+		CreatedAt: time.Now(),
+	}
+	if err := s.runRepo.Create(ctx, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+func (s *AlgorithmRunServiceS) List(ctx context.Context, userID uint, name, inputHash, status string, page, pageSize int) ([]*model.AlgorithmRun, error) {
+	offset := (page - 1) * pageSize
+	return s.runRepo.List(ctx, userID, name, inputHash, status, offset, pageSize)
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mango/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// runRepositoryStub 内存里的运行记录，FindSucceeded 的过滤条件与 SQL 查询相同
+type runRepositoryStub struct {
+	runs []*model.AlgorithmRun
+}
+
+func (*runRepositoryStub) Close() error    { return nil }
+func (*runRepositoryStub) Available() bool { return true }
+
+func (r *runRepositoryStub) Create(ctx context.Context, run *model.AlgorithmRun) error {
+	run.ID = uint(len(r.runs) + 1)
+	r.runs = append(r.runs, run)
+	return nil
+}
+
+func (r *runRepositoryStub) FindSucceeded(ctx context.Context, name, inputHash string) (*model.AlgorithmRun, error) {
+	for i := len(r.runs) - 1; i >= 0; i-- {
+		run := r.runs[i]
+		if run.Name == name && run.InputHash == inputHash && run.Error == "" && !run.Partial && run.ReplayOf == 0 {
+			return run, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *runRepositoryStub) List(ctx context.Context, userID uint, name, inputHash, status string, offset, limit int) ([]*model.AlgorithmRun, error) {
+	return r.runs, nil
+}
+
+// TestInputHash 相同输入得到相同的 sha256，不同输入不同
+func TestInputHash(t *testing.T) {
+	const empty = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	if got := InputHash(nil); got != empty {
+		t.Errorf("InputHash(nil) = %s, want %s", got, empty)
+	}
+	if InputHash([]byte(`{"k":3}`)) != InputHash([]byte(`{"k":3}`)) || InputHash([]byte(`{"k":3}`)) == InputHash([]byte(`{"k":4}`)) {
+		t.Error("InputHash is not a function of the input")
+	}
+}
+
+// TestRunReplay 只重放成功且完整的实际执行，重放本身也会记录
+func TestRunReplay(t *testing.T) {
+	ctx := context.Background()
+	s := NewAlgorithmRunService(&runRepositoryStub{})
+	input := []byte(`{"k":3,"n":9}`)
+	if _, err := s.Replay(ctx, 0, "combinationSum3", input); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("replay without history: err = %v", err)
+	}
+	s.Save(ctx, 0, "combinationSum3", input, []byte(`{"count":1}`), true, time.Millisecond, nil)
+	s.Save(ctx, 0, "combinationSum3", input, nil, false, time.Millisecond, errors.New("budget exceeded"))
+	if _, err := s.Replay(ctx, 0, "combinationSum3", input); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("partial or failed runs were replayed: err = %v", err)
+	}
+	stored, _ := s.Save(ctx, 0, "combinationSum3", input, []byte(`{"count":3}`), false, time.Millisecond, nil)
+	run, err := s.Replay(ctx, 7, "combinationSum3", input)
+	if err != nil || run.ReplayOf != stored.ID || run.Output != `{"count":3}` || run.UserID != 7 || run.ID == stored.ID {
+		t.Fatalf("Replay = %+v, %v, want a new record replaying %d", run, err, stored.ID)
+	}
+	if again, _ := s.Replay(ctx, 0, "combinationSum3", input); again.ReplayOf != stored.ID {
+		t.Fatalf("replay of a replay: replayOf %d, want %d", again.ReplayOf, stored.ID)
+	}
+}
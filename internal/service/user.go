@@ -14,6 +14,8 @@ import (
 // UserService 用户服务接口
 type UserService interface {
 	Service
+	// Available 数据库连接已经配置，为 false 时不能调用其他方法
+	Available() bool
 	GetByID(ctx context.Context, id uint) (*model.User, error)
 	Regist(ctx context.Context, username, email, password string) (*model.User, error)
 	Login(ctx context.Context, username, password string) (*model.User, error)
@@ -36,6 +38,10 @@ func (s *UserServiceS) Close() error {
 	return s.userRepo.Close()
 }
 
+func (s *UserServiceS) Available() bool {
+	return s.userRepo.Available()
+}
+
 func (s *UserServiceS) GetByID(ctx context.Context, id uint) (*model.User, error) {
 	return s.userRepo.FindByID(ctx, id)
 }